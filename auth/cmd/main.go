@@ -7,12 +7,15 @@ import (
 	"FinanceTracker/auth/internal/producer"
 	"FinanceTracker/auth/internal/repo"
 	"FinanceTracker/auth/internal/service"
+	authPb "FinanceTracker/auth/pkg/api/auth"
 	"context"
 	"os/signal"
 	"syscall"
 
+	"FinanceTracker/auth/pkg/health"
 	log "FinanceTracker/auth/pkg/logger"
 	"FinanceTracker/auth/pkg/postgres"
+	"FinanceTracker/auth/pkg/tracing"
 	"FinanceTracker/auth/pkg/transaction"
 
 	"github.com/joho/godotenv"
@@ -22,6 +25,8 @@ func main() {
 	conf := config.New()
 	logger := log.New(conf.Env)
 
+	tracer := tracing.MustNew(context.Background(), "auth", conf.Tracing.Exporter, conf.Tracing.Endpoint)
+
 	postgres := postgres.MustNew(conf.PostgresURL)
 	defer postgres.Close()
 	logger.Info("postgres connected")
@@ -33,15 +38,23 @@ func main() {
 	authService := service.NewAuthService(userRepo, otpRepo, producer, txManager, conf.JwtTTL, conf.JwtSecret)
 	authController := controller.NewAuthController(authService, conf.OAuth)
 
-	app := app.New(logger, authController)
+	checks := health.New()
+	checks.Add("postgres", postgres.PingContext)
+	checks.Add("kafka", health.Kafka(conf.KafkaBrokers))
+	// sessions only need the database, the gateway keeps routing to auth
+	// while kafka is down
+	checks.Service(authPb.AuthService_ServiceDesc.ServiceName, "postgres")
+
+	app := app.New(logger, checks, authController)
 
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
 	defer stop()
 
-	app.Start(conf.Host, conf.Port)
+	app.Start(conf.Host, conf.Port, conf.HttpPort)
 	<-ctx.Done()
 	app.Stop()
 	producer.Close()
+	tracer.Shutdown(context.Background())
 }
 
 func init() {
@@ -1,38 +1,56 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"net"
+	"net/http"
 	"os"
+	"time"
 
+	"FinanceTracker/auth/pkg/health"
 	log "FinanceTracker/auth/pkg/logger"
+	"FinanceTracker/auth/pkg/metrics"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 )
 
 type app struct {
-	logger *slog.Logger
-	srv    *grpc.Server
+	logger  *slog.Logger
+	health  *health.Health
+	srv     *grpc.Server
+	httpSrv *http.Server
 }
 
 type Controller interface {
 	Register(server *grpc.Server)
 }
 
-func New(logger *slog.Logger, controllers ...Controller) *app {
+func New(logger *slog.Logger, health *health.Health, controllers ...Controller) *app {
 	server := grpc.NewServer(
-		grpc.UnaryInterceptor(log.UnaryInterceptor(logger)),
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(
+			log.UnaryInterceptor(logger),
+			metrics.UnaryInterceptor(),
+			log.RecoveryInterceptor(),
+		),
 	)
 
 	for _, c := range controllers {
 		c.Register(server)
 	}
+	health.Register(server)
 
-	return &app{logger: logger, srv: server}
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", metrics.Handler())
+	health.Routes(mux)
+
+	return &app{logger: logger, health: health, srv: server, httpSrv: &http.Server{Handler: mux}}
 }
 
-func (a *app) Start(host string, port int) {
+func (a *app) Start(host string, port, httpPort int) {
 	go func() {
 		lis, err := net.Listen("tcp", fmt.Sprintf("%s:%d", host, port))
 		exitIfErr(a.logger, err, "failed to listen")
@@ -40,10 +58,24 @@ func (a *app) Start(host string, port int) {
 		err = a.srv.Serve(lis)
 		exitIfErr(a.logger, err, "failed to serve")
 	}()
+
+	a.httpSrv.Addr = fmt.Sprintf("%s:%d", host, httpPort)
+	go func() {
+		a.logger.Info("http server started", "addr", a.httpSrv.Addr)
+		if err := a.httpSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			exitIfErr(a.logger, err, "failed to serve http")
+		}
+	}()
 }
 
 func (a *app) Stop() {
+	a.health.Shutdown()
 	a.srv.GracefulStop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	a.httpSrv.Shutdown(ctx)
+
 	a.logger.Info("server stopped")
 }
 
@@ -8,9 +8,10 @@ import (
 )
 
 type Config struct {
-	Port int
-	Host string
-	Env  string
+	Port     int
+	HttpPort int // metrics and health endpoints
+	Host     string
+	Env      string
 
 	KafkaBrokers      []string
 	KafkaBatchTimeout time.Duration
@@ -21,6 +22,13 @@ type Config struct {
 
 	JwtSecret []byte
 	JwtTTL    time.Duration
+
+	Tracing Tracing
+}
+
+type Tracing struct {
+	Exporter string // none, stdout or otlp
+	Endpoint string // otlp collector address
 }
 
 type OAuth struct {
@@ -34,6 +42,7 @@ type OAuth struct {
 func New() Config {
 	return Config{
 		Port:              envInt("PORT", 50051),
+		HttpPort:          envInt("HTTP_PORT", 9051),
 		Host:              env("HOST", "localhost"),
 		Env:               env("ENV", "development"),
 		KafkaBrokers:      envArray("KAFKA_BROKERS", "localhost:9092"),
@@ -48,6 +57,10 @@ func New() Config {
 		PostgresURL: env("POSTGRES_URL"),
 		JwtTTL:      envDuration("JWT_TTL", 24*time.Hour),
 		JwtSecret:   []byte(env("JWT_SECRET", "secret")),
+		Tracing: Tracing{
+			Exporter: env("TRACING_EXPORTER", "none"),
+			Endpoint: env("TRACING_ENDPOINT", "localhost:4317"),
+		},
 	}
 }
 
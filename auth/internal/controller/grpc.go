@@ -5,6 +5,7 @@ import (
 	"FinanceTracker/auth/internal/domain"
 	"FinanceTracker/auth/internal/dto"
 	pb "FinanceTracker/auth/pkg/api/auth"
+	"FinanceTracker/auth/pkg/grpcerr"
 	"FinanceTracker/auth/pkg/logger"
 	"context"
 	"encoding/json"
@@ -18,7 +19,6 @@ import (
 	"golang.org/x/oauth2/yandex"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 const (
@@ -26,10 +26,19 @@ const (
 	yandexUserInfoUrl = "https://login.yandex.ru/info"
 )
 
+// Error reasons reported in ErrorInfo, the gateway maps them to HTTP responses.
+const (
+	ReasonOAuthFailed      = "OAUTH_FAILED"
+	ReasonProviderMismatch = "PROVIDER_MISMATCH"
+	ReasonInvalidEmail     = "INVALID_EMAIL"
+	ReasonOTPRequired      = "OTP_REQUIRED"
+	ReasonInvalidOTP       = "INVALID_OTP"
+)
+
 type AuthService interface {
 	OAuth(ctx context.Context, payload dto.OAuthPayload) (string, error)
-	GenerateOTP(ctx context.Context, email string) error
-	VerifyOTP(ctx context.Context, email, otp string) (string, error)
+	GenerateOTP(ctx context.Context, email, locale string) error
+	VerifyOTP(ctx context.Context, email, otp, locale string) (string, error)
 }
 
 type authController struct {
@@ -74,14 +83,14 @@ func (c *authController) ExchangeGoogleOAuth(ctx context.Context, req *pb.OAuthR
 	token, err := c.googleConfig.Exchange(ctx, req.Code)
 	if err != nil {
 		logger.Error(ctx, "failed to exchange token", "err", err)
-		return nil, status.Error(codes.Unauthenticated, "failed to exchange token")
+		return nil, grpcerr.New(codes.Unauthenticated, ReasonOAuthFailed, "failed to exchange token")
 	}
 
 	client := c.googleConfig.Client(ctx, token)
 	resp, err := client.Get(googleUserInfoUrl)
 	if err != nil || resp.StatusCode != http.StatusOK {
 		logger.Error(ctx, "failed to get user info", "err", err)
-		return nil, status.Error(codes.Unauthenticated, "failed to get user info")
+		return nil, grpcerr.New(codes.Unauthenticated, ReasonOAuthFailed, "failed to get user info")
 	}
 	defer resp.Body.Close()
 
@@ -93,13 +102,14 @@ func (c *authController) ExchangeGoogleOAuth(ctx context.Context, req *pb.OAuthR
 		FullName:  data.Name,
 		AvatarUrl: data.Picture,
 		Provider:  dto.OAuthProviderGoogle,
+		Locale:    req.Locale,
 	})
 	if errors.Is(err, domain.ErrProviderMismatch) {
-		return nil, status.Error(codes.InvalidArgument, "invalid provider")
+		return nil, grpcerr.New(codes.FailedPrecondition, ReasonProviderMismatch, "user is registered with another provider")
 	}
 	if err != nil {
 		logger.Error(ctx, "failed to oauth user", "err", err)
-		return nil, status.Error(codes.Unauthenticated, "failed to oauth user")
+		return nil, grpcerr.New(codes.Unauthenticated, ReasonOAuthFailed, "failed to oauth user")
 	}
 
 	return &pb.AuthResponse{AccessToken: accessToken}, nil
@@ -116,14 +126,14 @@ func (c *authController) ExchangeYandexOAuth(ctx context.Context, req *pb.OAuthR
 	token, err := c.yandexConfig.Exchange(ctx, req.Code)
 	if err != nil {
 		logger.Error(ctx, "failed to exchange token", "err", err)
-		return nil, status.Error(codes.Unauthenticated, "failed to exchange token")
+		return nil, grpcerr.New(codes.Unauthenticated, ReasonOAuthFailed, "failed to exchange token")
 	}
 
 	client := c.yandexConfig.Client(ctx, token)
 	resp, err := client.Get(yandexUserInfoUrl)
 	if err != nil || resp.StatusCode != http.StatusOK {
 		logger.Error(ctx, "failed to get user info", "err", err)
-		return nil, status.Error(codes.Unauthenticated, "failed to get user info")
+		return nil, grpcerr.New(codes.Unauthenticated, ReasonOAuthFailed, "failed to get user info")
 	}
 	defer resp.Body.Close()
 
@@ -135,13 +145,14 @@ func (c *authController) ExchangeYandexOAuth(ctx context.Context, req *pb.OAuthR
 		FullName:  data.Name,
 		AvatarUrl: fmt.Sprintf("https://avatars.yandex.net/get-yapic/%s/islands-200", data.AvatarID),
 		Provider:  dto.OAuthProviderYandex,
+		Locale:    req.Locale,
 	})
 	if errors.Is(err, domain.ErrProviderMismatch) {
-		return nil, status.Error(codes.InvalidArgument, "invalid provider")
+		return nil, grpcerr.New(codes.FailedPrecondition, ReasonProviderMismatch, "user is registered with another provider")
 	}
 	if err != nil {
 		logger.Error(ctx, "failed to oauth user", "err", err)
-		return nil, status.Error(codes.Unauthenticated, "failed to oauth user")
+		return nil, grpcerr.New(codes.Unauthenticated, ReasonOAuthFailed, "failed to oauth user")
 	}
 
 	return &pb.AuthResponse{AccessToken: accessToken}, nil
@@ -149,36 +160,36 @@ func (c *authController) ExchangeYandexOAuth(ctx context.Context, req *pb.OAuthR
 
 func (c *authController) GenerateOTP(ctx context.Context, req *pb.GenerateOTPRequest) (*pb.GenerateOTPResponse, error) {
 	if err := c.validate.Var(req.Email, "email"); err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid email format")
+		return nil, grpcerr.InvalidArgument(ReasonInvalidEmail, "email", "invalid email format")
 	}
 
-	err := c.authService.GenerateOTP(ctx, req.Email)
+	err := c.authService.GenerateOTP(ctx, req.Email, req.Locale)
 	if errors.Is(err, domain.ErrProviderMismatch) {
-		return nil, status.Error(codes.InvalidArgument, "invalid provider")
+		return nil, grpcerr.New(codes.FailedPrecondition, ReasonProviderMismatch, "user is registered with another provider")
 	}
 	if err != nil {
 		logger.Error(ctx, "failed to generate email OTP", "err", err)
-		return nil, status.Error(codes.Internal, "failed to generate email OTP")
+		return nil, grpcerr.Internal("failed to generate email OTP")
 	}
 	return &pb.GenerateOTPResponse{}, nil
 }
 
 func (c *authController) VerifyOTP(ctx context.Context, req *pb.VerifyOTPRequest) (*pb.AuthResponse, error) {
 	if err := c.validate.Var(req.Email, "email"); err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid email format")
+		return nil, grpcerr.InvalidArgument(ReasonInvalidEmail, "email", "invalid email format")
 	}
 	if err := c.validate.Var(req.Otp, "required"); err != nil {
-		return nil, status.Error(codes.InvalidArgument, "OTP is required")
+		return nil, grpcerr.InvalidArgument(ReasonOTPRequired, "otp", "OTP is required")
 	}
 
-	accessToken, err := c.authService.VerifyOTP(ctx, req.Email, req.Otp)
+	accessToken, err := c.authService.VerifyOTP(ctx, req.Email, req.Otp, req.Locale)
 	if errors.Is(err, domain.ErrInvalidOTP) {
-		return nil, status.Error(codes.Unauthenticated, "invalid OTP")
+		return nil, grpcerr.New(codes.Unauthenticated, ReasonInvalidOTP, "invalid OTP")
 	}
 
 	if err != nil {
 		logger.Error(ctx, "failed to verify email OTP", "err", err)
-		return nil, status.Error(codes.Internal, "failed to verify email OTP")
+		return nil, grpcerr.Internal("failed to verify email OTP")
 	}
 
 	return &pb.AuthResponse{AccessToken: accessToken}, nil
@@ -8,10 +8,26 @@ const (
 	UserProviderYandex = "yandex"
 )
 
+// Notification languages.
+const (
+	LocaleRu      = "ru"
+	LocaleEn      = "en"
+	DefaultLocale = LocaleRu
+)
+
 type User struct {
 	ID       int
 	Email    string
 	Provider string
+	Locale   string
+}
+
+// NormalizeLocale returns a supported locale, DefaultLocale for anything else.
+func NormalizeLocale(locale string) string {
+	if locale == LocaleRu || locale == LocaleEn {
+		return locale
+	}
+	return DefaultLocale
 }
 
 var (
@@ -10,4 +10,5 @@ type OAuthPayload struct {
 	FullName  string
 	AvatarUrl string
 	Provider  string
+	Locale    string // of a new user
 }
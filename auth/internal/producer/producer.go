@@ -2,12 +2,15 @@ package producer
 
 import (
 	"FinanceTracker/auth/pkg/events"
+	"FinanceTracker/auth/pkg/logger"
+	"FinanceTracker/auth/pkg/tracing"
 	"context"
 	"encoding/json"
 	"fmt"
 	"time"
 
 	kafka "github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/codes"
 )
 
 type producer struct {
@@ -40,7 +43,7 @@ func (p *producer) PublishUserRegistered(ctx context.Context, event events.Event
 		return fmt.Errorf("failed to marshal event: %w", err)
 	}
 
-	return p.userRegisteredWriter.WriteMessages(ctx, kafka.Message{Value: data})
+	return publish(ctx, p.userRegisteredWriter, data)
 }
 
 func (p *producer) PublishOTPGenerated(ctx context.Context, event events.EventOTPGenerated) error {
@@ -49,7 +52,22 @@ func (p *producer) PublishOTPGenerated(ctx context.Context, event events.EventOT
 		return fmt.Errorf("failed to marshal event: %w", err)
 	}
 
-	return p.otpGeneratedWriter.WriteMessages(ctx, kafka.Message{Value: data})
+	return publish(ctx, p.otpGeneratedWriter, data)
+}
+
+func publish(ctx context.Context, w *kafka.Writer, data []byte) error {
+	m := kafka.Message{Value: data}
+	if id := logger.RequestID(ctx); id != "" {
+		m.Headers = append(m.Headers, kafka.Header{Key: logger.RequestIDKey, Value: []byte(id)})
+	}
+	ctx, span := tracing.StartProducerSpan(ctx, w.Topic, &m)
+	defer span.End()
+
+	if err := w.WriteMessages(ctx, m); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	return nil
 }
 
 func (p *producer) Close() error {
@@ -16,6 +16,7 @@ type User struct {
 	ID        int       `db:"user_id"`
 	Email     string    `db:"email"`
 	Provider  string    `db:"provider"`
+	Locale    string    `db:"locale"`
 	CreatedAt time.Time `db:"created_at"`
 }
 
@@ -24,6 +25,7 @@ func (u User) ToDomain() domain.User {
 		ID:       u.ID,
 		Email:    u.Email,
 		Provider: u.Provider,
+		Locale:   u.Locale,
 	}
 }
 
@@ -41,7 +43,7 @@ func NewUserRepo(storage *sqlx.DB) *userRepo {
 }
 
 func (r *userRepo) GetByEmail(ctx context.Context, email string) (domain.User, error) {
-	query, args := r.qb.Select("user_id", "email", "provider", "created_at").
+	query, args := r.qb.Select("user_id", "email", "provider", "locale", "created_at").
 		From("users").
 		Where(sq.Eq{"email": email}).
 		MustSql()
@@ -59,7 +61,7 @@ func (r *userRepo) GetByEmail(ctx context.Context, email string) (domain.User, e
 }
 
 func (r *userRepo) GetByID(ctx context.Context, userID int) (domain.User, error) {
-	query, args := r.qb.Select("user_id", "email", "provider", "created_at").
+	query, args := r.qb.Select("user_id", "email", "provider", "locale", "created_at").
 		From("users").
 		Where(sq.Eq{"user_id": userID}).
 		MustSql()
@@ -76,11 +78,11 @@ func (r *userRepo) GetByID(ctx context.Context, userID int) (domain.User, error)
 	return user.ToDomain(), nil
 }
 
-func (r *userRepo) Create(ctx context.Context, email, provider string) (domain.User, error) {
+func (r *userRepo) Create(ctx context.Context, email, provider, locale string) (domain.User, error) {
 	query, args := r.qb.Insert("users").
-		Columns("email", "provider").
-		Values(email, provider).
-		Suffix("RETURNING user_id, email, provider, created_at").
+		Columns("email", "provider", "locale").
+		Values(email, provider, locale).
+		Suffix("RETURNING user_id, email, provider, locale, created_at").
 		MustSql()
 
 	var createdUser User
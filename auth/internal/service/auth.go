@@ -17,7 +17,7 @@ import (
 
 type UserRepo interface {
 	GetByEmail(ctx context.Context, email string) (domain.User, error)
-	Create(ctx context.Context, email, provider string) (domain.User, error)
+	Create(ctx context.Context, email, provider, locale string) (domain.User, error)
 }
 
 type OTPRepo interface {
@@ -61,7 +61,7 @@ func (s *authService) OAuth(ctx context.Context, payload dto.OAuthPayload) (stri
 		// if user not found, register new user
 		if errors.Is(err, domain.ErrUserNotFound) {
 			// create user
-			user, err = s.users.Create(ctx, payload.Email, payload.Provider)
+			user, err = s.users.Create(ctx, payload.Email, payload.Provider, domain.NormalizeLocale(payload.Locale))
 			if err != nil {
 				return fmt.Errorf("failed to create user: %w", err)
 			}
@@ -72,6 +72,7 @@ func (s *authService) OAuth(ctx context.Context, payload dto.OAuthPayload) (stri
 				Provider:  user.Provider,
 				AvatarURL: payload.AvatarUrl,
 				FullName:  payload.FullName,
+				Locale:    user.Locale,
 			}
 			if err := s.producer.PublishUserRegistered(ctx, event); err != nil {
 				return fmt.Errorf("failed to publish user registered event: %w", err)
@@ -97,7 +98,9 @@ func (s *authService) OAuth(ctx context.Context, payload dto.OAuthPayload) (stri
 	return token, err
 }
 
-func (c *authService) GenerateOTP(ctx context.Context, email string) error {
+// GenerateOTP sends the code in the language of the user, or in locale if
+// the email is not registered yet.
+func (c *authService) GenerateOTP(ctx context.Context, email, locale string) error {
 	const duration = 5 * time.Minute
 	return c.txManager.Do(ctx, func(ctx context.Context) error {
 		// check user provider
@@ -108,6 +111,8 @@ func (c *authService) GenerateOTP(ctx context.Context, email string) error {
 			}
 		} else if user.Provider != domain.UserProviderEmail {
 			return domain.ErrProviderMismatch
+		} else {
+			locale = user.Locale
 		}
 
 		// generate otp
@@ -120,6 +125,7 @@ func (c *authService) GenerateOTP(ctx context.Context, email string) error {
 		event := events.EventOTPGenerated{
 			Email:     otp.Email,
 			Code:      otp.Code,
+			Locale:    domain.NormalizeLocale(locale),
 			ExpiresAt: otp.ExpiresAt,
 			CreatedAt: otp.CreatedAt,
 		}
@@ -132,7 +138,7 @@ func (c *authService) GenerateOTP(ctx context.Context, email string) error {
 	})
 }
 
-func (s *authService) VerifyOTP(ctx context.Context, email, code string) (string, error) {
+func (s *authService) VerifyOTP(ctx context.Context, email, code, locale string) (string, error) {
 	var token string
 	err := s.txManager.Do(ctx, func(ctx context.Context) error {
 		// check is otp valid
@@ -155,7 +161,7 @@ func (s *authService) VerifyOTP(ctx context.Context, email, code string) (string
 		// if user not registered
 		if errors.Is(err, domain.ErrUserNotFound) {
 			// create user
-			user, err = s.users.Create(ctx, email, domain.UserProviderEmail)
+			user, err = s.users.Create(ctx, email, domain.UserProviderEmail, domain.NormalizeLocale(locale))
 			if err != nil {
 				return fmt.Errorf("failed to create user: %w", err)
 			}
@@ -164,6 +170,7 @@ func (s *authService) VerifyOTP(ctx context.Context, email, code string) (string
 				UserID:   user.ID,
 				Email:    user.Email,
 				Provider: user.Provider,
+				Locale:   user.Locale,
 			}
 			if err := s.producer.PublishUserRegistered(ctx, event); err != nil {
 				return fmt.Errorf("failed to publish user registered event: %w", err)
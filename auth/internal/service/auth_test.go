@@ -67,6 +67,7 @@ func TestAuthService_OAuth(t *testing.T) {
 				Provider:  domain.UserProviderGoogle,
 				FullName:  "New User",
 				AvatarUrl: "https://ex.com/a.png",
+				Locale:    domain.LocaleEn,
 			},
 			mockBehavior: func(users *mocks.MockUserRepo, producer *mocks.MockProducer) {
 				users.EXPECT().
@@ -74,8 +75,8 @@ func TestAuthService_OAuth(t *testing.T) {
 					Return(domain.User{}, domain.ErrUserNotFound)
 
 				users.EXPECT().
-					Create(mock.Anything, "new@example.com", domain.UserProviderGoogle).
-					Return(domain.User{ID: 11, Email: "new@example.com", Provider: domain.UserProviderGoogle}, nil)
+					Create(mock.Anything, "new@example.com", domain.UserProviderGoogle, domain.LocaleEn).
+					Return(domain.User{ID: 11, Email: "new@example.com", Provider: domain.UserProviderGoogle, Locale: domain.LocaleEn}, nil)
 
 				producer.EXPECT().
 					PublishUserRegistered(mock.Anything, mock.MatchedBy(func(ev any) bool {
@@ -83,7 +84,7 @@ func TestAuthService_OAuth(t *testing.T) {
 						if !ok {
 							return false
 						}
-						return e.UserID == 11 && e.Email == "new@example.com" && e.Provider == domain.UserProviderGoogle && e.AvatarURL == "https://ex.com/a.png" && e.FullName == "New User"
+						return e.UserID == 11 && e.Email == "new@example.com" && e.Provider == domain.UserProviderGoogle && e.AvatarURL == "https://ex.com/a.png" && e.FullName == "New User" && e.Locale == domain.LocaleEn
 					})).
 					Return(nil)
 			},
@@ -130,7 +131,7 @@ func TestAuthService_OAuth(t *testing.T) {
 					Return(domain.User{}, domain.ErrUserNotFound)
 
 				users.EXPECT().
-					Create(mock.Anything, "create-fail@example.com", domain.UserProviderGoogle).
+					Create(mock.Anything, "create-fail@example.com", domain.UserProviderGoogle, domain.DefaultLocale).
 					Return(domain.User{}, insertErr)
 			},
 			wantSubj: "",
@@ -150,7 +151,7 @@ func TestAuthService_OAuth(t *testing.T) {
 					Return(domain.User{}, domain.ErrUserNotFound)
 
 				users.EXPECT().
-					Create(mock.Anything, "event-fail@example.com", domain.UserProviderGoogle).
+					Create(mock.Anything, "event-fail@example.com", domain.UserProviderGoogle, domain.DefaultLocale).
 					Return(domain.User{ID: 13, Email: "event-fail@example.com", Provider: domain.UserProviderGoogle}, nil)
 
 				producer.EXPECT().
@@ -239,7 +240,7 @@ func TestAuthService_GenerateOTP(t *testing.T) {
 						if !ok {
 							return false
 						}
-						return e.Email == otp.Email && e.Code == otp.Code && e.CreatedAt.Equal(otp.CreatedAt) && e.ExpiresAt.Equal(otp.ExpiresAt)
+						return e.Email == otp.Email && e.Code == otp.Code && e.CreatedAt.Equal(otp.CreatedAt) && e.ExpiresAt.Equal(otp.ExpiresAt) && e.Locale == domain.LocaleEn
 					})).
 					Return(nil)
 			},
@@ -250,14 +251,17 @@ func TestAuthService_GenerateOTP(t *testing.T) {
 			mockBehavior: func(users *mocks.MockUserRepo, otps *mocks.MockOTPRepo, producer *mocks.MockProducer) {
 				users.EXPECT().
 					GetByEmail(mock.Anything, email).
-					Return(domain.User{ID: 1, Email: email, Provider: domain.UserProviderEmail}, nil)
+					Return(domain.User{ID: 1, Email: email, Provider: domain.UserProviderEmail, Locale: domain.LocaleRu}, nil)
 
 				otps.EXPECT().
 					Generate(mock.Anything, email, mock.MatchedBy(func(d time.Duration) bool { return d == duration })).
 					Return(otp, nil)
 
+				// the language of a registered user wins over the request
 				producer.EXPECT().
-					PublishOTPGenerated(mock.Anything, mock.Anything).
+					PublishOTPGenerated(mock.Anything, mock.MatchedBy(func(e events.EventOTPGenerated) bool {
+						return e.Locale == domain.LocaleRu
+					})).
 					Return(nil)
 			},
 			wantErr: nil,
@@ -329,7 +333,7 @@ func TestAuthService_GenerateOTP(t *testing.T) {
 
 			svc := servicepkg.NewAuthService(userRepo, otpRepo, producer, txManager, time.Minute, []byte("secret"))
 			ctx := logger.WithLogger(context.Background(), logger.New("test"))
-			err := svc.GenerateOTP(ctx, email)
+			err := svc.GenerateOTP(ctx, email, domain.LocaleEn)
 
 			if tc.wantErr != nil {
 				require.Error(t, err)
@@ -393,7 +397,7 @@ func TestAuthService_VerifyOTP(t *testing.T) {
 					Return(domain.User{}, domain.ErrUserNotFound)
 
 				users.EXPECT().
-					Create(mock.Anything, email, domain.UserProviderEmail).
+					Create(mock.Anything, email, domain.UserProviderEmail, domain.LocaleEn).
 					Return(domain.User{ID: 22, Email: email, Provider: domain.UserProviderEmail}, nil)
 
 				producer.EXPECT().
@@ -495,7 +499,7 @@ func TestAuthService_VerifyOTP(t *testing.T) {
 					Return(domain.User{}, domain.ErrUserNotFound)
 
 				users.EXPECT().
-					Create(mock.Anything, email, domain.UserProviderEmail).
+					Create(mock.Anything, email, domain.UserProviderEmail, domain.LocaleEn).
 					Return(domain.User{}, createErr)
 			},
 			wantSubj: "",
@@ -517,7 +521,7 @@ func TestAuthService_VerifyOTP(t *testing.T) {
 					Return(domain.User{}, domain.ErrUserNotFound)
 
 				users.EXPECT().
-					Create(mock.Anything, email, domain.UserProviderEmail).
+					Create(mock.Anything, email, domain.UserProviderEmail, domain.LocaleEn).
 					Return(domain.User{ID: 26, Email: email, Provider: domain.UserProviderEmail}, nil)
 
 				producer.EXPECT().
@@ -547,7 +551,7 @@ func TestAuthService_VerifyOTP(t *testing.T) {
 			jwtKey := []byte("secret")
 			svc := servicepkg.NewAuthService(userRepo, otpRepo, producer, txManager, time.Minute, jwtKey)
 			ctx := logger.WithLogger(context.Background(), logger.New("test"))
-			gotToken, err := svc.VerifyOTP(ctx, email, code)
+			gotToken, err := svc.VerifyOTP(ctx, email, code, domain.LocaleEn)
 
 			if tc.wantErr != nil {
 				require.Error(t, err)
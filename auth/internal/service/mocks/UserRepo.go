@@ -39,8 +39,8 @@ func (_m *MockUserRepo) EXPECT() *MockUserRepo_Expecter {
 }
 
 // Create provides a mock function for the type MockUserRepo
-func (_mock *MockUserRepo) Create(ctx context.Context, email string, provider string) (domain.User, error) {
-	ret := _mock.Called(ctx, email, provider)
+func (_mock *MockUserRepo) Create(ctx context.Context, email string, provider string, locale string) (domain.User, error) {
+	ret := _mock.Called(ctx, email, provider, locale)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
@@ -48,16 +48,16 @@ func (_mock *MockUserRepo) Create(ctx context.Context, email string, provider st
 
 	var r0 domain.User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (domain.User, error)); ok {
-		return returnFunc(ctx, email, provider)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (domain.User, error)); ok {
+		return returnFunc(ctx, email, provider, locale)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) domain.User); ok {
-		r0 = returnFunc(ctx, email, provider)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) domain.User); ok {
+		r0 = returnFunc(ctx, email, provider, locale)
 	} else {
 		r0 = ret.Get(0).(domain.User)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, email, provider)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = returnFunc(ctx, email, provider, locale)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -73,11 +73,12 @@ type MockUserRepo_Create_Call struct {
 //   - ctx context.Context
 //   - email string
 //   - provider string
-func (_e *MockUserRepo_Expecter) Create(ctx interface{}, email interface{}, provider interface{}) *MockUserRepo_Create_Call {
-	return &MockUserRepo_Create_Call{Call: _e.mock.On("Create", ctx, email, provider)}
+//   - locale string
+func (_e *MockUserRepo_Expecter) Create(ctx interface{}, email interface{}, provider interface{}, locale interface{}) *MockUserRepo_Create_Call {
+	return &MockUserRepo_Create_Call{Call: _e.mock.On("Create", ctx, email, provider, locale)}
 }
 
-func (_c *MockUserRepo_Create_Call) Run(run func(ctx context.Context, email string, provider string)) *MockUserRepo_Create_Call {
+func (_c *MockUserRepo_Create_Call) Run(run func(ctx context.Context, email string, provider string, locale string)) *MockUserRepo_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -91,10 +92,15 @@ func (_c *MockUserRepo_Create_Call) Run(run func(ctx context.Context, email stri
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
@@ -105,7 +111,7 @@ func (_c *MockUserRepo_Create_Call) Return(user domain.User, err error) *MockUse
 	return _c
 }
 
-func (_c *MockUserRepo_Create_Call) RunAndReturn(run func(ctx context.Context, email string, provider string) (domain.User, error)) *MockUserRepo_Create_Call {
+func (_c *MockUserRepo_Create_Call) RunAndReturn(run func(ctx context.Context, email string, provider string, locale string) (domain.User, error)) *MockUserRepo_Create_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -10,6 +10,7 @@ const (
 type EventOTPGenerated struct {
 	Email     string    `json:"email"`
 	Code      string    `json:"code"`
+	Locale    string    `json:"locale,omitempty"` // ru or en
 	ExpiresAt time.Time `json:"expires_at"`
 	CreatedAt time.Time `json:"created_at"`
 }
@@ -20,4 +21,5 @@ type EventUserRegistered struct {
 	Provider  string `json:"provider"`
 	FullName  string `json:"full_name,omitempty"`
 	AvatarURL string `json:"avatar_url,omitempty"`
+	Locale    string `json:"locale,omitempty"` // ru or en
 }
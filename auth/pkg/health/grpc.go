@@ -0,0 +1,39 @@
+package health
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+type grpcServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+	health *Health
+}
+
+// Register exposes the checks as grpc.health.v1. The empty service name is
+// the whole server with all checks, the named services only their own.
+func (h *Health) Register(server *grpc.Server) {
+	grpc_health_v1.RegisterHealthServer(server, &grpcServer{health: h})
+}
+
+func (s *grpcServer) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	if req.Service != "" {
+		ready, known := s.health.ServiceReady(ctx, req.Service)
+		if !known {
+			return nil, status.Errorf(codes.NotFound, "unknown service %q", req.Service)
+		}
+		if !ready {
+			return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_NOT_SERVING}, nil
+		}
+		return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+	}
+
+	if !s.health.Ready(ctx) {
+		return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_NOT_SERVING}, nil
+	}
+	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+}
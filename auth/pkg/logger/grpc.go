@@ -3,8 +3,12 @@ package logger
 import (
 	"context"
 	"log/slog"
+	"runtime/debug"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 func UnaryInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
@@ -14,6 +18,32 @@ func UnaryInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (any, error) {
-		return handler(WithLogger(ctx, logger), req)
+		ctx = WithLogger(ctx, logger)
+		if ids := metadata.ValueFromIncomingContext(ctx, RequestIDKey); len(ids) > 0 {
+			ctx = WithRequestID(ctx, ids[0])
+		}
+		if ips := metadata.ValueFromIncomingContext(ctx, ClientIPKey); len(ips) > 0 {
+			ctx = WithLogger(ctx, FromContext(ctx).With("client_ip", ips[0]))
+		}
+		return handler(ctx, req)
+	}
+}
+
+// RecoveryInterceptor turns handler panics into Internal errors.
+// It must run after UnaryInterceptor to log with the request logger.
+func RecoveryInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (resp any, err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				Error(ctx, "panic recovered", "method", info.FullMethod, "err", p, "stack", string(debug.Stack()))
+				err = status.Error(codes.Internal, "internal server error")
+			}
+		}()
+		return handler(ctx, req)
 	}
 }
@@ -11,9 +11,9 @@ type loggerKey struct{}
 func New(env string) *slog.Logger {
 	switch env {
 	case "production":
-		return slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
+		return slog.New(traceHandler{slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})})
 	default:
-		return slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		return slog.New(traceHandler{slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug})})
 	}
 }
 
@@ -26,13 +26,13 @@ func FromContext(ctx context.Context) *slog.Logger {
 }
 
 func Info(ctx context.Context, msg string, args ...any) {
-	FromContext(ctx).Info(msg, args...)
+	FromContext(ctx).InfoContext(ctx, msg, args...)
 }
 
 func Error(ctx context.Context, msg string, args ...any) {
-	FromContext(ctx).Error(msg, args...)
+	FromContext(ctx).ErrorContext(ctx, msg, args...)
 }
 
 func Debug(ctx context.Context, msg string, args ...any) {
-	FromContext(ctx).Debug(msg, args...)
+	FromContext(ctx).DebugContext(ctx, msg, args...)
 }
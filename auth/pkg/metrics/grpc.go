@@ -0,0 +1,40 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	grpcHandled = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_handled_total",
+		Help: "Total number of RPCs completed on the server, by method and code.",
+	}, []string{"method", "code"})
+
+	grpcDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "Latency of RPCs handled by the server, by method and code.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "code"})
+)
+
+func UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		code := status.Code(err).String()
+		grpcHandled.WithLabelValues(info.FullMethod, code).Inc()
+		grpcDuration.WithLabelValues(info.FullMethod, code).Observe(time.Since(start).Seconds())
+		return resp, err
+	}
+}
@@ -10,13 +10,25 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
+	_ "time/tzdata" // the timezone validator must know every zone
 
 	authPb "FinanceTracker/gateway/pkg/api/auth"
+	notificationPb "FinanceTracker/gateway/pkg/api/notification"
 	profilePb "FinanceTracker/gateway/pkg/api/profile"
 
-	"FinanceTracker/gateway/pkg/logger"
+	"FinanceTracker/gateway/pkg/cache"
+	"FinanceTracker/gateway/pkg/health"
+	log "FinanceTracker/gateway/pkg/logger"
+	"FinanceTracker/gateway/pkg/resilience"
+	"FinanceTracker/gateway/pkg/stream"
+	"FinanceTracker/gateway/pkg/tracing"
+	"FinanceTracker/gateway/pkg/unsubscribe"
+	"FinanceTracker/gateway/pkg/utils"
 
 	"github.com/joho/godotenv"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
@@ -27,37 +39,138 @@ import (
 // @securityDefinitions.apikey BearerAuth
 // @in header
 // @name Authorization
-// @description Используйте формат "Bearer {token}"
+// @description Используйте формат "Bearer {token}". Браузер может вместо заголовка передавать cookie access_token
 func main() {
 	conf := config.New()
-	logger := logger.New(conf.Env)
+	logger := log.New(conf.Env)
 
-	authMiddleware := middleware.NewAuth(conf.JwtSecret)
+	tracer := tracing.MustNew(context.Background(), "gateway", conf.Tracing.Exporter, conf.Tracing.Endpoint)
 
-	authConn, err := grpc.NewClient(conf.AuthServiceAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	origins, err := middleware.ParseOrigins(conf.CorsOrigins)
+	exitIfError(logger, err, "failed to parse cors origins")
+	authMiddleware := middleware.NewAuth(conf.JwtSecret, origins)
+	checks := health.New()
+
+	var rdb *redis.Client
+	if conf.RateLimit.Store == "redis" || conf.Cache.Store == "redis" || conf.Idempotency.Store == "redis" {
+		opts, err := redis.ParseURL(conf.RedisURL)
+		exitIfError(logger, err, "failed to parse redis url")
+		rdb = redis.NewClient(opts)
+		defer rdb.Close()
+		checks.Add("redis", func(ctx context.Context) error { return rdb.Ping(ctx).Err() })
+	}
+
+	var limiterStore middleware.LimiterStore
+	switch conf.RateLimit.Store {
+	case "redis":
+		limiterStore = middleware.NewRedisStore(rdb)
+	default:
+		limiterStore = middleware.NewMemoryStore(time.Minute)
+	}
+	rateLimiter, err := middleware.NewRateLimiter(limiterStore, conf.RateLimit.Routes)
+	exitIfError(logger, err, "failed to configure rate limits")
+
+	var idempotencyStore middleware.IdempotencyStore
+	switch conf.Idempotency.Store {
+	case "redis":
+		idempotencyStore = middleware.NewRedisIdempotencyStore(rdb)
+	default:
+		idempotencyStore = middleware.NewMemoryIdempotencyStore(time.Minute)
+	}
+	idempotency := middleware.NewIdempotency(idempotencyStore, conf.Idempotency.TTL, conf.Idempotency.MaxBody)
+
+	timeouts, err := resilience.ParseTimeouts(conf.GRPCClient.Timeouts)
+	exitIfError(logger, err, "failed to configure grpc timeouts")
+
+	authConn, err := grpc.NewClient(conf.AuthServiceAddr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		clientInterceptors("auth", conf.GRPCClient, timeouts),
+	)
 	exitIfError(logger, err, "failed to create grpc auth client")
 	authService := authPb.NewAuthServiceClient(authConn)
-	authController := controller.NewAuthController(authService, conf.OAuth)
+	authController := controller.NewAuthController(authService, conf.OAuth, rateLimiter, middleware.NewCSRF(origins), idempotency)
 
-	profileConn, err := grpc.NewClient(conf.ProfileServiceAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	profileConn, err := grpc.NewClient(conf.ProfileServiceAddr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		clientInterceptors("profile", conf.GRPCClient, timeouts),
+	)
 	exitIfError(logger, err, "failed to create grpc profile client")
 	profileService := profilePb.NewProfileServiceClient(profileConn)
-	profileController := controller.NewProfileController(profileService, authMiddleware)
+	hostname, _ := os.Hostname()
+	var responseCache cache.Cache
+	cacheGroupID := conf.KafkaGroupID
+	switch conf.Cache.Store {
+	case "redis":
+		responseCache = cache.NewRedis(rdb)
+	default:
+		responseCache = cache.NewMemory(time.Minute)
+		// every replica has its own entries and must see all invalidation events
+		cacheGroupID += "-" + hostname
+	}
+	profileController := controller.NewProfileController(profileService, authMiddleware, idempotency, responseCache, conf.Cache.ProfileTTL)
+	telegramController := controller.NewTelegramController(profileService, authMiddleware, conf.TelegramBotUsername)
+	unsubscribeController := controller.NewUnsubscribeController(profileService, unsubscribe.New(conf.UnsubscribeSecret))
+	eventsController := controller.NewEventsController(conf.KafkaBrokers, cacheGroupID, responseCache)
+	streamController := controller.NewStreamController(conf.KafkaBrokers, conf.KafkaGroupID+"-stream-"+hostname, conf.Stream.Topics,
+		stream.NewHub(conf.Stream.BufferSize, conf.Stream.Retention), authMiddleware, conf.Stream.Heartbeat, conf.Stream.Retry)
+
+	notificationConn, err := grpc.NewClient(conf.NotificationServiceAddr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		clientInterceptors("notification", conf.GRPCClient, timeouts),
+	)
+	exitIfError(logger, err, "failed to create grpc notification client")
+	notificationService := notificationPb.NewNotificationServiceClient(notificationConn)
+	notificationController := controller.NewNotificationController(notificationService, authMiddleware)
+	pushController := controller.NewPushController(notificationService, authMiddleware)
+
+	// only the database each service needs for the calls the gateway makes,
+	// an outage of kafka, s3 or mail must not take the whole API out of the
+	// load balancer
+	checks.Add("auth", health.GRPC(authConn, authPb.AuthService_ServiceDesc.ServiceName))
+	checks.Add("profile", health.GRPC(profileConn, profilePb.ProfileService_ServiceDesc.ServiceName))
+	checks.Add("notification", health.GRPC(notificationConn, notificationPb.NotificationService_ServiceDesc.ServiceName))
 
-	app := app.New(logger, conf, authController, profileController)
+	app := app.New(logger, conf, checks, authController, profileController, telegramController, notificationController, pushController, unsubscribeController, streamController)
 
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
 	defer stop()
 
+	ctx = log.WithLogger(ctx, logger)
+
 	app.Start()
+	go eventsController.Consume(ctx)
+	go streamController.Consume(ctx)
 	<-ctx.Done()
+	eventsController.Close()
+	streamController.Close()
 	app.Stop()
+	tracer.Shutdown(context.Background())
 }
 
 func init() {
 	godotenv.Load()
 }
 
+// clientInterceptors bounds every call with a deadline, fails fast while the
+// service is down and retries idempotent calls within the deadline.
+func clientInterceptors(target string, conf config.GRPCClient, timeouts map[string]time.Duration) grpc.DialOption {
+	return grpc.WithChainUnaryInterceptor(
+		log.UnaryClientInterceptor(),
+		utils.UnaryClientInterceptor(),
+		resilience.Timeout(conf.Timeout, timeouts),
+		resilience.NewBreaker(target, conf.BreakerFailures, conf.BreakerOpenTimeout).UnaryClientInterceptor(),
+		resilience.Retry(resilience.RetryPolicy{
+			Attempts:   conf.RetryAttempts,
+			Backoff:    conf.RetryBackoff,
+			MaxBackoff: conf.RetryMaxBackoff,
+			Methods:    conf.RetryMethods,
+		}),
+	)
+}
+
 func exitIfError(logger *slog.Logger, err error, msg string) {
 	if err != nil {
 		logger.Error(msg, "err", err)
@@ -17,7 +17,7 @@ const docTemplate = `{
     "paths": {
         "/auth/email": {
             "post": {
-                "description": "Отправляет одноразовый код на email",
+                "description": "Отправляет одноразовый код на email. Язык письма нового пользователя берется из Accept-Language",
                 "consumes": [
                     "application/json"
                 ],
@@ -37,6 +37,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/controller.EmailAuthRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор с тем же ключом вернет сохраненный ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -49,7 +55,19 @@ const docTemplate = `{
                     "400": {
                         "description": "Некорректные данные",
                         "schema": {
-                            "$ref": "#/definitions/utils.ValidationErrorResponse"
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Запрос с этим ключом идемпотентности еще выполняется",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности уже использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
                         }
                     },
                     "500": {
@@ -93,7 +111,13 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Неверные данные или код",
+                        "description": "Неверные данные",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неверный или просроченный код",
                         "schema": {
                             "$ref": "#/definitions/utils.ErrorResponse"
                         }
@@ -156,6 +180,32 @@ const docTemplate = `{
                 }
             }
         },
+        "/auth/logout": {
+            "post": {
+                "description": "Удаляет cookie с access token",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Выход",
+                "responses": {
+                    "200": {
+                        "description": "Logged out",
+                        "schema": {
+                            "$ref": "#/definitions/utils.MessageResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Запрос с чужого сайта",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/auth/yandex/callback": {
             "get": {
                 "description": "Обрабатывает redirect от Yandex и выдает access token",
@@ -205,33 +255,89 @@ const docTemplate = `{
                 }
             }
         },
-        "/profile/me": {
+        "/events/stream": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Возвращает информацию о профиле аутентифицированного пользователя.",
-                "consumes": [
-                    "application/json"
+                "description": "Server-Sent Events: имя события совпадает с топиком (например, profile.updated), данные - JSON события.\nПосле переподключения пропущенные события досылаются по Last-Event-ID. Если их уже нет, приходит событие resync и состояние нужно загрузить заново.\nБраузер может авторизоваться cookie access_token (EventSource с withCredentials).",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "События"
+                ],
+                "summary": "Поток событий пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID последнего полученного события",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Поток событий",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Возвращает уведомления текущего пользователя, новые первыми. Следующая страница запрашивается с cursor из next_cursor.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Профиль"
+                    "Уведомления"
+                ],
+                "summary": "Список уведомлений",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Размер страницы (1-100, по умолчанию 20)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только непрочитанные",
+                        "name": "unread",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Получить профиль текущего пользователя",
                 "responses": {
                     "200": {
-                        "description": "Успешный ответ с данными профиля",
+                        "description": "Страница уведомлений",
                         "schema": {
-                            "$ref": "#/definitions/controller.ProfileResponse"
+                            "$ref": "#/definitions/controller.NotificationsResponse"
                         }
                     },
                     "400": {
-                        "description": "Профиль не найден",
+                        "description": "Некорректные параметры",
                         "schema": {
                             "$ref": "#/definitions/utils.ErrorResponse"
                         }
@@ -251,47 +357,148 @@ const docTemplate = `{
                 }
             }
         },
-        "/profile/update": {
-            "put": {
+        "/notifications/push/public-key": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает VAPID-ключ для PushManager.subscribe (applicationServerKey).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Уведомления"
+                ],
+                "summary": "Ключ для подписки на push-уведомления",
+                "responses": {
+                    "200": {
+                        "description": "Публичный VAPID-ключ",
+                        "schema": {
+                            "$ref": "#/definitions/controller.PushPublicKeyResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Push-уведомления не настроены",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Сервис недоступен",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/push/subscriptions": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Принимает имя и/или аватар (multipart/form-data) и обновляет профиль",
+                "description": "Сохраняет подписку браузера и включает канал web_push в настройках уведомлений пользователя. Повторная регистрация того же endpoint обновляет ключи.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Профиль"
+                    "Уведомления"
                 ],
-                "summary": "Обновить профиль текущего пользователя",
+                "summary": "Подписать браузер на push-уведомления",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Имя пользователя",
-                        "name": "name",
-                        "in": "formData"
+                        "description": "Подписка из PushSubscription.toJSON()",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.PushSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Подписка сохранена",
+                        "schema": {
+                            "$ref": "#/definitions/controller.PushSubscriptionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректная подписка",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Push-уведомления не настроены",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
                     },
+                    "503": {
+                        "description": "Сервис недоступен",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
                     {
-                        "type": "file",
-                        "description": "Файл аватара (image/*)",
-                        "name": "avatar",
-                        "in": "formData"
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет подписку текущего пользователя. Удаление несуществующей подписки не является ошибкой.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Уведомления"
+                ],
+                "summary": "Отписать браузер от push-уведомлений",
+                "parameters": [
+                    {
+                        "description": "Endpoint подписки",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.PushUnsubscribeRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Успешный ответ с обновленным профилем",
+                        "description": "Подписка удалена",
                         "schema": {
-                            "$ref": "#/definitions/controller.ProfileResponse"
+                            "$ref": "#/definitions/utils.MessageResponse"
                         }
                     },
                     "400": {
-                        "description": "Неверные данные или нечего обновлять",
+                        "description": "Некорректные данные",
                         "schema": {
                             "$ref": "#/definitions/utils.ErrorResponse"
                         }
@@ -310,80 +517,909 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "controller.EmailAuthRequest": {
-            "type": "object",
-            "required": [
-                "email"
-            ],
-            "properties": {
-                "email": {
-                    "type": "string"
-                }
-            }
         },
-        "controller.ProfileResponse": {
-            "type": "object",
-            "properties": {
-                "avatar_id": {
-                    "type": "string"
-                },
-                "email": {
-                    "type": "string"
-                },
-                "full_name": {
-                    "type": "string"
-                },
-                "provider": {
-                    "type": "string"
-                },
-                "user_id": {
-                    "type": "integer"
+        "/notifications/read-all": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Уведомления"
+                ],
+                "summary": "Отметить все уведомления прочитанными",
+                "responses": {
+                    "200": {
+                        "description": "Число отмеченных уведомлений",
+                        "schema": {
+                            "$ref": "#/definitions/controller.MarkAllReadResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Сервис недоступен",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "controller.VerifyEmailRequest": {
-            "type": "object",
-            "required": [
-                "email",
-                "otp"
-            ],
-            "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "otp": {
+        "/notifications/unread-count": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Уведомления"
+                ],
+                "summary": "Число непрочитанных уведомлений",
+                "responses": {
+                    "200": {
+                        "description": "Число непрочитанных",
+                        "schema": {
+                            "$ref": "#/definitions/controller.UnreadCountResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Сервис недоступен",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/unsubscribe": {
+            "post": {
+                "description": "Отключает категорию писем по токену из ссылки в письме, вход не нужен. Почтовые клиенты вызывают метод сами по заголовку List-Unsubscribe-Post (RFC 8058). Повторная отписка не является ошибкой. Включить письма снова можно в настройках уведомлений.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Уведомления"
+                ],
+                "summary": "Отписаться от писем по ссылке",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Токен из ссылки",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Категория, от которой отписан пользователь",
+                        "schema": {
+                            "$ref": "#/definitions/controller.UnsubscribeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Недействительная ссылка",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Профиль не найден",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Сервис недоступен",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/{id}/read": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Уведомления"
+                ],
+                "summary": "Отметить уведомление прочитанным",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID уведомления",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Прочитанное уведомление",
+                        "schema": {
+                            "$ref": "#/definitions/controller.NotificationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный ID",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Уведомление не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Сервис недоступен",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/profile/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает информацию о профиле аутентифицированного пользователя.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Профиль"
+                ],
+                "summary": "Получить профиль текущего пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ETag из предыдущего ответа",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешный ответ с данными профиля",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ProfileResponse"
+                        }
+                    },
+                    "304": {
+                        "description": "Профиль не изменился"
+                    },
+                    "404": {
+                        "description": "Профиль не найден",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Сервис недоступен",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Сервис не ответил вовремя",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/profile/notification-preferences": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает каналы доставки, тихие часы, режим уведомлений и расписание дайджеста. Если пользователь их не менял, возвращаются значения по умолчанию.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Профиль"
+                ],
+                "summary": "Получить настройки уведомлений",
+                "responses": {
+                    "200": {
+                        "description": "Настройки уведомлений",
+                        "schema": {
+                            "$ref": "#/definitions/controller.NotificationPreferences"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Сервис недоступен",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Сервис не ответил вовремя",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Полностью заменяет настройки уведомлений; если email_unsubscribed не передан, сохраненный список отписок не меняется. В тихие часы уведомления сразу попадают во входящие, а письма, сообщения в Telegram и push-уведомления отправляются после их окончания. В режиме дайджеста предстоящие списания приходят одним письмом в 9:00 по времени пользователя. Код входа и письма безопасности отправляются независимо от настроек.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Профиль"
+                ],
+                "summary": "Обновить настройки уведомлений",
+                "parameters": [
+                    {
+                        "description": "Новые настройки",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controller.NotificationPreferences"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Сохраненные настройки",
+                        "schema": {
+                            "$ref": "#/definitions/controller.NotificationPreferences"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректные настройки",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Профиль не найден",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Сервис недоступен",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Сервис не ответил вовремя",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/profile/telegram": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Профиль"
+                ],
+                "summary": "Статус подключения Telegram",
+                "responses": {
+                    "200": {
+                        "description": "Подключенный чат или linked=false",
+                        "schema": {
+                            "$ref": "#/definitions/controller.TelegramLinkResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Сервис недоступен",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Бот перестает присылать уведомления. Повторное отключение не является ошибкой.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Профиль"
+                ],
+                "summary": "Отключить Telegram",
+                "responses": {
+                    "200": {
+                        "description": "Telegram отключен",
+                        "schema": {
+                            "$ref": "#/definitions/utils.MessageResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Сервис недоступен",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/profile/telegram/link": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Выдает одноразовый код, который нужно отправить боту командой /start. Ссылка url открывает бота с уже подставленным кодом. Предыдущий код перестает действовать.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Профиль"
+                ],
+                "summary": "Получить ссылку для подключения Telegram",
+                "responses": {
+                    "200": {
+                        "description": "Код и ссылка на бота",
+                        "schema": {
+                            "$ref": "#/definitions/controller.TelegramLinkCodeResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Профиль не найден",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Сервис недоступен",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/profile/update": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Принимает имя, аватар и/или язык уведомлений (multipart/form-data) и обновляет профиль",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Профиль"
+                ],
+                "summary": "Обновить профиль текущего пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя",
+                        "name": "name",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Файл аватара (image/*)",
+                        "name": "avatar",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Язык уведомлений: ru или en",
+                        "name": "locale",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор с тем же ключом вернет сохраненный ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешный ответ с обновленным профилем",
+                        "schema": {
+                            "$ref": "#/definitions/controller.ProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные данные или нечего обновлять",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Профиль не найден",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Запрос с этим ключом идемпотентности еще выполняется",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ идемпотентности уже использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Сервис недоступен",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Сервис не ответил вовремя",
+                        "schema": {
+                            "$ref": "#/definitions/utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "controller.AvatarVariant": {
+            "type": "object",
+            "properties": {
+                "format": {
+                    "description": "jpeg or webp",
+                    "type": "string",
+                    "example": "webp"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 128
+                },
+                "url": {
+                    "type": "string",
+                    "example": "http://localhost:9000/finance-tracker/avatars/1/128.webp"
+                }
+            }
+        },
+        "controller.EmailAuthRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
                     "type": "string"
                 }
             }
         },
-        "utils.ErrorResponse": {
+        "controller.MarkAllReadResponse": {
             "type": "object",
             "properties": {
-                "message": {
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.NotificationPreferences": {
+            "type": "object",
+            "required": [
+                "mode",
+                "timezone"
+            ],
+            "properties": {
+                "channels": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "email",
+                        "in_app"
+                    ]
+                },
+                "digest_day": {
+                    "type": "string",
+                    "enum": [
+                        "monday",
+                        "tuesday",
+                        "wednesday",
+                        "thursday",
+                        "friday",
+                        "saturday",
+                        "sunday"
+                    ],
+                    "example": "monday"
+                },
+                "digest_frequency": {
+                    "type": "string",
+                    "enum": [
+                        "daily",
+                        "weekly"
+                    ],
+                    "example": "weekly"
+                },
+                "email_unsubscribed": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "digest"
+                    ]
+                },
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "immediate",
+                        "digest"
+                    ],
+                    "example": "immediate"
+                },
+                "quiet_hours_end": {
+                    "type": "string",
+                    "example": "08:00"
+                },
+                "quiet_hours_start": {
+                    "type": "string",
+                    "example": "22:00"
+                },
+                "reminder_lead_days": {
+                    "type": "integer",
+                    "maximum": 30,
+                    "minimum": 0,
+                    "example": 1
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Europe/Moscow"
+                }
+            }
+        },
+        "controller.NotificationResponse": {
+            "type": "object",
+            "properties": {
+                "body": {
                     "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "link": {
+                    "type": "string",
+                    "example": "/subscriptions/42"
+                },
+                "read_at": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "welcome"
                 }
             }
         },
-        "utils.MessageResponse": {
+        "controller.NotificationsResponse": {
             "type": "object",
             "properties": {
-                "message": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.NotificationResponse"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.ProfileResponse": {
+            "type": "object",
+            "properties": {
+                "avatar_id": {
+                    "type": "string"
+                },
+                "avatar_variants": {
+                    "description": "AvatarVariants are square copies of the avatar for srcset, empty for\navatars uploaded before they were made",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controller.AvatarVariant"
+                    }
+                },
+                "email": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "locale": {
+                    "description": "language of notifications",
+                    "type": "string",
+                    "example": "ru"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.PushPublicKeyResponse": {
+            "type": "object",
+            "properties": {
+                "public_key": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.PushSubscriptionRequest": {
+            "type": "object",
+            "required": [
+                "endpoint"
+            ],
+            "properties": {
+                "endpoint": {
+                    "type": "string",
+                    "maxLength": 2048
+                },
+                "keys": {
+                    "type": "object",
+                    "required": [
+                        "auth",
+                        "p256dh"
+                    ],
+                    "properties": {
+                        "auth": {
+                            "type": "string"
+                        },
+                        "p256dh": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "controller.PushSubscriptionResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "endpoint": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.PushUnsubscribeRequest": {
+            "type": "object",
+            "required": [
+                "endpoint"
+            ],
+            "properties": {
+                "endpoint": {
+                    "type": "string"
+                }
+            }
+        },
+        "controller.TelegramLinkCodeResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://t.me/FinanceTrackerBot?start=Xy7..."
+                }
+            }
+        },
+        "controller.TelegramLinkResponse": {
+            "type": "object",
+            "properties": {
+                "linked": {
+                    "type": "boolean"
+                },
+                "linked_at": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "alice"
+                }
+            }
+        },
+        "controller.UnreadCountResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controller.UnsubscribeResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "digest"
+                }
+            }
+        },
+        "controller.VerifyEmailRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "otp"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "otp": {
                     "type": "string"
                 }
             }
         },
-        "utils.ValidationErrorResponse": {
+        "utils.ErrorResponse": {
             "type": "object",
             "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "PROFILE_NOT_FOUND"
+                },
                 "fields": {
                     "type": "object",
                     "additionalProperties": {
                         "type": "string"
                     }
                 },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 404
+                }
+            }
+        },
+        "utils.MessageResponse": {
+            "type": "object",
+            "properties": {
                 "message": {
                     "type": "string"
                 }
@@ -392,7 +1428,7 @@ const docTemplate = `{
     },
     "securityDefinitions": {
         "BearerAuth": {
-            "description": "Используйте формат \"Bearer {token}\"",
+            "description": "Используйте формат \"Bearer {token}\". Браузер может вместо заголовка передавать cookie access_token",
             "type": "apiKey",
             "name": "Authorization",
             "in": "header"
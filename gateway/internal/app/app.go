@@ -3,6 +3,7 @@ package app
 import (
 	"FinanceTracker/gateway/internal/config"
 	"FinanceTracker/gateway/internal/middleware"
+	"FinanceTracker/gateway/pkg/health"
 	"FinanceTracker/gateway/pkg/logger"
 	"context"
 	"fmt"
@@ -13,41 +14,80 @@ import (
 
 	_ "FinanceTracker/gateway/docs"
 
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	httpSwagger "github.com/swaggo/http-swagger/v2"
 )
 
 type app struct {
-	srv    *http.Server
-	logger *slog.Logger
+	srv           *http.Server
+	logger        *slog.Logger
+	health        *health.Health
+	shutdownDelay time.Duration
 }
 
 type Controller interface {
 	Init(r *http.ServeMux)
 }
 
-func New(log *slog.Logger, conf config.Config, controllers ...Controller) *app {
+// Shutdowner is implemented by controllers with long-lived responses, which
+// must be ended when the server stops.
+type Shutdowner interface {
+	Shutdown()
+}
+
+func New(log *slog.Logger, conf config.Config, health *health.Health, controllers ...Controller) *app {
 	mux := http.NewServeMux()
 	mux.Handle("/swagger/", httpSwagger.WrapHandler)
+	mux.Handle("GET /metrics", promhttp.Handler())
+	health.Routes(mux)
 	for _, c := range controllers {
 		c.Init(mux)
 	}
 
-	corsMiddleware := middleware.NewCORS(middleware.CORSConfig{
+	corsMiddleware, err := middleware.NewCORS(middleware.CORSConfig{
 		AllowedOrigins:   conf.CorsOrigins,
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Content-Type", "Authorization"},
+		AllowedHeaders:   []string{"Content-Type", "Authorization", "If-None-Match", "Last-Event-ID", middleware.IdempotencyKeyHeader, logger.RequestIDHeader},
+		ExposedHeaders:   []string{"ETag", "Retry-After", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", middleware.IdempotentReplayedHeader, logger.RequestIDHeader},
 		AllowCredentials: true,
+		MaxAge:           conf.CorsMaxAge,
+	})
+	if err != nil {
+		log.Error("failed to configure cors", "err", err)
+		os.Exit(1)
+	}
+	securityMiddleware := middleware.NewSecurityHeaders(middleware.SecurityHeadersConfig{
+		HSTSMaxAge:     conf.Security.HSTSMaxAge,
+		ReferrerPolicy: conf.Security.ReferrerPolicy,
+		SwaggerCSP:     conf.Security.SwaggerCSP,
 	})
 	loggerMiddleware := logger.NewHttpMiddleware(log)
+	tracingMiddleware := middleware.NewTracing("gateway")
+	metricsMiddleware := middleware.NewMetrics()
+	accessLogMiddleware := middleware.NewAccessLog()
+	recoveryMiddleware := middleware.NewRecovery()
+	clientIPMiddleware, err := middleware.NewClientIP(conf.TrustedProxies, conf.TrustedProxyHeader)
+	if err != nil {
+		log.Error("failed to configure trusted proxies", "err", err)
+		os.Exit(1)
+	}
 
 	srv := &http.Server{
 		Addr:    fmt.Sprintf("%s:%d", conf.Host, conf.Port),
-		Handler: loggerMiddleware(corsMiddleware(mux)),
+		Handler: tracingMiddleware(clientIPMiddleware(loggerMiddleware(accessLogMiddleware(metricsMiddleware(securityMiddleware(corsMiddleware(recoveryMiddleware(mux)))))))),
+	}
+
+	for _, c := range controllers {
+		if s, ok := c.(Shutdowner); ok {
+			srv.RegisterOnShutdown(s.Shutdown)
+		}
 	}
 
 	return &app{
-		logger: log,
-		srv:    srv,
+		logger:        log,
+		srv:           srv,
+		health:        health,
+		shutdownDelay: conf.ShutdownDelay,
 	}
 }
 
@@ -62,6 +102,9 @@ func (a *app) Start() {
 }
 
 func (a *app) Stop() {
+	a.health.Shutdown()
+	time.Sleep(a.shutdownDelay)
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	a.srv.Shutdown(ctx)
@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"FinanceTracker/gateway/internal/config"
@@ -17,9 +18,6 @@ import (
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	"golang.org/x/oauth2/yandex"
-	"golang.org/x/time/rate"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 type authController struct {
@@ -29,9 +27,12 @@ type authController struct {
 	successUrl   string
 	failureUrl   string
 	authService  pb.AuthServiceClient
+	limiter      *middleware.RateLimiter
+	csrf         func(http.Handler) http.Handler
+	idempotency  func(http.Handler) http.Handler
 }
 
-func NewAuthController(authService pb.AuthServiceClient, oauthConf config.OAuth) *authController {
+func NewAuthController(authService pb.AuthServiceClient, oauthConf config.OAuth, limiter *middleware.RateLimiter, csrf, idempotency func(http.Handler) http.Handler) *authController {
 	return &authController{
 		googleConfig: &oauth2.Config{
 			ClientID:    oauthConf.GoogleClientID,
@@ -47,25 +48,24 @@ func NewAuthController(authService pb.AuthServiceClient, oauthConf config.OAuth)
 		successUrl:  oauthConf.SuccessURL,
 		failureUrl:  oauthConf.FailureURL,
 		authService: authService,
+		limiter:     limiter,
+		csrf:        csrf,
+		idempotency: idempotency,
 		validate:    validator.New(),
 	}
 }
 
 func (c *authController) Init(r *http.ServeMux) {
-	const (
-		emailRateLimit = 30 * time.Second
-		ipRateLimit    = 10 * time.Second
-	)
-
-	ipLimiter := middleware.NewIPLimiter(rate.Every(ipRateLimit), 10)
-	emailLimiter := middleware.NewBodyLimiter(rate.Every(emailRateLimit), 1, "email")
+	ipLimiter := c.limiter.IP("auth.email.verify", middleware.Limit{Interval: 10 * time.Second, Burst: 10})
+	emailLimiter := c.limiter.Body("auth.email", middleware.Limit{Interval: 30 * time.Second, Burst: 1}, "email")
 
 	r.HandleFunc("/auth/google/login", c.handleGoogleLogin)
 	r.HandleFunc("/auth/google/callback", c.handleGoogleCallback)
 	r.HandleFunc("/auth/yandex/login", c.handleYandexLogin)
 	r.HandleFunc("/auth/yandex/callback", c.handleYandexCallback)
-	r.Handle("POST /auth/email", emailLimiter(http.HandlerFunc(c.handleEmailAuth)))
+	r.Handle("POST /auth/email", c.idempotency(emailLimiter(http.HandlerFunc(c.handleEmailAuth))))
 	r.Handle("POST /auth/email/verify", ipLimiter(http.HandlerFunc(c.handleVerifyEmailOTP)))
+	r.Handle("POST /auth/logout", c.csrf(http.HandlerFunc(c.handleLogout)))
 }
 
 // @Summary		Google OAuth вход
@@ -98,10 +98,10 @@ func (c *authController) handleGoogleCallback(w http.ResponseWriter, r *http.Req
 	}
 
 	code := r.URL.Query().Get("code")
-	resp, err := c.authService.ExchangeGoogleOAuth(ctx, &pb.OAuthRequest{Code: code})
+	resp, err := c.authService.ExchangeGoogleOAuth(ctx, &pb.OAuthRequest{Code: code, Locale: utils.Language(r)})
 	if err != nil {
 		logger.Error(ctx, "failed to exchange google oauth", "err", err)
-		http.Redirect(w, r, fmt.Sprintf("%s?error=oauth_failed", c.failureUrl), http.StatusTemporaryRedirect)
+		http.Redirect(w, r, fmt.Sprintf("%s?error=%s", c.failureUrl, oauthErrorCode(err)), http.StatusTemporaryRedirect)
 		return
 	}
 
@@ -139,10 +139,10 @@ func (c *authController) handleYandexCallback(w http.ResponseWriter, r *http.Req
 	}
 
 	code := r.URL.Query().Get("code")
-	resp, err := c.authService.ExchangeYandexOAuth(ctx, &pb.OAuthRequest{Code: code})
+	resp, err := c.authService.ExchangeYandexOAuth(ctx, &pb.OAuthRequest{Code: code, Locale: utils.Language(r)})
 	if err != nil {
 		logger.Error(ctx, "failed to exchange yandex oauth", "err", err)
-		http.Redirect(w, r, fmt.Sprintf("%s?error=oauth_failed", c.failureUrl), http.StatusTemporaryRedirect)
+		http.Redirect(w, r, fmt.Sprintf("%s?error=%s", c.failureUrl, oauthErrorCode(err)), http.StatusTemporaryRedirect)
 		return
 	}
 
@@ -155,13 +155,16 @@ type EmailAuthRequest struct {
 }
 
 // @Summary		Запросить код на email
-// @Description	Отправляет одноразовый код на email
+// @Description	Отправляет одноразовый код на email. Язык письма нового пользователя берется из Accept-Language
 // @Tags			auth
 // @Accept			json
 // @Produce		json
 // @Param			request	body		EmailAuthRequest				true	"Email для отправки OTP"
+// @Param			Idempotency-Key	header	string	false	"Ключ идемпотентности: повтор с тем же ключом вернет сохраненный ответ"
 // @Success		200		{object}	utils.MessageResponse			"Email sent"
-// @Failure		400		{object}	utils.ValidationErrorResponse	"Некорректные данные"
+// @Failure		400		{object}	utils.ErrorResponse				"Некорректные данные"
+// @Failure		409		{object}	utils.ErrorResponse				"Запрос с этим ключом идемпотентности еще выполняется"
+// @Failure		422		{object}	utils.ErrorResponse				"Ключ идемпотентности уже использован с другим запросом"
 // @Failure		500		{object}	utils.ErrorResponse				"Сбой при отправке"
 // @Router			/auth/email [post]
 func (c *authController) handleEmailAuth(w http.ResponseWriter, r *http.Request) {
@@ -180,22 +183,9 @@ func (c *authController) handleEmailAuth(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	_, err := c.authService.GenerateOTP(ctx, &pb.GenerateOTPRequest{Email: req.Email})
+	_, err := c.authService.GenerateOTP(ctx, &pb.GenerateOTPRequest{Email: req.Email, Locale: utils.Language(r)})
 	if err != nil {
-		if e, ok := status.FromError(err); ok {
-			switch e.Code() {
-			case codes.InvalidArgument:
-				utils.WriteError(w, e.Message(), http.StatusBadRequest)
-				return
-			case codes.Unavailable:
-				logger.Error(ctx, "auth service unavailable", "err", e.Message())
-				utils.WriteError(w, "service unavailable", http.StatusServiceUnavailable)
-				return
-			}
-		}
-
-		logger.Error(ctx, "failed to generate otp", "err", err)
-		utils.WriteError(w, "failed to generate otp", http.StatusInternalServerError)
+		utils.WriteGRPCError(w, r, err)
 		return
 	}
 
@@ -214,7 +204,8 @@ type VerifyEmailRequest struct {
 // @Produce		json
 // @Param			request	body		VerifyEmailRequest		true	"Email и OTP-код"
 // @Success		200		{object}	utils.MessageResponse	"Email verified or login successful"
-// @Failure		400		{object}	utils.ErrorResponse		"Неверные данные или код"
+// @Failure		400		{object}	utils.ErrorResponse		"Неверные данные"
+// @Failure		401		{object}	utils.ErrorResponse		"Неверный или просроченный код"
 // @Failure		500		{object}	utils.ErrorResponse		"Внутренняя ошибка"
 // @Router			/auth/email/verify [post]
 func (c *authController) handleVerifyEmailOTP(w http.ResponseWriter, r *http.Request) {
@@ -233,25 +224,9 @@ func (c *authController) handleVerifyEmailOTP(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	resp, err := c.authService.VerifyOTP(ctx, &pb.VerifyOTPRequest{Email: req.Email, Otp: req.OTP})
+	resp, err := c.authService.VerifyOTP(ctx, &pb.VerifyOTPRequest{Email: req.Email, Otp: req.OTP, Locale: utils.Language(r)})
 	if err != nil {
-		if e, ok := status.FromError(err); ok {
-			switch e.Code() {
-			case codes.InvalidArgument:
-				utils.WriteError(w, e.Message(), http.StatusBadRequest)
-				return
-			case codes.Unauthenticated:
-				utils.WriteError(w, e.Message(), http.StatusUnauthorized)
-				return
-			case codes.Unavailable:
-				logger.Error(ctx, "auth service unavailable", "err", e.Message())
-				utils.WriteError(w, "service unavailable", http.StatusServiceUnavailable)
-				return
-			}
-		}
-
-		logger.Error(ctx, "failed to verify email", "err", err)
-		utils.WriteError(w, "failed to verify email", http.StatusInternalServerError)
+		utils.WriteGRPCError(w, r, err)
 		return
 	}
 
@@ -264,10 +239,27 @@ func (c *authController) handleVerifyEmailOTP(w http.ResponseWriter, r *http.Req
 	}
 }
 
-const (
-	oauthStateCookieName  = "oauth_state"
-	accessTokenCookieName = "access_token"
-)
+// @Summary		Выход
+// @Description	Удаляет cookie с access token
+// @Tags			auth
+// @Produce		json
+// @Success		200	{object}	utils.MessageResponse	"Logged out"
+// @Failure		403	{object}	utils.ErrorResponse		"Запрос с чужого сайта"
+// @Router			/auth/logout [post]
+func (c *authController) handleLogout(w http.ResponseWriter, r *http.Request) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     middleware.AccessTokenCookie,
+		Value:    "",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   true,
+		Path:     "/",
+		SameSite: http.SameSiteLaxMode,
+	})
+	utils.WriteMessage(w, "logged out")
+}
+
+const oauthStateCookieName = "oauth_state"
 
 func checkOAuthState(r *http.Request) bool {
 	expectedState, err := r.Cookie(oauthStateCookieName)
@@ -281,7 +273,7 @@ func checkOAuthState(r *http.Request) bool {
 
 func setAuthTokenToCookie(w http.ResponseWriter, token string) {
 	http.SetCookie(w, &http.Cookie{
-		Name:     accessTokenCookieName,
+		Name:     middleware.AccessTokenCookie,
 		Value:    token,
 		HttpOnly: true,
 		Secure:   true,
@@ -301,6 +293,15 @@ func setOAuthStateToCookie(w http.ResponseWriter, state string) {
 	})
 }
 
+// oauthErrorCode turns the downstream reason into the error query parameter
+// of the failure redirect, e.g. PROVIDER_MISMATCH -> provider_mismatch.
+func oauthErrorCode(err error) string {
+	if reason := utils.ErrorReason(err); reason != "" {
+		return strings.ToLower(reason)
+	}
+	return "oauth_failed"
+}
+
 func generateOAuthState() string {
 	b := make([]byte, 16)
 	rand.Read(b)
@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"FinanceTracker/gateway/pkg/cache"
+	"FinanceTracker/gateway/pkg/events"
+	"FinanceTracker/gateway/pkg/logger"
+	"FinanceTracker/gateway/pkg/tracing"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// eventsController drops cached responses when downstream data changes.
+type eventsController struct {
+	cache  cache.Cache
+	reader *kafka.Reader
+}
+
+func NewEventsController(brokers []string, groupID string, cache cache.Cache) *eventsController {
+	return &eventsController{
+		cache: cache,
+		reader: kafka.NewReader(kafka.ReaderConfig{
+			Brokers: brokers,
+			GroupID: groupID,
+			Topic:   events.TopicProfileUpdated,
+		}),
+	}
+}
+
+func (c *eventsController) Consume(ctx context.Context) {
+	for {
+		m, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, context.Canceled) {
+				break
+			} else {
+				logger.Error(ctx, "failed to fetch message", "err", err)
+				continue
+			}
+		}
+
+		if err := c.process(ctx, m); err != nil {
+			continue
+		}
+
+		if err := c.reader.CommitMessages(ctx, m); err != nil {
+			logger.Error(ctx, "failed to commit message", "err", err)
+		}
+	}
+}
+
+func (c *eventsController) process(ctx context.Context, m kafka.Message) error {
+	ctx, span := tracing.StartConsumerSpan(ctx, m)
+	defer span.End()
+	if id := requestID(m); id != "" {
+		ctx = logger.WithRequestID(ctx, id)
+	}
+
+	if err := c.handleProfileUpdated(ctx, m); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		logger.Error(ctx, "failed to handle message", "err", err)
+		return err
+	}
+	return nil
+}
+
+func (c *eventsController) handleProfileUpdated(ctx context.Context, m kafka.Message) error {
+	var event events.EventProfileUpdated
+	if err := json.Unmarshal(m.Value, &event); err != nil {
+		return fmt.Errorf("failed to decode message: %w", err)
+	}
+
+	return c.cache.Delete(ctx, profileCacheKey(int64(event.UserID)))
+}
+
+func (c *eventsController) Close() error {
+	return c.reader.Close()
+}
+
+func requestID(m kafka.Message) string {
+	for _, h := range m.Headers {
+		if h.Key == logger.RequestIDKey {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
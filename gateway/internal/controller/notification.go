@@ -0,0 +1,199 @@
+package controller
+
+import (
+	pb "FinanceTracker/gateway/pkg/api/notification"
+	"FinanceTracker/gateway/pkg/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type notificationController struct {
+	notificationService pb.NotificationServiceClient
+	auth                func(http.Handler) http.Handler
+}
+
+func NewNotificationController(notificationService pb.NotificationServiceClient, auth func(http.Handler) http.Handler) *notificationController {
+	return &notificationController{
+		notificationService: notificationService,
+		auth:                auth,
+	}
+}
+
+func (c *notificationController) Init(r *http.ServeMux) {
+	r.Handle("GET /notifications", c.auth(http.HandlerFunc(c.handleList)))
+	r.Handle("GET /notifications/unread-count", c.auth(http.HandlerFunc(c.handleUnreadCount)))
+	r.Handle("POST /notifications/{id}/read", c.auth(http.HandlerFunc(c.handleMarkRead)))
+	r.Handle("POST /notifications/read-all", c.auth(http.HandlerFunc(c.handleMarkAllRead)))
+}
+
+type NotificationResponse struct {
+	ID        int64      `json:"id"`
+	Type      string     `json:"type" example:"welcome"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	Link      string     `json:"link,omitempty" example:"/subscriptions/42"`
+	ReadAt    *time.Time `json:"read_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type NotificationsResponse struct {
+	Items      []NotificationResponse `json:"items"`
+	NextCursor string                 `json:"next_cursor,omitempty"`
+}
+
+type UnreadCountResponse struct {
+	Count int64 `json:"count"`
+}
+
+type MarkAllReadResponse struct {
+	Updated int64 `json:"updated"`
+}
+
+func protoToNotificationResponse(n *pb.Notification) NotificationResponse {
+	return NotificationResponse{
+		ID:        n.NotificationId,
+		Type:      n.Type,
+		Title:     n.Title,
+		Body:      n.Body,
+		Link:      n.Link,
+		ReadAt:    optionalTime(n.ReadAt),
+		CreatedAt: n.CreatedAt.AsTime(),
+	}
+}
+
+func optionalTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+// @Summary Список уведомлений
+// @Description Возвращает уведомления текущего пользователя, новые первыми. Следующая страница запрашивается с cursor из next_cursor.
+// @Tags Уведомления
+// @Security BearerAuth
+// @Produce json
+// @Param limit query int false "Размер страницы (1-100, по умолчанию 20)"
+// @Param cursor query string false "Курсор следующей страницы"
+// @Param unread query bool false "Только непрочитанные"
+// @Success 200 {object} NotificationsResponse "Страница уведомлений"
+// @Failure 400 {object} utils.ErrorResponse "Некорректные параметры"
+// @Failure 503 {object} utils.ErrorResponse "Сервис недоступен"
+// @Failure 500 {object} utils.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /notifications [get]
+func (c *notificationController) handleList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	q := r.URL.Query()
+
+	req := &pb.ListNotificationsRequest{
+		UserId: utils.GetUserID(ctx),
+		Cursor: q.Get("cursor"),
+	}
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > 100 {
+			utils.WriteError(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		req.PageSize = int32(limit)
+	}
+	if v := q.Get("unread"); v != "" {
+		unread, err := strconv.ParseBool(v)
+		if err != nil {
+			utils.WriteError(w, "invalid unread", http.StatusBadRequest)
+			return
+		}
+		req.UnreadOnly = unread
+	}
+
+	resp, err := c.notificationService.ListNotifications(ctx, req)
+	if err != nil {
+		utils.WriteGRPCError(w, r, err)
+		return
+	}
+
+	res := NotificationsResponse{
+		Items:      make([]NotificationResponse, len(resp.Notifications)),
+		NextCursor: resp.NextCursor,
+	}
+	for i, n := range resp.Notifications {
+		res.Items[i] = protoToNotificationResponse(n)
+	}
+	utils.WriteJSON(w, res, http.StatusOK)
+}
+
+// @Summary Число непрочитанных уведомлений
+// @Tags Уведомления
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} UnreadCountResponse "Число непрочитанных"
+// @Failure 503 {object} utils.ErrorResponse "Сервис недоступен"
+// @Failure 500 {object} utils.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /notifications/unread-count [get]
+func (c *notificationController) handleUnreadCount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	resp, err := c.notificationService.GetUnreadCount(ctx, &pb.GetUnreadCountRequest{UserId: utils.GetUserID(ctx)})
+	if err != nil {
+		utils.WriteGRPCError(w, r, err)
+		return
+	}
+
+	utils.WriteJSON(w, UnreadCountResponse{Count: resp.Count}, http.StatusOK)
+}
+
+// @Summary Отметить уведомление прочитанным
+// @Tags Уведомления
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID уведомления"
+// @Success 200 {object} NotificationResponse "Прочитанное уведомление"
+// @Failure 400 {object} utils.ErrorResponse "Некорректный ID"
+// @Failure 404 {object} utils.ErrorResponse "Уведомление не найдено"
+// @Failure 503 {object} utils.ErrorResponse "Сервис недоступен"
+// @Failure 500 {object} utils.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /notifications/{id}/read [post]
+func (c *notificationController) handleMarkRead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || id <= 0 {
+		utils.WriteError(w, "invalid notification id", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := c.notificationService.MarkRead(ctx, &pb.MarkReadRequest{
+		UserId:         utils.GetUserID(ctx),
+		NotificationId: id,
+	})
+	if err != nil {
+		utils.WriteGRPCError(w, r, err)
+		return
+	}
+
+	utils.WriteJSON(w, protoToNotificationResponse(resp), http.StatusOK)
+}
+
+// @Summary Отметить все уведомления прочитанными
+// @Tags Уведомления
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} MarkAllReadResponse "Число отмеченных уведомлений"
+// @Failure 503 {object} utils.ErrorResponse "Сервис недоступен"
+// @Failure 500 {object} utils.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /notifications/read-all [post]
+func (c *notificationController) handleMarkAllRead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	resp, err := c.notificationService.MarkAllRead(ctx, &pb.MarkAllReadRequest{UserId: utils.GetUserID(ctx)})
+	if err != nil {
+		utils.WriteGRPCError(w, r, err)
+		return
+	}
+
+	utils.WriteJSON(w, MarkAllReadResponse{Updated: resp.Updated}, http.StatusOK)
+}
@@ -2,33 +2,49 @@ package controller
 
 import (
 	pb "FinanceTracker/gateway/pkg/api/profile"
+	"FinanceTracker/gateway/pkg/cache"
 	"FinanceTracker/gateway/pkg/logger"
 	"FinanceTracker/gateway/pkg/utils"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/go-playground/validator/v10"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 type profileController struct {
 	validate       *validator.Validate
 	profileService pb.ProfileServiceClient
 	auth           func(http.Handler) http.Handler
+	idempotency    func(http.Handler) http.Handler
+	cache          cache.Cache
+	cacheTTL       time.Duration
 }
 
-func NewProfileController(profileService pb.ProfileServiceClient, auth func(http.Handler) http.Handler) *profileController {
+func NewProfileController(profileService pb.ProfileServiceClient, auth, idempotency func(http.Handler) http.Handler, cache cache.Cache, cacheTTL time.Duration) *profileController {
 	return &profileController{
 		validate:       validator.New(),
 		profileService: profileService,
 		auth:           auth,
+		idempotency:    idempotency,
+		cache:          cache,
+		cacheTTL:       cacheTTL,
 	}
 }
 
+// profileCacheKey names the cached ProfileResponse of a user.
+func profileCacheKey(userID int64) string {
+	return fmt.Sprintf("profile:%d", userID)
+}
+
 func (c *profileController) Init(r *http.ServeMux) {
 	r.Handle("GET /profile/me", c.auth(http.HandlerFunc(c.handleGetMe)))
-	r.Handle("PUT /profile/update", c.auth(http.HandlerFunc(c.handleUpdateProfile)))
+	r.Handle("PUT /profile/update", c.auth(c.idempotency(http.HandlerFunc(c.handleUpdateProfile))))
+	r.Handle("GET /profile/notification-preferences", c.auth(http.HandlerFunc(c.handleGetPreferences)))
+	r.Handle("PUT /profile/notification-preferences", c.auth(http.HandlerFunc(c.handleUpdatePreferences)))
 }
 
 type ProfileResponse struct {
@@ -37,15 +53,31 @@ type ProfileResponse struct {
 	Provider string `json:"provider"`
 	FullName string `json:"full_name,omitempty"`
 	AvatarID string `json:"avatar_id,omitempty"`
+	Locale   string `json:"locale" example:"ru"` // language of notifications
+	// AvatarVariants are square copies of the avatar for srcset, empty for
+	// avatars uploaded before they were made
+	AvatarVariants []AvatarVariant `json:"avatar_variants"`
+}
+
+type AvatarVariant struct {
+	URL    string `json:"url" example:"http://localhost:9000/finance-tracker/avatars/1/128.webp"`
+	Size   int32  `json:"size" example:"128"`
+	Format string `json:"format" example:"webp"` // jpeg or webp
 }
 
 func protoToProfileResponse(resp *pb.Profile) ProfileResponse {
+	variants := make([]AvatarVariant, len(resp.AvatarVariants))
+	for i, v := range resp.AvatarVariants {
+		variants[i] = AvatarVariant{URL: v.Url, Size: v.Size, Format: v.Format}
+	}
 	return ProfileResponse{
-		UserID:   resp.UserId,
-		Email:    resp.Email,
-		Provider: resp.Provider,
-		FullName: resp.FullName,
-		AvatarID: resp.AvatarId,
+		UserID:         resp.UserId,
+		Email:          resp.Email,
+		Provider:       resp.Provider,
+		FullName:       resp.FullName,
+		AvatarID:       resp.AvatarId,
+		Locale:         resp.Locale,
+		AvatarVariants: variants,
 	}
 }
 
@@ -55,51 +87,64 @@ func protoToProfileResponse(resp *pb.Profile) ProfileResponse {
 // @Security BearerAuth
 // @Accept json
 // @Produce json
+// @Param If-None-Match header string false "ETag из предыдущего ответа"
 // @Success 200 {object} ProfileResponse "Успешный ответ с данными профиля"
-// @Failure 400 {object} utils.ErrorResponse "Профиль не найден"
+// @Success 304 "Профиль не изменился"
+// @Failure 404 {object} utils.ErrorResponse "Профиль не найден"
 // @Failure 503 {object} utils.ErrorResponse "Сервис недоступен"
+// @Failure 504 {object} utils.ErrorResponse "Сервис не ответил вовремя"
 // @Failure 500 {object} utils.ErrorResponse "Внутренняя ошибка сервера"
 // @Router /profile/me [get]
 func (c *profileController) handleGetMe(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	userID := utils.GetUserID(ctx)
+	key := profileCacheKey(userID)
 
-	resp, err := c.profileService.GetProfile(ctx, &pb.GetProfileRequest{
-		UserId: userID,
-	})
-
+	body, err := c.cache.Get(ctx, key)
 	if err != nil {
-		if e, ok := status.FromError(err); ok {
-			switch e.Code() {
-			case codes.NotFound:
-				utils.WriteError(w, e.Message(), http.StatusBadRequest)
-				return
-			case codes.Unavailable:
-				logger.Error(ctx, "profile service unavailable", "err", e.Message())
-				utils.WriteError(w, "service unavailable", http.StatusServiceUnavailable)
-				return
-			}
+		if !errors.Is(err, cache.ErrMiss) {
+			logger.Error(ctx, "failed to get cached profile", "err", err)
 		}
 
-		logger.Error(ctx, "failed to get profile", "err", err)
-		utils.WriteError(w, "internal server error", http.StatusInternalServerError)
-		return
+		resp, err := c.profileService.GetProfile(ctx, &pb.GetProfileRequest{
+			UserId: userID,
+		})
+		if err != nil {
+			utils.WriteGRPCError(w, r, err)
+			return
+		}
+
+		body, err = json.Marshal(protoToProfileResponse(resp))
+		if err != nil {
+			logger.Error(ctx, "failed to encode profile", "err", err)
+			utils.WriteError(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		if err := c.cache.Set(ctx, key, body, c.cacheTTL); err != nil {
+			logger.Error(ctx, "failed to cache profile", "err", err)
+		}
 	}
 
-	utils.WriteJSON(w, protoToProfileResponse(resp), http.StatusOK)
+	utils.WriteETagJSON(w, r, body)
 }
 
 // @Summary Обновить профиль текущего пользователя
-// @Description Принимает имя и/или аватар (multipart/form-data) и обновляет профиль
+// @Description Принимает имя, аватар и/или язык уведомлений (multipart/form-data) и обновляет профиль
 // @Tags Профиль
 // @Security BearerAuth
 // @Accept multipart/form-data
 // @Produce json
 // @Param name formData string false "Имя пользователя"
 // @Param avatar formData file false "Файл аватара (image/*)"
+// @Param locale formData string false "Язык уведомлений: ru или en"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор с тем же ключом вернет сохраненный ответ"
 // @Success 200 {object} ProfileResponse "Успешный ответ с обновленным профилем"
 // @Failure 400 {object} utils.ErrorResponse "Неверные данные или нечего обновлять"
+// @Failure 409 {object} utils.ErrorResponse "Запрос с этим ключом идемпотентности еще выполняется"
+// @Failure 422 {object} utils.ErrorResponse "Ключ идемпотентности уже использован с другим запросом"
+// @Failure 404 {object} utils.ErrorResponse "Профиль не найден"
 // @Failure 503 {object} utils.ErrorResponse "Сервис недоступен"
+// @Failure 504 {object} utils.ErrorResponse "Сервис не ответил вовремя"
 // @Failure 500 {object} utils.ErrorResponse "Внутренняя ошибка сервера"
 // @Router /profile/update [put]
 func (c *profileController) handleUpdateProfile(w http.ResponseWriter, r *http.Request) {
@@ -119,6 +164,16 @@ func (c *profileController) handleUpdateProfile(w http.ResponseWriter, r *http.R
 		fullNamePtr = &name
 	}
 
+	// Optional notification language
+	var localePtr *string
+	if locale := r.FormValue("locale"); locale != "" {
+		if locale != utils.LangRu && locale != utils.LangEn {
+			utils.WriteError(w, "locale must be ru or en", http.StatusBadRequest)
+			return
+		}
+		localePtr = &locale
+	}
+
 	// Optional avatar file
 	var avatarBytes []byte
 	file, _, err := r.FormFile("avatar")
@@ -133,7 +188,7 @@ func (c *profileController) handleUpdateProfile(w http.ResponseWriter, r *http.R
 		avatarBytes = data
 	}
 
-	if fullNamePtr == nil && len(avatarBytes) == 0 {
+	if fullNamePtr == nil && localePtr == nil && len(avatarBytes) == 0 {
 		utils.WriteError(w, "nothing to update", http.StatusBadRequest)
 		return
 	}
@@ -142,24 +197,137 @@ func (c *profileController) handleUpdateProfile(w http.ResponseWriter, r *http.R
 		UserId:      userID,
 		FullName:    fullNamePtr,
 		AvatarBytes: avatarBytes,
+		Locale:      localePtr,
 	})
 	if err != nil {
-		if e, ok := status.FromError(err); ok {
-			switch e.Code() {
-			case codes.NotFound:
-				utils.WriteError(w, e.Message(), http.StatusBadRequest)
-				return
-			case codes.Unavailable:
-				logger.Error(ctx, "profile service unavailable", "err", e.Message())
-				utils.WriteError(w, "service unavailable", http.StatusServiceUnavailable)
-				return
-			}
-		}
-
-		logger.Error(ctx, "failed to update profile", "err", err)
-		utils.WriteError(w, "internal server error", http.StatusInternalServerError)
+		utils.WriteGRPCError(w, r, err)
 		return
 	}
 
+	if err := c.cache.Delete(ctx, profileCacheKey(userID)); err != nil {
+		logger.Error(ctx, "failed to invalidate cached profile", "err", err)
+	}
+
 	utils.WriteJSON(w, protoToProfileResponse(resp), http.StatusOK)
 }
+
+// NotificationPreferences is both the response and the full replacement
+// accepted by PUT. Quiet hours are "HH:MM" in the user's timezone; both empty
+// turns them off; messages that arrive during them reach the inbox at once and
+// the other channels when the quiet hours end. In digest mode the
+// upcoming charges come in one email at 9:00 local time; the digest fields
+// default to a weekly digest on Monday.
+// EmailUnsubscribed lists the email categories turned off by the link in the
+// emails; PUT keeps the stored list when it is omitted.
+type NotificationPreferences struct {
+	Channels          []string  `json:"channels" validate:"unique,dive,oneof=email in_app telegram web_push" example:"email,in_app"`
+	ReminderLeadDays  int32     `json:"reminder_lead_days" validate:"min=0,max=30" example:"1"`
+	QuietHoursStart   string    `json:"quiet_hours_start,omitempty" validate:"required_with=QuietHoursEnd,omitempty,datetime=15:04" example:"22:00"`
+	QuietHoursEnd     string    `json:"quiet_hours_end,omitempty" validate:"required_with=QuietHoursStart,omitempty,datetime=15:04" example:"08:00"`
+	Timezone          string    `json:"timezone" validate:"required,timezone" example:"Europe/Moscow"`
+	Mode              string    `json:"mode" validate:"required,oneof=immediate digest" example:"immediate"`
+	DigestFrequency   string    `json:"digest_frequency,omitempty" validate:"omitempty,oneof=daily weekly" example:"weekly"`
+	DigestDay         string    `json:"digest_day,omitempty" validate:"omitempty,oneof=monday tuesday wednesday thursday friday saturday sunday" example:"monday"`
+	EmailUnsubscribed *[]string `json:"email_unsubscribed" validate:"omitnil,unique,dive,oneof=reminders digest" example:"digest"`
+}
+
+func protoToPreferences(resp *pb.NotificationPreferences) NotificationPreferences {
+	channels := resp.Channels
+	if channels == nil {
+		channels = []string{}
+	}
+	unsubscribed := resp.EmailUnsubscribed
+	if unsubscribed == nil {
+		unsubscribed = []string{}
+	}
+	return NotificationPreferences{
+		Channels:          channels,
+		ReminderLeadDays:  resp.ReminderLeadDays,
+		QuietHoursStart:   resp.QuietHoursStart,
+		QuietHoursEnd:     resp.QuietHoursEnd,
+		Timezone:          resp.Timezone,
+		Mode:              resp.Mode,
+		DigestFrequency:   resp.DigestFrequency,
+		DigestDay:         resp.DigestDay,
+		EmailUnsubscribed: &unsubscribed,
+	}
+}
+
+// @Summary Получить настройки уведомлений
+// @Description Возвращает каналы доставки, тихие часы, режим уведомлений и расписание дайджеста. Если пользователь их не менял, возвращаются значения по умолчанию.
+// @Tags Профиль
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} NotificationPreferences "Настройки уведомлений"
+// @Failure 503 {object} utils.ErrorResponse "Сервис недоступен"
+// @Failure 504 {object} utils.ErrorResponse "Сервис не ответил вовремя"
+// @Failure 500 {object} utils.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /profile/notification-preferences [get]
+func (c *profileController) handleGetPreferences(w http.ResponseWriter, r *http.Request) {
+	resp, err := c.profileService.GetNotificationPreferences(r.Context(), &pb.GetNotificationPreferencesRequest{
+		UserId: utils.GetUserID(r.Context()),
+	})
+	if err != nil {
+		utils.WriteGRPCError(w, r, err)
+		return
+	}
+
+	utils.WriteJSON(w, protoToPreferences(resp), http.StatusOK)
+}
+
+// @Summary Обновить настройки уведомлений
+// @Description Полностью заменяет настройки уведомлений; если email_unsubscribed не передан, сохраненный список отписок не меняется. В тихие часы уведомления сразу попадают во входящие, а письма, сообщения в Telegram и push-уведомления отправляются после их окончания. В режиме дайджеста предстоящие списания приходят одним письмом в 9:00 по времени пользователя. Код входа и письма безопасности отправляются независимо от настроек.
+// @Tags Профиль
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body NotificationPreferences true "Новые настройки"
+// @Success 200 {object} NotificationPreferences "Сохраненные настройки"
+// @Failure 400 {object} utils.ErrorResponse "Некорректные настройки"
+// @Failure 404 {object} utils.ErrorResponse "Профиль не найден"
+// @Failure 503 {object} utils.ErrorResponse "Сервис недоступен"
+// @Failure 504 {object} utils.ErrorResponse "Сервис не ответил вовремя"
+// @Failure 500 {object} utils.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /profile/notification-preferences [put]
+func (c *profileController) handleUpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req NotificationPreferences
+	if err := utils.DecodeBody(r, &req); err != nil {
+		logger.Debug(ctx, "failed to decode body", "err", err)
+		utils.WriteError(w, "invalid body", http.StatusBadRequest)
+		return
+	}
+
+	if err := c.validate.Struct(req); err != nil {
+		logger.Debug(ctx, "invalid request", "err", err)
+		utils.WriteValidationError(w, err)
+		return
+	}
+
+	// clients that predate unsubscribe links must not undo them
+	var unsubscribed []string
+	if req.EmailUnsubscribed != nil {
+		unsubscribed = *req.EmailUnsubscribed
+	}
+
+	resp, err := c.profileService.UpdateNotificationPreferences(ctx, &pb.NotificationPreferences{
+		UserId:                utils.GetUserID(ctx),
+		Channels:              req.Channels,
+		ReminderLeadDays:      req.ReminderLeadDays,
+		QuietHoursStart:       req.QuietHoursStart,
+		QuietHoursEnd:         req.QuietHoursEnd,
+		Timezone:              req.Timezone,
+		Mode:                  req.Mode,
+		DigestFrequency:       req.DigestFrequency,
+		DigestDay:             req.DigestDay,
+		EmailUnsubscribed:     unsubscribed,
+		KeepEmailUnsubscribed: req.EmailUnsubscribed == nil,
+	})
+	if err != nil {
+		utils.WriteGRPCError(w, r, err)
+		return
+	}
+
+	utils.WriteJSON(w, protoToPreferences(resp), http.StatusOK)
+}
@@ -0,0 +1,161 @@
+package controller
+
+import (
+	pb "FinanceTracker/gateway/pkg/api/notification"
+	"FinanceTracker/gateway/pkg/logger"
+	"FinanceTracker/gateway/pkg/utils"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type pushController struct {
+	validate            *validator.Validate
+	notificationService pb.NotificationServiceClient
+	auth                func(http.Handler) http.Handler
+}
+
+func NewPushController(notificationService pb.NotificationServiceClient, auth func(http.Handler) http.Handler) *pushController {
+	return &pushController{
+		validate:            validator.New(),
+		notificationService: notificationService,
+		auth:                auth,
+	}
+}
+
+func (c *pushController) Init(r *http.ServeMux) {
+	r.Handle("GET /notifications/push/public-key", c.auth(http.HandlerFunc(c.handlePublicKey)))
+	r.Handle("POST /notifications/push/subscriptions", c.auth(http.HandlerFunc(c.handleSubscribe)))
+	r.Handle("DELETE /notifications/push/subscriptions", c.auth(http.HandlerFunc(c.handleUnsubscribe)))
+}
+
+type PushPublicKeyResponse struct {
+	PublicKey string `json:"public_key"`
+}
+
+// PushSubscriptionRequest is PushSubscription.toJSON() of the browser.
+type PushSubscriptionRequest struct {
+	Endpoint string `json:"endpoint" validate:"required,url,max=2048"`
+	Keys     struct {
+		P256dh string `json:"p256dh" validate:"required"`
+		Auth   string `json:"auth" validate:"required"`
+	} `json:"keys"`
+}
+
+type PushSubscriptionResponse struct {
+	ID        int64     `json:"id"`
+	Endpoint  string    `json:"endpoint"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type PushUnsubscribeRequest struct {
+	Endpoint string `json:"endpoint" validate:"required"`
+}
+
+// @Summary Ключ для подписки на push-уведомления
+// @Description Возвращает VAPID-ключ для PushManager.subscribe (applicationServerKey).
+// @Tags Уведомления
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} PushPublicKeyResponse "Публичный VAPID-ключ"
+// @Failure 501 {object} utils.ErrorResponse "Push-уведомления не настроены"
+// @Failure 503 {object} utils.ErrorResponse "Сервис недоступен"
+// @Failure 500 {object} utils.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /notifications/push/public-key [get]
+func (c *pushController) handlePublicKey(w http.ResponseWriter, r *http.Request) {
+	resp, err := c.notificationService.GetPushPublicKey(r.Context(), &pb.GetPushPublicKeyRequest{})
+	if err != nil {
+		utils.WriteGRPCError(w, r, err)
+		return
+	}
+
+	utils.WriteJSON(w, PushPublicKeyResponse{PublicKey: resp.PublicKey}, http.StatusOK)
+}
+
+// @Summary Подписать браузер на push-уведомления
+// @Description Сохраняет подписку браузера и включает канал web_push в настройках уведомлений пользователя. Повторная регистрация того же endpoint обновляет ключи.
+// @Tags Уведомления
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body PushSubscriptionRequest true "Подписка из PushSubscription.toJSON()"
+// @Success 201 {object} PushSubscriptionResponse "Подписка сохранена"
+// @Failure 400 {object} utils.ErrorResponse "Некорректная подписка"
+// @Failure 501 {object} utils.ErrorResponse "Push-уведомления не настроены"
+// @Failure 503 {object} utils.ErrorResponse "Сервис недоступен"
+// @Failure 500 {object} utils.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /notifications/push/subscriptions [post]
+func (c *pushController) handleSubscribe(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req PushSubscriptionRequest
+	if err := utils.DecodeBody(r, &req); err != nil {
+		logger.Debug(ctx, "failed to decode body", "err", err)
+		utils.WriteError(w, "invalid body", http.StatusBadRequest)
+		return
+	}
+
+	if err := c.validate.Struct(req); err != nil {
+		logger.Debug(ctx, "invalid request", "err", err)
+		utils.WriteValidationError(w, err)
+		return
+	}
+
+	resp, err := c.notificationService.RegisterPushSubscription(ctx, &pb.RegisterPushSubscriptionRequest{
+		UserId:   utils.GetUserID(ctx),
+		Endpoint: req.Endpoint,
+		P256Dh:   req.Keys.P256dh,
+		Auth:     req.Keys.Auth,
+	})
+	if err != nil {
+		utils.WriteGRPCError(w, r, err)
+		return
+	}
+
+	utils.WriteJSON(w, PushSubscriptionResponse{
+		ID:        resp.SubscriptionId,
+		Endpoint:  resp.Endpoint,
+		CreatedAt: resp.CreatedAt.AsTime(),
+	}, http.StatusCreated)
+}
+
+// @Summary Отписать браузер от push-уведомлений
+// @Description Удаляет подписку текущего пользователя. Удаление несуществующей подписки не является ошибкой.
+// @Tags Уведомления
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body PushUnsubscribeRequest true "Endpoint подписки"
+// @Success 200 {object} utils.MessageResponse "Подписка удалена"
+// @Failure 400 {object} utils.ErrorResponse "Некорректные данные"
+// @Failure 503 {object} utils.ErrorResponse "Сервис недоступен"
+// @Failure 500 {object} utils.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /notifications/push/subscriptions [delete]
+func (c *pushController) handleUnsubscribe(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req PushUnsubscribeRequest
+	if err := utils.DecodeBody(r, &req); err != nil {
+		logger.Debug(ctx, "failed to decode body", "err", err)
+		utils.WriteError(w, "invalid body", http.StatusBadRequest)
+		return
+	}
+
+	if err := c.validate.Struct(req); err != nil {
+		logger.Debug(ctx, "invalid request", "err", err)
+		utils.WriteValidationError(w, err)
+		return
+	}
+
+	_, err := c.notificationService.UnregisterPushSubscription(ctx, &pb.UnregisterPushSubscriptionRequest{
+		UserId:   utils.GetUserID(ctx),
+		Endpoint: req.Endpoint,
+	})
+	if err != nil {
+		utils.WriteGRPCError(w, r, err)
+		return
+	}
+
+	utils.WriteMessage(w, "push subscription removed")
+}
@@ -0,0 +1,185 @@
+package controller
+
+import (
+	"FinanceTracker/gateway/pkg/logger"
+	"FinanceTracker/gateway/pkg/stream"
+	"FinanceTracker/gateway/pkg/tracing"
+	"FinanceTracker/gateway/pkg/utils"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// EventResync tells a reconnected client that events were missed and its
+// state has to be reloaded.
+const EventResync = "resync"
+
+// streamController pushes user-scoped Kafka events to browsers over
+// Server-Sent Events.
+type streamController struct {
+	hub       *stream.Hub
+	auth      func(http.Handler) http.Handler
+	heartbeat time.Duration
+	retry     time.Duration
+	reader    *kafka.Reader
+}
+
+// NewStreamController consumes topics whose messages carry a user_id. Every
+// replica must use its own groupID to see the events of all users.
+func NewStreamController(brokers []string, groupID string, topics []string, hub *stream.Hub, auth func(http.Handler) http.Handler, heartbeat, retry time.Duration) *streamController {
+	return &streamController{
+		hub:       hub,
+		auth:      auth,
+		heartbeat: heartbeat,
+		retry:     retry,
+		reader: kafka.NewReader(kafka.ReaderConfig{
+			Brokers:     brokers,
+			GroupID:     groupID,
+			GroupTopics: topics,
+			// a new replica must not replay the history to connected users
+			StartOffset: kafka.LastOffset,
+		}),
+	}
+}
+
+func (c *streamController) Init(r *http.ServeMux) {
+	r.Handle("GET /events/stream", c.auth(http.HandlerFunc(c.handleStream)))
+}
+
+// @Summary Поток событий пользователя
+// @Description Server-Sent Events: имя события совпадает с топиком (например, profile.updated), данные - JSON события.
+// @Description После переподключения пропущенные события досылаются по Last-Event-ID. Если их уже нет, приходит событие resync и состояние нужно загрузить заново.
+// @Description Браузер может авторизоваться cookie access_token (EventSource с withCredentials).
+// @Tags События
+// @Security BearerAuth
+// @Produce text/event-stream
+// @Param Last-Event-ID header string false "ID последнего полученного события"
+// @Success 200 {string} string "Поток событий"
+// @Failure 401 {object} utils.ErrorResponse "Не авторизован"
+// @Router /events/stream [get]
+func (c *streamController) handleStream(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rc := http.NewResponseController(w)
+
+	sub, missed, ok := c.hub.Subscribe(utils.GetUserID(ctx), r.Header.Get("Last-Event-ID"))
+	defer c.hub.Unsubscribe(sub)
+
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-store")
+	h.Set("X-Accel-Buffering", "no") // disable proxy buffering
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprintf(w, "retry: %d\n\n", c.retry.Milliseconds())
+	if !ok {
+		writeEvent(w, stream.Event{Type: EventResync, Data: []byte("{}")})
+	}
+	for _, e := range missed {
+		writeEvent(w, e)
+	}
+	if err := rc.Flush(); err != nil {
+		logger.Error(ctx, "failed to flush event stream", "err", err)
+		return
+	}
+
+	heartbeat := time.NewTicker(c.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-sub.C:
+			if !ok {
+				// the hub is closing or the client fell behind: it reconnects with Last-Event-ID
+				return
+			}
+			writeEvent(w, e)
+		case <-heartbeat.C:
+			io.WriteString(w, ": heartbeat\n\n")
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func writeEvent(w io.Writer, e stream.Event) {
+	if e.ID != "" {
+		fmt.Fprintf(w, "id: %s\n", e.ID)
+	}
+	fmt.Fprintf(w, "event: %s\n", e.Type)
+	for _, line := range strings.Split(string(e.Data), "\n") {
+		fmt.Fprintf(w, "data: %s\n", line)
+	}
+	io.WriteString(w, "\n")
+}
+
+func (c *streamController) Consume(ctx context.Context) {
+	for {
+		m, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, context.Canceled) {
+				break
+			} else {
+				logger.Error(ctx, "failed to fetch message", "err", err)
+				continue
+			}
+		}
+
+		if err := c.process(ctx, m); err != nil {
+			continue
+		}
+
+		if err := c.reader.CommitMessages(ctx, m); err != nil {
+			logger.Error(ctx, "failed to commit message", "err", err)
+		}
+	}
+}
+
+func (c *streamController) process(ctx context.Context, m kafka.Message) error {
+	ctx, span := tracing.StartConsumerSpan(ctx, m)
+	defer span.End()
+	if id := requestID(m); id != "" {
+		ctx = logger.WithRequestID(ctx, id)
+	}
+
+	var event struct {
+		UserID int64 `json:"user_id"`
+	}
+	err := json.Unmarshal(m.Value, &event)
+	if err == nil && event.UserID == 0 {
+		err = errors.New("missing user_id")
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to decode %s message: %w", m.Topic, err)
+		span.SetStatus(codes.Error, err.Error())
+		logger.Error(ctx, "failed to handle message", "err", err)
+		return err
+	}
+
+	c.hub.Publish(event.UserID, stream.Event{
+		// a user's events share a partition, so offsets keep them ordered
+		ID:   fmt.Sprintf("%s-%d-%d", m.Topic, m.Partition, m.Offset),
+		Type: m.Topic,
+		Data: m.Value,
+	})
+	return nil
+}
+
+// Shutdown ends open streams, so that the server can stop gracefully.
+func (c *streamController) Shutdown() {
+	c.hub.Close()
+}
+
+func (c *streamController) Close() error {
+	return c.reader.Close()
+}
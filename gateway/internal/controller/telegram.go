@@ -0,0 +1,115 @@
+package controller
+
+import (
+	pb "FinanceTracker/gateway/pkg/api/profile"
+	"FinanceTracker/gateway/pkg/utils"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type telegramController struct {
+	profileService pb.ProfileServiceClient
+	auth           func(http.Handler) http.Handler
+	botUsername    string
+}
+
+func NewTelegramController(profileService pb.ProfileServiceClient, auth func(http.Handler) http.Handler, botUsername string) *telegramController {
+	return &telegramController{
+		profileService: profileService,
+		auth:           auth,
+		botUsername:    botUsername,
+	}
+}
+
+func (c *telegramController) Init(r *http.ServeMux) {
+	r.Handle("GET /profile/telegram", c.auth(http.HandlerFunc(c.handleGetLink)))
+	r.Handle("POST /profile/telegram/link", c.auth(http.HandlerFunc(c.handleCreateLinkCode)))
+	r.Handle("DELETE /profile/telegram", c.auth(http.HandlerFunc(c.handleUnlink)))
+}
+
+type TelegramLinkResponse struct {
+	Linked   bool       `json:"linked"`
+	Username string     `json:"username,omitempty" example:"alice"`
+	LinkedAt *time.Time `json:"linked_at,omitempty"`
+}
+
+type TelegramLinkCodeResponse struct {
+	Code      string    `json:"code"`
+	URL       string    `json:"url,omitempty" example:"https://t.me/FinanceTrackerBot?start=Xy7..."`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// @Summary Статус подключения Telegram
+// @Tags Профиль
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} TelegramLinkResponse "Подключенный чат или linked=false"
+// @Failure 503 {object} utils.ErrorResponse "Сервис недоступен"
+// @Failure 500 {object} utils.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /profile/telegram [get]
+func (c *telegramController) handleGetLink(w http.ResponseWriter, r *http.Request) {
+	resp, err := c.profileService.GetTelegramLink(r.Context(), &pb.GetTelegramLinkRequest{
+		UserId: utils.GetUserID(r.Context()),
+	})
+	if err != nil {
+		utils.WriteGRPCError(w, r, err)
+		return
+	}
+
+	res := TelegramLinkResponse{Linked: resp.Linked, Username: resp.Username}
+	if resp.Linked {
+		res.LinkedAt = optionalTime(resp.LinkedAt)
+	}
+	utils.WriteJSON(w, res, http.StatusOK)
+}
+
+// @Summary Получить ссылку для подключения Telegram
+// @Description Выдает одноразовый код, который нужно отправить боту командой /start. Ссылка url открывает бота с уже подставленным кодом. Предыдущий код перестает действовать.
+// @Tags Профиль
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} TelegramLinkCodeResponse "Код и ссылка на бота"
+// @Failure 404 {object} utils.ErrorResponse "Профиль не найден"
+// @Failure 503 {object} utils.ErrorResponse "Сервис недоступен"
+// @Failure 500 {object} utils.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /profile/telegram/link [post]
+func (c *telegramController) handleCreateLinkCode(w http.ResponseWriter, r *http.Request) {
+	resp, err := c.profileService.CreateTelegramLinkCode(r.Context(), &pb.CreateTelegramLinkCodeRequest{
+		UserId: utils.GetUserID(r.Context()),
+	})
+	if err != nil {
+		utils.WriteGRPCError(w, r, err)
+		return
+	}
+
+	res := TelegramLinkCodeResponse{
+		Code:      resp.Code,
+		ExpiresAt: resp.ExpiresAt.AsTime(),
+	}
+	if c.botUsername != "" {
+		res.URL = "https://t.me/" + c.botUsername + "?start=" + url.QueryEscape(resp.Code)
+	}
+	utils.WriteJSON(w, res, http.StatusOK)
+}
+
+// @Summary Отключить Telegram
+// @Description Бот перестает присылать уведомления. Повторное отключение не является ошибкой.
+// @Tags Профиль
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} utils.MessageResponse "Telegram отключен"
+// @Failure 503 {object} utils.ErrorResponse "Сервис недоступен"
+// @Failure 500 {object} utils.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /profile/telegram [delete]
+func (c *telegramController) handleUnlink(w http.ResponseWriter, r *http.Request) {
+	_, err := c.profileService.UnlinkTelegram(r.Context(), &pb.UnlinkTelegramRequest{
+		UserId: utils.GetUserID(r.Context()),
+	})
+	if err != nil {
+		utils.WriteGRPCError(w, r, err)
+		return
+	}
+
+	utils.WriteMessage(w, "telegram unlinked")
+}
@@ -0,0 +1,70 @@
+package controller
+
+import (
+	pb "FinanceTracker/gateway/pkg/api/profile"
+	"FinanceTracker/gateway/pkg/logger"
+	"FinanceTracker/gateway/pkg/unsubscribe"
+	"FinanceTracker/gateway/pkg/utils"
+	"net/http"
+)
+
+// CodeInvalidUnsubscribeToken is returned for a forged or mangled link.
+const CodeInvalidUnsubscribeToken = "INVALID_UNSUBSCRIBE_TOKEN"
+
+type unsubscribeController struct {
+	profileService pb.ProfileServiceClient
+	signer         *unsubscribe.Signer
+}
+
+func NewUnsubscribeController(profileService pb.ProfileServiceClient, signer *unsubscribe.Signer) *unsubscribeController {
+	return &unsubscribeController{
+		profileService: profileService,
+		signer:         signer,
+	}
+}
+
+func (c *unsubscribeController) Init(r *http.ServeMux) {
+	// the token is the credential, mail clients post here without a session
+	r.HandleFunc("POST /notifications/unsubscribe", c.handleUnsubscribe)
+}
+
+type UnsubscribeResponse struct {
+	Category string `json:"category" example:"digest"`
+}
+
+// @Summary Отписаться от писем по ссылке
+// @Description Отключает категорию писем по токену из ссылки в письме, вход не нужен. Почтовые клиенты вызывают метод сами по заголовку List-Unsubscribe-Post (RFC 8058). Повторная отписка не является ошибкой. Включить письма снова можно в настройках уведомлений.
+// @Tags Уведомления
+// @Produce json
+// @Param token query string true "Токен из ссылки"
+// @Success 200 {object} UnsubscribeResponse "Категория, от которой отписан пользователь"
+// @Failure 400 {object} utils.ErrorResponse "Недействительная ссылка"
+// @Failure 404 {object} utils.ErrorResponse "Профиль не найден"
+// @Failure 503 {object} utils.ErrorResponse "Сервис недоступен"
+// @Failure 500 {object} utils.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /notifications/unsubscribe [post]
+func (c *unsubscribeController) handleUnsubscribe(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, category, err := c.signer.Verify(r.URL.Query().Get("token"))
+	if err != nil {
+		logger.Debug(ctx, "invalid unsubscribe token", "err", err)
+		utils.WriteJSON(w, utils.ErrorResponse{
+			Code:    CodeInvalidUnsubscribeToken,
+			Status:  http.StatusBadRequest,
+			Message: utils.Localize(CodeInvalidUnsubscribeToken, utils.Language(r), "invalid unsubscribe token"),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	_, err = c.profileService.UnsubscribeEmail(ctx, &pb.UnsubscribeEmailRequest{
+		UserId:   userID,
+		Category: category,
+	})
+	if err != nil {
+		utils.WriteGRPCError(w, r, err)
+		return
+	}
+
+	utils.WriteJSON(w, UnsubscribeResponse{Category: category}, http.StatusOK)
+}
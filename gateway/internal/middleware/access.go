@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"FinanceTracker/gateway/pkg/logger"
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// sensitiveParams are query parameters whose values never reach the logs.
+var sensitiveParams = []string{"code", "state", "otp", "token", "access_token"}
+
+type accessEntry struct {
+	userID int64
+}
+
+type accessEntryKey struct{}
+
+// NewAccessLog logs every request after it is served. It must receive the
+// same *http.Request as the mux to see the matched route pattern.
+func NewAccessLog() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			entry := &accessEntry{}
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+			r = r.WithContext(context.WithValue(r.Context(), accessEntryKey{}, entry))
+			next.ServeHTTP(rec, r)
+
+			args := []any{
+				"method", r.Method,
+				"route", r.Pattern,
+				"path", r.URL.Path,
+				"query", redactQuery(r.URL.Query()),
+				"status", rec.status,
+				"bytes", rec.bytes,
+				"duration", time.Since(start),
+				"ip", clientIP(r),
+			}
+			if entry.userID != 0 {
+				args = append(args, "user_id", entry.userID)
+			}
+			logger.Info(r.Context(), "http request", args...)
+		})
+	}
+}
+
+// setAccessUserID makes the authenticated user visible to the access log.
+func setAccessUserID(ctx context.Context, userID int64) {
+	if entry, ok := ctx.Value(accessEntryKey{}).(*accessEntry); ok {
+		entry.userID = userID
+	}
+}
+
+func redactQuery(q url.Values) string {
+	for _, key := range sensitiveParams {
+		if q.Has(key) {
+			q.Set(key, "REDACTED")
+		}
+	}
+	return q.Encode()
+}
@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"FinanceTracker/gateway/pkg/logger"
+)
+
+func TestNewAccessLog(t *testing.T) {
+	testCases := []struct {
+		name      string
+		query     string
+		wantQuery url.Values
+	}{
+		{
+			name:      "no_query",
+			wantQuery: url.Values{},
+		},
+		{
+			name:      "plain_params_are_kept",
+			query:     "page_size=20&cursor=abc",
+			wantQuery: url.Values{"page_size": {"20"}, "cursor": {"abc"}},
+		},
+		{
+			name:      "oauth_callback",
+			query:     "code=4%2F0Adeu5B&state=xyz&scope=email",
+			wantQuery: url.Values{"code": {"REDACTED"}, "state": {"REDACTED"}, "scope": {"email"}},
+		},
+		{
+			name:      "otp",
+			query:     "otp=482913",
+			wantQuery: url.Values{"otp": {"REDACTED"}},
+		},
+		{
+			name:      "tokens",
+			query:     "token=1.digest.sig&access_token=eyJhbGci",
+			wantQuery: url.Values{"token": {"REDACTED"}, "access_token": {"REDACTED"}},
+		},
+		{
+			name:      "repeated_param",
+			query:     "token=a&token=b",
+			wantQuery: url.Values{"token": {"REDACTED"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			log := slog.New(slog.NewJSONHandler(&buf, nil))
+			handler := NewAccessLog()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusTeapot)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/auth/callback?"+tc.query, nil)
+			req = req.WithContext(logger.WithLogger(req.Context(), log))
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			var entry struct {
+				Msg    string `json:"msg"`
+				Path   string `json:"path"`
+				Query  string `json:"query"`
+				Status int    `json:"status"`
+			}
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+			assert.Equal(t, "http request", entry.Msg)
+			assert.Equal(t, "/auth/callback", entry.Path)
+			assert.Equal(t, http.StatusTeapot, entry.Status)
+			gotQuery, err := url.ParseQuery(entry.Query)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantQuery, gotQuery)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"FinanceTracker/gateway/pkg/logger"
 	"FinanceTracker/gateway/pkg/utils"
 	"net/http"
 	"strconv"
@@ -8,17 +9,34 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
-func NewAuth(secretKey []byte) func(next http.Handler) http.Handler {
+// AccessTokenCookie holds the JWT issued to browser sessions.
+const AccessTokenCookie = "access_token"
+
+// NewAuth authenticates requests by the Authorization: Bearer header or, if
+// the header is absent, by the access token cookie. A present header always
+// wins, even when its token is invalid. Cookie-authenticated mutating
+// requests must come from one of trustedOrigins.
+func NewAuth(secretKey []byte, trustedOrigins *Origins) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			authHeader := r.Header.Get("Authorization")
-			if len(authHeader) < len("Bearer ") || authHeader[:7] != "Bearer " {
-				utils.WriteError(w, "invalid authorization format", http.StatusUnauthorized)
+			var token string
+			if authHeader := r.Header.Get("Authorization"); authHeader != "" {
+				if len(authHeader) < len("Bearer ") || authHeader[:7] != "Bearer " {
+					utils.WriteError(w, "invalid authorization format", http.StatusUnauthorized)
+					return
+				}
+				token = authHeader[len("Bearer "):]
+			} else if cookie, err := r.Cookie(AccessTokenCookie); err == nil && cookie.Value != "" {
+				if !isSameOriginRequest(r, trustedOrigins) {
+					utils.WriteError(w, "cross-site request rejected", http.StatusForbidden)
+					return
+				}
+				token = cookie.Value
+			} else {
+				utils.WriteError(w, "missing authorization", http.StatusUnauthorized)
 				return
 			}
 
-			token := authHeader[len("Bearer "):]
-
 			claims, err := verify(token, secretKey)
 			if err != nil {
 				utils.WriteError(w, "invalid token", http.StatusUnauthorized)
@@ -35,7 +53,10 @@ func NewAuth(secretKey []byte) func(next http.Handler) http.Handler {
 				return
 			}
 
-			next.ServeHTTP(w, r.WithContext(utils.WithUserID(r.Context(), userId)))
+			setAccessUserID(r.Context(), userId)
+			ctx := utils.WithUserID(r.Context(), userId)
+			ctx = logger.WithLogger(ctx, logger.FromContext(ctx).With("user_id", userId))
+			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
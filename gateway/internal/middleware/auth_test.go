@@ -0,0 +1,140 @@
+package middleware
+
+import (
+	"FinanceTracker/gateway/pkg/logger"
+	"FinanceTracker/gateway/pkg/utils"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAuth(t *testing.T) {
+	secret := []byte("secret")
+	sign := func(sub string) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": sub}).SignedString(secret)
+		require.NoError(t, err)
+		return token
+	}
+	valid, other := sign("42"), sign("7")
+
+	testCases := []struct {
+		name       string
+		method     string
+		header     string
+		cookie     string
+		origin     string
+		referer    string
+		wantStatus int
+		wantUserID int64
+	}{
+		{
+			name:       "bearer",
+			method:     http.MethodPost,
+			header:     "Bearer " + valid,
+			wantStatus: http.StatusOK,
+			wantUserID: 42,
+		},
+		{
+			name:       "bearer_wins_over_cookie",
+			method:     http.MethodGet,
+			header:     "Bearer " + other,
+			cookie:     valid,
+			wantStatus: http.StatusOK,
+			wantUserID: 7,
+		},
+		{
+			name:       "invalid_bearer_does_not_fall_back_to_cookie",
+			method:     http.MethodGet,
+			header:     "Bearer garbage",
+			cookie:     valid,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "cookie_safe_method",
+			method:     http.MethodGet,
+			cookie:     valid,
+			wantStatus: http.StatusOK,
+			wantUserID: 42,
+		},
+		{
+			name:       "cookie_mutation_from_trusted_origin",
+			method:     http.MethodPut,
+			cookie:     valid,
+			origin:     "http://localhost:3000",
+			wantStatus: http.StatusOK,
+			wantUserID: 42,
+		},
+		{
+			name:       "cookie_mutation_from_trusted_referer",
+			method:     http.MethodPut,
+			cookie:     valid,
+			referer:    "http://localhost:3000/profile",
+			wantStatus: http.StatusOK,
+			wantUserID: 42,
+		},
+		{
+			name:       "cookie_mutation_from_foreign_origin",
+			method:     http.MethodPut,
+			cookie:     valid,
+			origin:     "https://evil.example",
+			referer:    "http://localhost:3000/profile",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "cookie_mutation_without_origin",
+			method:     http.MethodPost,
+			cookie:     valid,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "cookie_invalid_token",
+			method:     http.MethodGet,
+			cookie:     "garbage",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "no_credentials",
+			method:     http.MethodGet,
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	origins, err := ParseOrigins([]string{"*", "http://localhost:3000"})
+	require.NoError(t, err)
+	auth := NewAuth(secret, origins)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotUserID int64
+			handler := auth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotUserID = utils.GetUserID(r.Context())
+			}))
+
+			req := httptest.NewRequest(tc.method, "/profile/update", nil)
+			req = req.WithContext(logger.WithLogger(req.Context(), slog.New(slog.DiscardHandler)))
+			if tc.header != "" {
+				req.Header.Set("Authorization", tc.header)
+			}
+			if tc.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: AccessTokenCookie, Value: tc.cookie})
+			}
+			if tc.origin != "" {
+				req.Header.Set("Origin", tc.origin)
+			}
+			if tc.referer != "" {
+				req.Header.Set("Referer", tc.referer)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.wantStatus, rec.Code)
+			assert.Equal(t, tc.wantUserID, gotUserID)
+		})
+	}
+}
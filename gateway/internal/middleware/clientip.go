@@ -0,0 +1,139 @@
+package middleware
+
+import (
+	"FinanceTracker/gateway/pkg/utils"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// Forwarding headers the trusted proxies may set.
+const (
+	HeaderXForwardedFor = "x-forwarded-for"
+	HeaderForwarded     = "forwarded"
+)
+
+// NewClientIP resolves the client IP and stores it in the request context.
+// The forwarding header is honoured only when the peer is a trusted proxy; the
+// chain is then walked from the right and the first untrusted hop wins, so a
+// client can't spoof its address by sending the header itself. Only the
+// header the proxies write is read: most of them pass the other one from the
+// client through untouched.
+func NewClientIP(trustedProxies []string, header string) (func(next http.Handler) http.Handler, error) {
+	trusted, err := parsePrefixes(trustedProxies)
+	if err != nil {
+		return nil, err
+	}
+	header = strings.ToLower(header)
+	if header != HeaderXForwardedFor && header != HeaderForwarded {
+		return nil, fmt.Errorf("invalid trusted proxy header %q, want %s or %s", header, HeaderXForwardedFor, HeaderForwarded)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := resolveClientIP(r, trusted, header)
+			next.ServeHTTP(w, r.WithContext(utils.WithClientIP(r.Context(), ip)))
+		})
+	}, nil
+}
+
+func parsePrefixes(values []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		if !strings.Contains(v, "/") {
+			addr, err := netip.ParseAddr(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q: %w", v, err)
+			}
+			prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+		p, err := netip.ParsePrefix(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", v, err)
+		}
+		prefixes = append(prefixes, p.Masked())
+	}
+	return prefixes, nil
+}
+
+func isTrusted(addr netip.Addr, trusted []netip.Prefix) bool {
+	for _, p := range trusted {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func resolveClientIP(r *http.Request, trusted []netip.Prefix, header string) string {
+	peer, ok := parseHost(r.RemoteAddr)
+	if !ok {
+		return ""
+	}
+	if !isTrusted(peer, trusted) {
+		return peer.String()
+	}
+
+	var chain []string
+	switch header {
+	case HeaderForwarded:
+		chain = parseForwarded(r.Header.Values("Forwarded"))
+	default:
+		for _, v := range r.Header.Values("X-Forwarded-For") {
+			chain = append(chain, strings.Split(v, ",")...)
+		}
+	}
+
+	client := peer
+	for i := len(chain) - 1; i >= 0; i-- {
+		addr, ok := parseHost(chain[i])
+		if !ok {
+			// an unparsable hop can't be attributed, so stop at the last proxy we trust
+			break
+		}
+		client = addr
+		if !isTrusted(addr, trusted) {
+			break
+		}
+	}
+	return client.String()
+}
+
+// parseForwarded extracts the "for" parameters of RFC 7239 Forwarded headers.
+func parseForwarded(values []string) []string {
+	var chain []string
+	for _, v := range values {
+		for _, element := range strings.Split(v, ",") {
+			node := ""
+			for _, pair := range strings.Split(element, ";") {
+				key, value, _ := strings.Cut(strings.TrimSpace(pair), "=")
+				if strings.EqualFold(key, "for") {
+					node = strings.Trim(value, `"`)
+				}
+			}
+			chain = append(chain, node)
+		}
+	}
+	return chain
+}
+
+// parseHost accepts "ip", "ip:port", "[ipv6]" and "[ipv6]:port".
+func parseHost(s string) (netip.Addr, bool) {
+	s = strings.TrimSpace(s)
+	if host, _, err := net.SplitHostPort(s); err == nil {
+		s = host
+	}
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "["), "]")
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr.Unmap(), true
+}
@@ -0,0 +1,154 @@
+package middleware
+
+import (
+	"FinanceTracker/gateway/pkg/utils"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClientIP(t *testing.T) {
+	trusted := []string{"10.0.0.0/8", "192.168.1.1", "fd00::/8"}
+
+	testCases := []struct {
+		name       string
+		header     string // the trusted one, x-forwarded-for if empty
+		remoteAddr string
+		headers    map[string]string
+		wantIP     string
+	}{
+		{
+			name:       "direct_client",
+			remoteAddr: "203.0.113.7:5555",
+			wantIP:     "203.0.113.7",
+		},
+		{
+			name:       "spoofed_xff_from_untrusted_peer",
+			remoteAddr: "203.0.113.7:5555",
+			headers:    map[string]string{"X-Forwarded-For": "1.1.1.1"},
+			wantIP:     "203.0.113.7",
+		},
+		{
+			name:       "spoofed_forwarded_from_untrusted_peer",
+			header:     HeaderForwarded,
+			remoteAddr: "203.0.113.7:5555",
+			headers:    map[string]string{"Forwarded": "for=1.1.1.1"},
+			wantIP:     "203.0.113.7",
+		},
+		{
+			name:       "xff_from_trusted_proxy",
+			remoteAddr: "10.0.0.5:80",
+			headers:    map[string]string{"X-Forwarded-For": "198.51.100.20"},
+			wantIP:     "198.51.100.20",
+		},
+		{
+			name:       "spoofed_entry_prepended_by_client",
+			remoteAddr: "10.0.0.5:80",
+			headers:    map[string]string{"X-Forwarded-For": "1.1.1.1, 198.51.100.20"},
+			wantIP:     "198.51.100.20",
+		},
+		{
+			name:       "chain_of_trusted_proxies",
+			remoteAddr: "10.0.0.5:80",
+			headers:    map[string]string{"X-Forwarded-For": "198.51.100.20, 192.168.1.1, 10.1.2.3"},
+			wantIP:     "198.51.100.20",
+		},
+		{
+			name:       "garbage_hop_stops_at_last_trusted",
+			remoteAddr: "10.0.0.5:80",
+			headers:    map[string]string{"X-Forwarded-For": "198.51.100.20, not-an-ip"},
+			wantIP:     "10.0.0.5",
+		},
+		{
+			name:       "only_trusted_hops",
+			remoteAddr: "10.0.0.5:80",
+			headers:    map[string]string{"X-Forwarded-For": "10.0.0.9"},
+			wantIP:     "10.0.0.9",
+		},
+		{
+			name:       "spoofed_forwarded_behind_xff_proxy",
+			remoteAddr: "10.0.0.5:80",
+			headers: map[string]string{
+				"Forwarded":       "for=1.2.3.4",
+				"X-Forwarded-For": "198.51.100.20",
+			},
+			wantIP: "198.51.100.20",
+		},
+		{
+			name:       "forwarded_from_trusted_proxy",
+			header:     HeaderForwarded,
+			remoteAddr: "10.0.0.5:80",
+			headers: map[string]string{
+				"Forwarded":       `for=198.51.100.20;proto=https, for="10.0.0.9:4711"`,
+				"X-Forwarded-For": "1.1.1.1",
+			},
+			wantIP: "198.51.100.20",
+		},
+		{
+			name:       "spoofed_xff_behind_forwarded_proxy",
+			header:     HeaderForwarded,
+			remoteAddr: "10.0.0.5:80",
+			headers:    map[string]string{"X-Forwarded-For": "1.2.3.4"},
+			wantIP:     "10.0.0.5",
+		},
+		{
+			name:       "forwarded_ipv6",
+			header:     HeaderForwarded,
+			remoteAddr: "[fd00::1]:80",
+			headers:    map[string]string{"Forwarded": `for="[2001:db8::17]:4711"`},
+			wantIP:     "2001:db8::17",
+		},
+		{
+			name:       "forwarded_obfuscated_identifier",
+			header:     HeaderForwarded,
+			remoteAddr: "10.0.0.5:80",
+			headers:    map[string]string{"Forwarded": "for=_hidden"},
+			wantIP:     "10.0.0.5",
+		},
+		{
+			name:       "ipv4_mapped_peer",
+			remoteAddr: "[::ffff:10.0.0.5]:80",
+			headers:    map[string]string{"X-Forwarded-For": "198.51.100.20"},
+			wantIP:     "198.51.100.20",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			header := tc.header
+			if header == "" {
+				header = HeaderXForwardedFor
+			}
+			mw, err := NewClientIP(trusted, header)
+			require.NoError(t, err)
+
+			var gotIP string
+			handler := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotIP = utils.GetClientIP(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tc.remoteAddr
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, tc.wantIP, gotIP)
+		})
+	}
+}
+
+func TestNewClientIP_InvalidProxy(t *testing.T) {
+	_, err := NewClientIP([]string{"10.0.0.0/33"}, HeaderXForwardedFor)
+	assert.Error(t, err)
+
+	_, err = NewClientIP([]string{"proxy.local"}, HeaderXForwardedFor)
+	assert.Error(t, err)
+
+	_, err = NewClientIP([]string{"10.0.0.0/8"}, "x-real-ip")
+	assert.Error(t, err)
+}
@@ -2,44 +2,97 @@ package middleware
 
 import (
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type CORSConfig struct {
-	AllowedOrigins   []string
+	AllowedOrigins   []string // origins, "https://*.example.com" patterns or "*"
 	AllowedMethods   []string
 	AllowedHeaders   []string
+	ExposedHeaders   []string
 	AllowCredentials bool
+	MaxAge           time.Duration // how long browsers may cache a preflight response
 }
 
-func NewCORS(cfg CORSConfig) func(next http.Handler) http.Handler {
+// NewCORS answers preflight requests and adds CORS headers to responses for
+// allowed origins. With "*" any origin may read responses, but never with
+// credentials: only explicitly configured origins get Allow-Credentials.
+func NewCORS(cfg CORSConfig) (func(next http.Handler) http.Handler, error) {
+	origins, err := ParseOrigins(cfg.AllowedOrigins)
+	if err != nil {
+		return nil, err
+	}
+
+	allowedMethods := strings.Join(cfg.AllowedMethods, ", ")
+	allowedHeaders := strings.Join(cfg.AllowedHeaders, ", ")
+	exposedHeaders := strings.Join(cfg.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(int(cfg.MaxAge.Seconds()))
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h := w.Header()
 			origin := r.Header.Get("Origin")
-			if origin != "" && isOriginAllowed(origin, cfg.AllowedOrigins...) {
-				w.Header().Set("Access-Control-Allow-Origin", origin)
-				if cfg.AllowCredentials {
-					w.Header().Set("Access-Control-Allow-Credentials", "true")
+			preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+			if preflight {
+				h.Add("Vary", "Origin")
+				h.Add("Vary", "Access-Control-Request-Method")
+				h.Add("Vary", "Access-Control-Request-Headers")
+
+				if origin == "" || !origins.Allowed(origin) ||
+					!slices.Contains(cfg.AllowedMethods, r.Header.Get("Access-Control-Request-Method")) ||
+					!headersAllowed(r.Header.Get("Access-Control-Request-Headers"), cfg.AllowedHeaders) {
+					w.WriteHeader(http.StatusForbidden)
+					return
 				}
-				w.Header().Set("Access-Control-Allow-Methods", strings.Join(cfg.AllowedMethods, ", "))
-				w.Header().Set("Access-Control-Allow-Headers", strings.Join(cfg.AllowedHeaders, ", "))
-			}
 
-			if r.Method == http.MethodOptions {
+				setAllowOrigin(h, origin, origins, cfg.AllowCredentials)
+				h.Set("Access-Control-Allow-Methods", allowedMethods)
+				h.Set("Access-Control-Allow-Headers", allowedHeaders)
+				if cfg.MaxAge > 0 {
+					h.Set("Access-Control-Max-Age", maxAge)
+				}
 				w.WriteHeader(http.StatusNoContent)
 				return
 			}
 
+			h.Add("Vary", "Origin")
+			if origin != "" && origins.Allowed(origin) {
+				setAllowOrigin(h, origin, origins, cfg.AllowCredentials)
+				if exposedHeaders != "" {
+					h.Set("Access-Control-Expose-Headers", exposedHeaders)
+				}
+			}
+
 			next.ServeHTTP(w, r)
 		})
+	}, nil
+}
+
+func setAllowOrigin(h http.Header, origin string, origins *Origins, allowCredentials bool) {
+	if !origins.Trusted(origin) {
+		h.Set("Access-Control-Allow-Origin", "*")
+		return
+	}
+	h.Set("Access-Control-Allow-Origin", origin)
+	if allowCredentials {
+		h.Set("Access-Control-Allow-Credentials", "true")
 	}
 }
 
-func isOriginAllowed(origin string, allowedOrigins ...string) bool {
-	for _, o := range allowedOrigins {
-		if o == "*" || o == origin {
-			return true
+// headersAllowed checks the comma-separated Access-Control-Request-Headers.
+func headersAllowed(requested string, allowed []string) bool {
+	for _, name := range strings.Split(requested, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !slices.ContainsFunc(allowed, func(a string) bool { return strings.EqualFold(a, name) }) {
+			return false
 		}
 	}
-	return false
+	return true
 }
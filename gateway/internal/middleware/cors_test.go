@@ -0,0 +1,175 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCORS(t *testing.T) {
+	cfg := CORSConfig{
+		AllowedOrigins:   []string{"http://localhost:3000", "https://*.example.com"},
+		AllowedMethods:   []string{"GET", "POST"},
+		AllowedHeaders:   []string{"Content-Type", "Authorization"},
+		ExposedHeaders:   []string{"ETag"},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	}
+
+	testCases := []struct {
+		name            string
+		cfg             CORSConfig
+		method          string
+		headers         map[string]string
+		wantStatus      int
+		wantOrigin      string
+		wantCredentials string
+		wantExposed     string
+		wantMaxAge      string
+	}{
+		{
+			name:            "exact_origin",
+			method:          http.MethodGet,
+			headers:         map[string]string{"Origin": "http://localhost:3000"},
+			wantStatus:      http.StatusOK,
+			wantOrigin:      "http://localhost:3000",
+			wantCredentials: "true",
+			wantExposed:     "ETag",
+		},
+		{
+			name:            "subdomain_pattern",
+			method:          http.MethodGet,
+			headers:         map[string]string{"Origin": "https://app.eu.example.com"},
+			wantStatus:      http.StatusOK,
+			wantOrigin:      "https://app.eu.example.com",
+			wantCredentials: "true",
+			wantExposed:     "ETag",
+		},
+		{
+			name:       "pattern_does_not_match_apex_or_other_scheme",
+			method:     http.MethodGet,
+			headers:    map[string]string{"Origin": "http://app.example.com"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "pattern_does_not_match_lookalike",
+			method:     http.MethodGet,
+			headers:    map[string]string{"Origin": "https://evilexample.com"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "unknown_origin",
+			method:     http.MethodGet,
+			headers:    map[string]string{"Origin": "https://evil.test"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:        "wildcard_never_allows_credentials",
+			cfg:         CORSConfig{AllowedOrigins: []string{"*"}, AllowedMethods: []string{"GET"}, ExposedHeaders: []string{"ETag"}, AllowCredentials: true},
+			method:      http.MethodGet,
+			headers:     map[string]string{"Origin": "https://evil.test"},
+			wantStatus:  http.StatusOK,
+			wantOrigin:  "*",
+			wantExposed: "ETag",
+		},
+		{
+			name:   "preflight",
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                         "http://localhost:3000",
+				"Access-Control-Request-Method":  "POST",
+				"Access-Control-Request-Headers": "content-type, authorization",
+			},
+			wantStatus:      http.StatusNoContent,
+			wantOrigin:      "http://localhost:3000",
+			wantCredentials: "true",
+			wantMaxAge:      "600",
+		},
+		{
+			name:       "preflight_unknown_origin",
+			method:     http.MethodOptions,
+			headers:    map[string]string{"Origin": "https://evil.test", "Access-Control-Request-Method": "POST"},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "preflight_method_not_allowed",
+			method:     http.MethodOptions,
+			headers:    map[string]string{"Origin": "http://localhost:3000", "Access-Control-Request-Method": "DELETE"},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:   "preflight_header_not_allowed",
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                         "http://localhost:3000",
+				"Access-Control-Request-Method":  "POST",
+				"Access-Control-Request-Headers": "X-Secret",
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "plain_options_reaches_handler",
+			method:     http.MethodOptions,
+			headers:    map[string]string{"Origin": "http://localhost:3000"},
+			wantStatus: http.StatusOK,
+			wantOrigin: "http://localhost:3000", wantCredentials: "true", wantExposed: "ETag",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := cfg
+			if tc.cfg.AllowedOrigins != nil {
+				c = tc.cfg
+			}
+			cors, err := NewCORS(c)
+			require.NoError(t, err)
+			handler := cors(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+			req := httptest.NewRequest(tc.method, "/", nil)
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			h := rec.Header()
+			assert.Equal(t, tc.wantStatus, rec.Code)
+			assert.Equal(t, tc.wantOrigin, h.Get("Access-Control-Allow-Origin"))
+			assert.Equal(t, tc.wantCredentials, h.Get("Access-Control-Allow-Credentials"))
+			assert.Equal(t, tc.wantExposed, h.Get("Access-Control-Expose-Headers"))
+			assert.Equal(t, tc.wantMaxAge, h.Get("Access-Control-Max-Age"))
+			assert.Contains(t, h.Values("Vary"), "Origin")
+		})
+	}
+}
+
+func TestParseOrigins_Invalid(t *testing.T) {
+	for _, v := range []string{"localhost:3000", "https://", "https://app.example.com/path", "https://*.", "https://app.*.example.com", "https://*.*.example.com"} {
+		_, err := ParseOrigins([]string{v})
+		assert.Error(t, err, v)
+	}
+}
+
+func TestNewSecurityHeaders(t *testing.T) {
+	handler := NewSecurityHeaders(SecurityHeadersConfig{
+		HSTSMaxAge:     time.Hour,
+		ReferrerPolicy: "no-referrer",
+		SwaggerCSP:     "default-src 'self'",
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/swagger/index.html", nil))
+	assert.Equal(t, "nosniff", rec.Header().Get("X-Content-Type-Options"))
+	assert.Equal(t, "no-referrer", rec.Header().Get("Referrer-Policy"))
+	assert.Equal(t, "max-age=3600; includeSubDomains", rec.Header().Get("Strict-Transport-Security"))
+	assert.Equal(t, "default-src 'self'", rec.Header().Get("Content-Security-Policy"))
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/profile/me", nil))
+	assert.Empty(t, rec.Header().Get("Content-Security-Policy"))
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"FinanceTracker/gateway/pkg/utils"
+	"net/http"
+	"net/url"
+)
+
+// NewCSRF rejects mutating requests that carry the access token cookie but
+// don't come from one of trustedOrigins. Requests without the cookie can't
+// ride on a browser session, so they are let through.
+func NewCSRF(trustedOrigins *Origins) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, err := r.Cookie(AccessTokenCookie); err == nil && !isSameOriginRequest(r, trustedOrigins) {
+				utils.WriteError(w, "cross-site request rejected", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// isSameOriginRequest reports whether a request is safe to authenticate with a cookie:
+// safe methods always are, mutating ones need an Origin, or a Referer
+// if Origin is missing, from trustedOrigins.
+func isSameOriginRequest(r *http.Request, trustedOrigins *Origins) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+
+	origin := r.Header.Get("Origin")
+	if origin == "" || origin == "null" {
+		referer, err := url.Parse(r.Header.Get("Referer"))
+		if err != nil || referer.Scheme == "" || referer.Host == "" {
+			return false
+		}
+		origin = referer.Scheme + "://" + referer.Host
+	}
+
+	// a wildcard allows reading responses, but must not allow writes with the cookie
+	return trustedOrigins.Trusted(origin)
+}
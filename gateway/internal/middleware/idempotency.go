@@ -0,0 +1,238 @@
+package middleware
+
+import (
+	"FinanceTracker/gateway/pkg/logger"
+	"FinanceTracker/gateway/pkg/utils"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotentReplayedHeader  = "Idempotent-Replayed"
+	CodeIdempotencyKeyReused  = "IDEMPOTENCY_KEY_REUSED"
+	CodeIdempotencyInProgress = "IDEMPOTENCY_IN_PROGRESS"
+
+	maxIdempotencyKeyLength = 255
+	// idempotencyLockTTL bounds how long a request that never completed,
+	// e.g. because the replica died, blocks retries with its key.
+	idempotencyLockTTL = time.Minute
+)
+
+// replayHeaders are the response headers stored with the body. The rest
+// are set again by the outer middlewares when a response is replayed.
+var replayHeaders = []string{"Content-Type", "Cache-Control", "ETag", "Location"}
+
+// IdempotencyRecord is the stored outcome of a request. A record without
+// Done belongs to a request that is still in flight.
+type IdempotencyRecord struct {
+	Fingerprint string              `json:"fingerprint"`
+	Done        bool                `json:"done"`
+	Status      int                 `json:"status,omitempty"`
+	Header      map[string][]string `json:"header,omitempty"`
+	Body        []byte              `json:"body,omitempty"`
+}
+
+// IdempotencyStore keeps records, so that they can be shared between replicas.
+type IdempotencyStore interface {
+	// Reserve saves a pending record unless key is taken, in which case it
+	// returns the existing record and false.
+	Reserve(ctx context.Context, key, fingerprint string, ttl time.Duration) (IdempotencyRecord, bool, error)
+	// Complete replaces the record of key with the finished one.
+	Complete(ctx context.Context, key string, record IdempotencyRecord, ttl time.Duration) error
+	// Release deletes the record, so that the request can be retried.
+	Release(ctx context.Context, key string) error
+}
+
+// NewIdempotency replays the stored response of a request retried with the
+// same Idempotency-Key for ttl. Keys are scoped by route and user, and a key
+// reused with another body is rejected. Requests without the header, server
+// errors and rate limited responses are not stored. The request body is
+// buffered, so it is limited to maxBody bytes.
+func NewIdempotency(store IdempotencyStore, ttl time.Duration, maxBody int64) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			idempotencyKey := r.Header.Get(IdempotencyKeyHeader)
+			if idempotencyKey == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(idempotencyKey) > maxIdempotencyKeyLength {
+				utils.WriteError(w, "Idempotency-Key is too long", http.StatusBadRequest)
+				return
+			}
+
+			ctx := r.Context()
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBody))
+			if err != nil {
+				var maxBytesErr *http.MaxBytesError
+				if errors.As(err, &maxBytesErr) {
+					utils.WriteError(w, "request body is too large", http.StatusRequestEntityTooLarge)
+					return
+				}
+				utils.WriteError(w, "cannot read body", http.StatusBadRequest)
+				return
+			}
+			r.Body.Close()
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			key := "idempotency:" + r.Pattern + ":" + strconv.FormatInt(utils.GetUserID(ctx), 10) + ":" + idempotencyKey
+			fingerprint := requestFingerprint(r, body)
+
+			record, reserved, err := store.Reserve(ctx, key, fingerprint, idempotencyLockTTL)
+			if err != nil {
+				// fail open like the rate limiter: an unavailable store must not take the API down
+				logger.Error(ctx, "failed to reserve idempotency key", "err", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !reserved {
+				replayRecord(w, record, fingerprint)
+				return
+			}
+
+			rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+			completed := false
+			defer func() {
+				if completed {
+					return
+				}
+				// the handler failed or panicked: let the client retry
+				if err := store.Release(context.WithoutCancel(ctx), key); err != nil {
+					logger.Error(ctx, "failed to release idempotency key", "err", err)
+				}
+			}()
+
+			next.ServeHTTP(rec, r)
+
+			if rec.status >= http.StatusInternalServerError || rec.status == http.StatusTooManyRequests {
+				return
+			}
+			record = IdempotencyRecord{
+				Fingerprint: fingerprint,
+				Done:        true,
+				Status:      rec.status,
+				Header:      make(map[string][]string),
+				Body:        rec.body.Bytes(),
+			}
+			for _, h := range replayHeaders {
+				if v := rec.Header().Values(h); len(v) > 0 {
+					record.Header[h] = v
+				}
+			}
+			if err := store.Complete(context.WithoutCancel(ctx), key, record, ttl); err != nil {
+				logger.Error(ctx, "failed to store idempotent response", "err", err)
+				return
+			}
+			completed = true
+		})
+	}
+}
+
+// replayRecord answers a retried request from the record of the first one.
+func replayRecord(w http.ResponseWriter, record IdempotencyRecord, fingerprint string) {
+	if record.Fingerprint != fingerprint {
+		utils.WriteJSON(w, utils.ErrorResponse{
+			Code:    CodeIdempotencyKeyReused,
+			Status:  http.StatusUnprocessableEntity,
+			Message: "Idempotency-Key was already used with another request",
+		}, http.StatusUnprocessableEntity)
+		return
+	}
+	if !record.Done {
+		w.Header().Set("Retry-After", "1")
+		utils.WriteJSON(w, utils.ErrorResponse{
+			Code:    CodeIdempotencyInProgress,
+			Status:  http.StatusConflict,
+			Message: "request with this Idempotency-Key is in progress",
+		}, http.StatusConflict)
+		return
+	}
+
+	h := w.Header()
+	for k, v := range record.Header {
+		h[k] = v
+	}
+	h.Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(record.Status)
+	w.Write(record.Body)
+}
+
+// requestFingerprint identifies a request by its route, media type and body.
+// Multipart bodies are compared by their fields, because a client that
+// builds the form again on retry gets another boundary.
+func requestFingerprint(r *http.Request, body []byte) string {
+	mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		mediaType, params = r.Header.Get("Content-Type"), nil
+	}
+
+	h := sha256.New()
+	io.WriteString(h, r.Method+" "+r.URL.Path+"\n"+mediaType+"\n")
+	if fields, ok := multipartFields(mediaType, params["boundary"], body); ok {
+		for _, f := range fields {
+			io.WriteString(h, f+"\n")
+		}
+	} else {
+		h.Write(body)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// multipartFields describes every part by its name, file name and a hash of
+// the content, sorted so that the order of the fields doesn't matter.
+func multipartFields(mediaType, boundary string, body []byte) ([]string, bool) {
+	if !strings.HasPrefix(mediaType, "multipart/") || boundary == "" {
+		return nil, false
+	}
+	mr := multipart.NewReader(bytes.NewReader(body), boundary)
+	var fields []string
+	for {
+		part, err := mr.NextRawPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, false
+		}
+		content := sha256.New()
+		if _, err := io.Copy(content, part); err != nil {
+			return nil, false
+		}
+		fields = append(fields, strconv.Quote(part.FormName())+" "+strconv.Quote(part.FileName())+" "+hex.EncodeToString(content.Sum(nil)))
+	}
+	slices.Sort(fields)
+	return fields, true
+}
+
+// responseRecorder copies the response body while writing it.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
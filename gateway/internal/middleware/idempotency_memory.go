@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type idempotencyEntry struct {
+	record    IdempotencyRecord
+	expiresAt time.Time
+}
+
+// memoryIdempotencyStore keeps records in process. Expired records are
+// evicted on access and every sweepInterval.
+type memoryIdempotencyStore struct {
+	mu            sync.Mutex
+	entries       map[string]idempotencyEntry
+	sweepInterval time.Duration
+	lastSweep     time.Time
+	now           func() time.Time
+}
+
+func NewMemoryIdempotencyStore(sweepInterval time.Duration) *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{
+		entries:       make(map[string]idempotencyEntry),
+		sweepInterval: sweepInterval,
+		now:           time.Now,
+	}
+}
+
+func (s *memoryIdempotencyStore) Reserve(_ context.Context, key, fingerprint string, ttl time.Duration) (IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.Sub(s.lastSweep) >= s.sweepInterval {
+		s.sweep(now)
+	}
+
+	if e, ok := s.entries[key]; ok && now.Before(e.expiresAt) {
+		return e.record, false, nil
+	}
+	s.entries[key] = idempotencyEntry{
+		record:    IdempotencyRecord{Fingerprint: fingerprint},
+		expiresAt: now.Add(ttl),
+	}
+	return IdempotencyRecord{}, true, nil
+}
+
+func (s *memoryIdempotencyStore) Complete(_ context.Context, key string, record IdempotencyRecord, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[key] = idempotencyEntry{record: record, expiresAt: s.now().Add(ttl)}
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+	return nil
+}
+
+func (s *memoryIdempotencyStore) sweep(now time.Time) {
+	for key, e := range s.entries {
+		if !now.Before(e.expiresAt) {
+			delete(s.entries, key)
+		}
+	}
+	s.lastSweep = now
+}
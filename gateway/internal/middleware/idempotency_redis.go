@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// reserveScript saves the pending record ARGV[1] unless the key exists and
+// returns the existing record otherwise.
+var reserveScript = redis.NewScript(`
+local existing = redis.call('GET', KEYS[1])
+if existing then
+	return existing
+end
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+return false
+`)
+
+// redisIdempotencyStore shares records between gateway replicas.
+type redisIdempotencyStore struct {
+	client redis.Cmdable
+}
+
+func NewRedisIdempotencyStore(client redis.Cmdable) *redisIdempotencyStore {
+	return &redisIdempotencyStore{client: client}
+}
+
+func (s *redisIdempotencyStore) Reserve(ctx context.Context, key, fingerprint string, ttl time.Duration) (IdempotencyRecord, bool, error) {
+	pending, err := json.Marshal(IdempotencyRecord{Fingerprint: fingerprint})
+	if err != nil {
+		return IdempotencyRecord{}, false, err
+	}
+
+	existing, err := reserveScript.Run(ctx, s.client, []string{key}, pending, ttl.Milliseconds()).Text()
+	if errors.Is(err, redis.Nil) {
+		return IdempotencyRecord{}, true, nil
+	}
+	if err != nil {
+		return IdempotencyRecord{}, false, err
+	}
+
+	var record IdempotencyRecord
+	if err := json.Unmarshal([]byte(existing), &record); err != nil {
+		return IdempotencyRecord{}, false, err
+	}
+	return record, false, nil
+}
+
+func (s *redisIdempotencyStore) Complete(ctx context.Context, key string, record IdempotencyRecord, ttl time.Duration) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, key, data, ttl).Err()
+}
+
+func (s *redisIdempotencyStore) Release(ctx context.Context, key string) error {
+	return s.client.Del(ctx, key).Err()
+}
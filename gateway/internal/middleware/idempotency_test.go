@@ -0,0 +1,268 @@
+package middleware
+
+import (
+	"FinanceTracker/gateway/pkg/logger"
+	"FinanceTracker/gateway/pkg/utils"
+	"context"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewIdempotency(t *testing.T) {
+	type request struct {
+		key        string
+		userID     int64
+		body       string
+		wantStatus int
+		wantBody   string
+		wantReplay bool
+	}
+
+	testCases := []struct {
+		name      string
+		status    int // status returned by the handler
+		requests  []request
+		wantCalls int
+	}{
+		{
+			name:   "replays_response",
+			status: http.StatusOK,
+			requests: []request{
+				{key: "k1", body: `{"a":1}`, wantStatus: http.StatusOK, wantBody: "call 1"},
+				{key: "k1", body: `{"a":1}`, wantStatus: http.StatusOK, wantBody: "call 1", wantReplay: true},
+			},
+			wantCalls: 1,
+		},
+		{
+			name:   "rejects_reused_key_with_other_body",
+			status: http.StatusOK,
+			requests: []request{
+				{key: "k1", body: `{"a":1}`, wantStatus: http.StatusOK, wantBody: "call 1"},
+				{key: "k1", body: `{"a":2}`, wantStatus: http.StatusUnprocessableEntity},
+			},
+			wantCalls: 1,
+		},
+		{
+			name:   "keys_are_scoped_by_user",
+			status: http.StatusOK,
+			requests: []request{
+				{key: "k1", userID: 1, body: `{}`, wantStatus: http.StatusOK, wantBody: "call 1"},
+				{key: "k1", userID: 2, body: `{}`, wantStatus: http.StatusOK, wantBody: "call 2"},
+			},
+			wantCalls: 2,
+		},
+		{
+			name:   "without_key_every_request_runs",
+			status: http.StatusOK,
+			requests: []request{
+				{body: `{}`, wantStatus: http.StatusOK, wantBody: "call 1"},
+				{body: `{}`, wantStatus: http.StatusOK, wantBody: "call 2"},
+			},
+			wantCalls: 2,
+		},
+		{
+			name:   "client_errors_are_replayed",
+			status: http.StatusBadRequest,
+			requests: []request{
+				{key: "k1", body: `{}`, wantStatus: http.StatusBadRequest, wantBody: "call 1"},
+				{key: "k1", body: `{}`, wantStatus: http.StatusBadRequest, wantBody: "call 1", wantReplay: true},
+			},
+			wantCalls: 1,
+		},
+		{
+			name:   "server_errors_can_be_retried",
+			status: http.StatusServiceUnavailable,
+			requests: []request{
+				{key: "k1", body: `{}`, wantStatus: http.StatusServiceUnavailable, wantBody: "call 1"},
+				{key: "k1", body: `{}`, wantStatus: http.StatusServiceUnavailable, wantBody: "call 2"},
+			},
+			wantCalls: 2,
+		},
+		{
+			name:   "rate_limited_requests_can_be_retried",
+			status: http.StatusTooManyRequests,
+			requests: []request{
+				{key: "k1", body: `{}`, wantStatus: http.StatusTooManyRequests, wantBody: "call 1"},
+				{key: "k1", body: `{}`, wantStatus: http.StatusTooManyRequests, wantBody: "call 2"},
+			},
+			wantCalls: 2,
+		},
+		{
+			name:   "key_too_long",
+			status: http.StatusOK,
+			requests: []request{
+				{key: strings.Repeat("k", 256), body: `{}`, wantStatus: http.StatusBadRequest},
+			},
+		},
+		{
+			name:   "body_too_large",
+			status: http.StatusOK,
+			requests: []request{
+				{key: "k1", body: strings.Repeat("x", 65), wantStatus: http.StatusRequestEntityTooLarge},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls int
+			mux := http.NewServeMux()
+			mux.Handle("POST /items", NewIdempotency(NewMemoryIdempotencyStore(time.Minute), time.Hour, 64)(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					calls++
+					_, err := io.ReadAll(r.Body)
+					require.NoError(t, err)
+					w.Header().Set("Content-Type", "text/plain")
+					w.WriteHeader(tc.status)
+					io.WriteString(w, "call "+string(rune('0'+calls)))
+				})))
+
+			for _, req := range tc.requests {
+				r := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(req.body))
+				ctx := logger.WithLogger(r.Context(), slog.New(slog.DiscardHandler))
+				r = r.WithContext(utils.WithUserID(ctx, req.userID))
+				if req.key != "" {
+					r.Header.Set(IdempotencyKeyHeader, req.key)
+				}
+				rec := httptest.NewRecorder()
+				mux.ServeHTTP(rec, r)
+
+				assert.Equal(t, req.wantStatus, rec.Code)
+				if req.wantBody != "" {
+					assert.Equal(t, req.wantBody, rec.Body.String())
+					assert.Equal(t, "text/plain", rec.Header().Get("Content-Type"))
+				}
+				assert.Equal(t, req.wantReplay, rec.Header().Get(IdempotentReplayedHeader) == "true")
+			}
+			assert.Equal(t, tc.wantCalls, calls)
+		})
+	}
+}
+
+func TestNewIdempotency_InProgress(t *testing.T) {
+	store := NewMemoryIdempotencyStore(time.Minute)
+	started, release := make(chan struct{}), make(chan struct{})
+
+	mux := http.NewServeMux()
+	mux.Handle("POST /items", NewIdempotency(store, time.Hour, 64)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-release
+		})))
+
+	send := func() *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(`{}`))
+		r.Header.Set(IdempotencyKeyHeader, "k1")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, r)
+		return rec
+	}
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- send() }()
+	<-started
+
+	rec := send()
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+
+	close(release)
+	assert.Equal(t, http.StatusOK, (<-done).Code)
+	assert.Equal(t, "true", send().Header().Get(IdempotentReplayedHeader))
+}
+
+func TestNewIdempotency_PanicReleasesKey(t *testing.T) {
+	store := NewMemoryIdempotencyStore(time.Minute)
+	handler := NewIdempotency(store, time.Hour, 64)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	r := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(`{}`))
+	r.Header.Set(IdempotencyKeyHeader, "k1")
+	assert.Panics(t, func() { handler.ServeHTTP(httptest.NewRecorder(), r) })
+
+	_, reserved, err := store.Reserve(context.Background(), "idempotency::0:k1", "", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, reserved, "key must be released after a panic")
+}
+
+func TestRequestFingerprint(t *testing.T) {
+	type field struct{ name, file, content string }
+	type body struct{ contentType, data string }
+	form := func(boundary string, fields ...field) body {
+		var data strings.Builder
+		mw := multipart.NewWriter(&data)
+		require.NoError(t, mw.SetBoundary(boundary))
+		for _, f := range fields {
+			var w io.Writer
+			var err error
+			if f.file != "" {
+				w, err = mw.CreateFormFile(f.name, f.file)
+			} else {
+				w, err = mw.CreateFormField(f.name)
+			}
+			require.NoError(t, err)
+			io.WriteString(w, f.content)
+		}
+		require.NoError(t, mw.Close())
+		return body{contentType: mw.FormDataContentType(), data: data.String()}
+	}
+	fingerprint := func(b body) string {
+		r := httptest.NewRequest(http.MethodPut, "/profile/update", strings.NewReader(b.data))
+		r.Header.Set("Content-Type", b.contentType)
+		return requestFingerprint(r, []byte(b.data))
+	}
+
+	name := field{name: "full_name", content: "Alice"}
+	avatar := field{name: "avatar", file: "me.png", content: "png bytes"}
+	first := form("first", name, avatar)
+
+	testCases := []struct {
+		name     string
+		retry    body
+		wantSame bool
+	}{
+		{
+			name:     "new_boundary",
+			retry:    form("second", name, avatar),
+			wantSame: true,
+		},
+		{
+			name:     "fields_reordered",
+			retry:    form("second", avatar, name),
+			wantSame: true,
+		},
+		{
+			name:  "other_file",
+			retry: form("second", name, field{name: "avatar", file: "me.png", content: "other"}),
+		},
+		{
+			name:  "other_field",
+			retry: form("second", field{name: "full_name", content: "Bob"}, avatar),
+		},
+		{
+			name:  "malformed_form",
+			retry: body{contentType: first.contentType, data: "--first\r\nbroken"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantSame, fingerprint(first) == fingerprint(tc.retry))
+		})
+	}
+
+	// parameters of other media types don't matter either
+	assert.Equal(t,
+		fingerprint(body{contentType: "application/json", data: `{"a":1}`}),
+		fingerprint(body{contentType: "application/json; charset=utf-8", data: `{"a":1}`}))
+}
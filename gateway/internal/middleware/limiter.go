@@ -1,51 +1,147 @@
 package middleware
 
 import (
+	"FinanceTracker/gateway/pkg/logger"
 	"FinanceTracker/gateway/pkg/utils"
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"io"
+	"math"
 	"net"
 	"net/http"
-	"sync"
-
-	"golang.org/x/time/rate"
+	"strconv"
+	"strings"
+	"time"
 )
 
-type limiter struct {
-	limiters map[string]*rate.Limiter
-	mu       sync.Mutex
-	rate     rate.Limit
-	burst    int
+// Limit allows Burst requests at once and restores one request every Interval.
+type Limit struct {
+	Interval time.Duration
+	Burst    int
+}
+
+func ParseLimit(s string) (Limit, error) {
+	interval, burst, ok := strings.Cut(s, ":")
+	if !ok {
+		return Limit{}, fmt.Errorf("invalid limit %q, want interval:burst", s)
+	}
+	d, err := time.ParseDuration(interval)
+	if err != nil || d <= 0 {
+		return Limit{}, fmt.Errorf("invalid limit interval %q", interval)
+	}
+	b, err := strconv.Atoi(burst)
+	if err != nil || b <= 0 {
+		return Limit{}, fmt.Errorf("invalid limit burst %q", burst)
+	}
+	return Limit{Interval: d, Burst: b}, nil
+}
+
+type LimitResult struct {
+	Allowed    bool
+	Remaining  int
+	RetryAfter time.Duration // time until the next request is allowed, if denied
+	ResetAfter time.Duration // time until the full burst is restored
+}
+
+// LimiterStore keeps limiter state, so that it can be shared between replicas.
+type LimiterStore interface {
+	Allow(ctx context.Context, key string, limit Limit) (LimitResult, error)
+}
+
+// gcra applies the generic cell rate algorithm to the theoretical arrival time
+// tat of the next request and returns the result with the new tat.
+func gcra(now, tat time.Time, limit Limit) (LimitResult, time.Time) {
+	if tat.Before(now) {
+		tat = now
+	}
+	newTat := tat.Add(limit.Interval)
+	allowAt := newTat.Add(-time.Duration(limit.Burst) * limit.Interval)
+
+	if now.Before(allowAt) {
+		return LimitResult{
+			RetryAfter: allowAt.Sub(now),
+			ResetAfter: tat.Sub(now),
+		}, tat
+	}
+
+	return LimitResult{
+		Allowed:    true,
+		Remaining:  int(now.Sub(allowAt) / limit.Interval),
+		ResetAfter: newTat.Sub(now),
+	}, newTat
+}
+
+type RateLimiter struct {
+	store  LimiterStore
+	routes map[string]Limit
 }
 
-func newLimiter(r rate.Limit, burst int) *limiter {
-	return &limiter{
-		limiters: make(map[string]*rate.Limiter),
-		rate:     r,
-		burst:    burst,
+// NewRateLimiter creates limiters backed by store. Routes maps route names to
+// "interval:burst" limits that override the defaults given by controllers.
+func NewRateLimiter(store LimiterStore, routes map[string]string) (*RateLimiter, error) {
+	rl := &RateLimiter{store: store, routes: make(map[string]Limit, len(routes))}
+	for route, s := range routes {
+		limit, err := ParseLimit(s)
+		if err != nil {
+			return nil, fmt.Errorf("route %s: %w", route, err)
+		}
+		rl.routes[route] = limit
 	}
+	return rl, nil
 }
 
-func (rl *limiter) getLimiter(key string) *rate.Limiter {
-	rl.mu.Lock()
-	defer rl.mu.Unlock()
+func (rl *RateLimiter) limit(route string, def Limit) Limit {
+	if limit, ok := rl.routes[route]; ok {
+		return limit
+	}
+	return def
+}
+
+// allow checks the limit, writes the RateLimit headers and reports whether the request may proceed.
+func (rl *RateLimiter) allow(w http.ResponseWriter, r *http.Request, route, key string, limit Limit) bool {
+	res, err := rl.store.Allow(r.Context(), "ratelimit:"+route+":"+key, limit)
+	if err != nil {
+		// fail open: an unavailable store must not take the API down
+		logger.Error(r.Context(), "failed to check rate limit", "route", route, "err", err)
+		return true
+	}
+
+	h := w.Header()
+	h.Set("RateLimit-Limit", strconv.Itoa(limit.Burst))
+	h.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+	h.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(res.ResetAfter)))
+	if !res.Allowed {
+		h.Set("Retry-After", strconv.Itoa(ceilSeconds(res.RetryAfter)))
+		rateLimitRejections.WithLabelValues(route).Inc()
+		utils.WriteError(w, "Too many requests. Please wait.", http.StatusTooManyRequests)
+		return false
+	}
+	return true
+}
 
-	limiter, exists := rl.limiters[key]
-	if !exists {
-		limiter = rate.NewLimiter(rl.rate, rl.burst)
-		rl.limiters[key] = limiter
+// clientIP prefers the address resolved by NewClientIP and falls back to the peer address.
+func clientIP(r *http.Request) string {
+	if ip := utils.GetClientIP(r.Context()); ip != "" {
+		return ip
 	}
-	return limiter
+	ip, _, _ := net.SplitHostPort(r.RemoteAddr)
+	return ip
 }
 
-func NewBodyLimiter(limit rate.Limit, burst int, key string) func(next http.Handler) http.Handler {
-	rl := newLimiter(limit, burst)
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// Body limits requests by client IP and the value of a JSON body field.
+func (rl *RateLimiter) Body(route string, def Limit, key string) func(next http.Handler) http.Handler {
+	limit := rl.limit(route, def)
 
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			ip, _, err := net.SplitHostPort(r.RemoteAddr)
-			if err != nil {
+			ip := clientIP(r)
+			if ip == "" {
 				utils.WriteError(w, "cannot parse IP", http.StatusInternalServerError)
 				return
 			}
@@ -69,9 +165,7 @@ func NewBodyLimiter(limit rate.Limit, burst int, key string) func(next http.Hand
 				return
 			}
 
-			limiter := rl.getLimiter(ip + "|" + val)
-			if !limiter.Allow() {
-				utils.WriteError(w, "Too many requests. Please wait.", http.StatusTooManyRequests)
+			if !rl.allow(w, r, route, ip+"|"+val, limit) {
 				return
 			}
 
@@ -82,20 +176,19 @@ func NewBodyLimiter(limit rate.Limit, burst int, key string) func(next http.Hand
 	}
 }
 
-func NewIPLimiter(limit rate.Limit, burst int) func(next http.Handler) http.Handler {
-	rl := newLimiter(limit, burst)
+// IP limits requests by client IP.
+func (rl *RateLimiter) IP(route string, def Limit) func(next http.Handler) http.Handler {
+	limit := rl.limit(route, def)
 
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			ip, _, err := net.SplitHostPort(r.RemoteAddr)
-			if err != nil {
+			ip := clientIP(r)
+			if ip == "" {
 				utils.WriteError(w, "cannot parse IP", http.StatusInternalServerError)
 				return
 			}
 
-			limiter := rl.getLimiter(ip)
-			if !limiter.Allow() {
-				utils.WriteError(w, "Too many requests. Please wait.", http.StatusTooManyRequests)
+			if !rl.allow(w, r, route, ip, limit) {
 				return
 			}
 
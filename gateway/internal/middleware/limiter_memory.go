@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryStore keeps limiter state in process. Keys whose burst is fully
+// restored carry no state and are evicted every sweepInterval.
+type memoryStore struct {
+	mu            sync.Mutex
+	tats          map[string]time.Time
+	sweepInterval time.Duration
+	lastSweep     time.Time
+	now           func() time.Time
+}
+
+func NewMemoryStore(sweepInterval time.Duration) *memoryStore {
+	return &memoryStore{
+		tats:          make(map[string]time.Time),
+		sweepInterval: sweepInterval,
+		now:           time.Now,
+	}
+}
+
+func (s *memoryStore) Allow(_ context.Context, key string, limit Limit) (LimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.Sub(s.lastSweep) >= s.sweepInterval {
+		s.sweep(now)
+	}
+
+	res, tat := gcra(now, s.tats[key], limit)
+	s.tats[key] = tat
+	return res, nil
+}
+
+func (s *memoryStore) sweep(now time.Time) {
+	for key, tat := range s.tats {
+		if !tat.After(now) {
+			delete(s.tats, key)
+		}
+	}
+	s.lastSweep = now
+}
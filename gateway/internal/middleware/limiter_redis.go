@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// gcraScript is the Redis counterpart of gcra. Time is read from the server,
+// so that replicas with skewed clocks agree. All durations are in microseconds.
+var gcraScript = redis.NewScript(`
+local interval = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000000 + tonumber(t[2])
+
+local tat = tonumber(redis.call('GET', KEYS[1]) or now)
+if tat < now then
+	tat = now
+end
+local new_tat = tat + interval
+local allow_at = new_tat - burst * interval
+
+if now < allow_at then
+	return {0, 0, allow_at - now, tat - now}
+end
+
+redis.call('SET', KEYS[1], string.format('%.0f', new_tat), 'PX', math.ceil((new_tat - now) / 1000))
+return {1, math.floor((now - allow_at) / interval), 0, new_tat - now}
+`)
+
+type redisStore struct {
+	client redis.Scripter
+}
+
+func NewRedisStore(client redis.Scripter) *redisStore {
+	return &redisStore{client: client}
+}
+
+func (s *redisStore) Allow(ctx context.Context, key string, limit Limit) (LimitResult, error) {
+	res, err := gcraScript.Run(ctx, s.client, []string{key}, limit.Interval.Microseconds(), limit.Burst).Int64Slice()
+	if err != nil {
+		return LimitResult{}, err
+	}
+
+	return LimitResult{
+		Allowed:    res[0] == 1,
+		Remaining:  int(res[1]),
+		RetryAfter: time.Duration(res[2]) * time.Microsecond,
+		ResetAfter: time.Duration(res[3]) * time.Microsecond,
+	}, nil
+}
@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_Allow(t *testing.T) {
+	limit := Limit{Interval: 10 * time.Second, Burst: 3}
+
+	testCases := []struct {
+		name          string
+		requests      []time.Duration // offsets from start
+		wantAllowed   bool
+		wantRemaining int
+		wantRetry     time.Duration
+	}{
+		{
+			name:          "first_request",
+			requests:      []time.Duration{0},
+			wantAllowed:   true,
+			wantRemaining: 2,
+		},
+		{
+			name:          "burst_exhausted",
+			requests:      []time.Duration{0, 0, 0, 0},
+			wantAllowed:   false,
+			wantRemaining: 0,
+			wantRetry:     10 * time.Second,
+		},
+		{
+			name:          "restored_after_interval",
+			requests:      []time.Duration{0, 0, 0, 10 * time.Second},
+			wantAllowed:   true,
+			wantRemaining: 0,
+		},
+		{
+			name:          "partially_restored",
+			requests:      []time.Duration{0, 0, 0, 4 * time.Second},
+			wantAllowed:   false,
+			wantRemaining: 0,
+			wantRetry:     6 * time.Second,
+		},
+		{
+			name:          "fully_restored",
+			requests:      []time.Duration{0, 0, 0, time.Minute},
+			wantAllowed:   true,
+			wantRemaining: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			start := time.Now()
+			store := NewMemoryStore(time.Hour)
+
+			var res LimitResult
+			for _, offset := range tc.requests {
+				store.now = func() time.Time { return start.Add(offset) }
+				var err error
+				res, err = store.Allow(context.Background(), "key", limit)
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.wantAllowed, res.Allowed)
+			assert.Equal(t, tc.wantRemaining, res.Remaining)
+			assert.Equal(t, tc.wantRetry, res.RetryAfter)
+		})
+	}
+}
+
+func TestMemoryStore_Eviction(t *testing.T) {
+	start := time.Now()
+	limit := Limit{Interval: time.Second, Burst: 1}
+	store := NewMemoryStore(time.Minute)
+	store.now = func() time.Time { return start }
+
+	for _, key := range []string{"a", "b", "c"} {
+		_, err := store.Allow(context.Background(), key, limit)
+		require.NoError(t, err)
+	}
+	require.Len(t, store.tats, 3)
+
+	store.now = func() time.Time { return start.Add(time.Minute) }
+	_, err := store.Allow(context.Background(), "d", limit)
+	require.NoError(t, err)
+
+	assert.Len(t, store.tats, 1)
+	assert.Contains(t, store.tats, "d")
+}
+
+func TestRateLimiter_Headers(t *testing.T) {
+	rl, err := NewRateLimiter(NewMemoryStore(time.Minute), map[string]string{"test": "30s:1"})
+	require.NoError(t, err)
+
+	handler := rl.IP("test", Limit{Interval: time.Second, Burst: 10})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "0", rec.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "30", rec.Header().Get("RateLimit-Reset"))
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "30", rec.Header().Get("Retry-After"))
+}
+
+func TestParseLimit(t *testing.T) {
+	limit, err := ParseLimit("30s:5")
+	require.NoError(t, err)
+	assert.Equal(t, Limit{Interval: 30 * time.Second, Burst: 5}, limit)
+
+	for _, s := range []string{"", "30s", "x:1", "30s:0", "-1s:1", "30s:x"} {
+		_, err := ParseLimit(s)
+		assert.Error(t, err, s)
+	}
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gateway_http_requests_total",
+		Help: "Number of HTTP requests, by method, route and status code.",
+	}, []string{"method", "route", "code"})
+
+	httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "gateway_http_request_duration_seconds",
+		Help:    "Latency of HTTP requests, by method and route.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	rateLimitRejections = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gateway_rate_limit_rejections_total",
+		Help: "Number of requests rejected by a rate limiter, by route.",
+	}, []string{"route"})
+)
+
+// NewMetrics must receive the same *http.Request as the mux: the route
+// pattern is only known after the mux has matched the request.
+func NewMetrics() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+			next.ServeHTTP(rec, r)
+
+			route := r.Pattern
+			if route == "" {
+				route = "unmatched"
+			}
+			httpRequests.WithLabelValues(r.Method, route, strconv.Itoa(rec.status)).Inc()
+			httpDuration.WithLabelValues(r.Method, route).Observe(time.Since(start).Seconds())
+		})
+	}
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += n
+	return n, err
+}
+
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
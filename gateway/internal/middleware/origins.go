@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Origins matches request origins against exact origins ("https://app.example.com"),
+// subdomain patterns ("https://*.example.com") and the "*" wildcard.
+type Origins struct {
+	any      bool
+	exact    map[string]bool
+	suffixes []originSuffix
+}
+
+type originSuffix struct {
+	scheme string
+	host   string // ".example.com", including the port if any
+}
+
+func ParseOrigins(values []string) (*Origins, error) {
+	o := &Origins{exact: make(map[string]bool)}
+	for _, v := range values {
+		v = strings.ToLower(strings.TrimSpace(v))
+		switch {
+		case v == "":
+			continue
+		case v == "*":
+			o.any = true
+			continue
+		}
+
+		scheme, host, ok := strings.Cut(v, "://")
+		if !ok || scheme == "" || host == "" || strings.ContainsAny(host, "/?#") {
+			return nil, fmt.Errorf("invalid origin %q, want scheme://host[:port]", v)
+		}
+		if wildcard, ok := strings.CutPrefix(host, "*."); ok {
+			if wildcard == "" || strings.Contains(wildcard, "*") {
+				return nil, fmt.Errorf("invalid origin pattern %q", v)
+			}
+			o.suffixes = append(o.suffixes, originSuffix{scheme: scheme, host: "." + wildcard})
+			continue
+		}
+		if strings.Contains(host, "*") {
+			return nil, fmt.Errorf("invalid origin pattern %q, only a leading *. is supported", v)
+		}
+		o.exact[v] = true
+	}
+	return o, nil
+}
+
+// Allowed reports whether origin matches, including the "*" wildcard.
+func (o *Origins) Allowed(origin string) bool {
+	return o.any || o.Trusted(origin)
+}
+
+// Trusted reports whether origin matches an explicitly configured origin or pattern.
+func (o *Origins) Trusted(origin string) bool {
+	origin = strings.ToLower(origin)
+	if o.exact[origin] {
+		return true
+	}
+
+	scheme, host, ok := strings.Cut(origin, "://")
+	if !ok {
+		return false
+	}
+	for _, s := range o.suffixes {
+		if s.scheme == scheme && len(host) > len(s.host) && strings.HasSuffix(host, s.host) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"FinanceTracker/gateway/pkg/logger"
+	"FinanceTracker/gateway/pkg/utils"
+	"net/http"
+	"runtime/debug"
+)
+
+func NewRecovery() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				err := recover()
+				if err == nil {
+					return
+				}
+				if err == http.ErrAbortHandler {
+					panic(err)
+				}
+
+				logger.Error(r.Context(), "panic recovered", "err", err, "stack", string(debug.Stack()))
+				utils.WriteError(w, "internal server error", http.StatusInternalServerError)
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"FinanceTracker/gateway/pkg/logger"
+	"FinanceTracker/gateway/pkg/utils"
+)
+
+func TestNewRecovery(t *testing.T) {
+	testCases := []struct {
+		name       string
+		handler    http.HandlerFunc
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:       "no_panic",
+			handler:    func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) },
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "panic_with_string",
+			handler:    func(w http.ResponseWriter, r *http.Request) { panic("boom") },
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   "INTERNAL_SERVER_ERROR",
+		},
+		{
+			name: "panic_with_error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				var m map[string]int
+				m["x"]++ // assignment to entry in nil map
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   "INTERNAL_SERVER_ERROR",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewRecovery()(tc.handler)
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req = req.WithContext(logger.WithLogger(req.Context(), slog.New(slog.DiscardHandler)))
+			rec := httptest.NewRecorder()
+
+			require.NotPanics(t, func() { handler.ServeHTTP(rec, req) })
+
+			assert.Equal(t, tc.wantStatus, rec.Code)
+			if tc.wantCode == "" {
+				return
+			}
+			var res utils.ErrorResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+			assert.Equal(t, tc.wantCode, res.Code)
+			// the panic value never reaches the client
+			assert.NotContains(t, rec.Body.String(), "boom")
+		})
+	}
+}
+
+func TestNewRecovery_AbortHandler(t *testing.T) {
+	// net/http relies on ErrAbortHandler to drop the connection quietly
+	handler := NewRecovery()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+}
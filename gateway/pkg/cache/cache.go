@@ -0,0 +1,17 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrMiss = errors.New("cache miss")
+
+// Cache stores encoded responses. Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns ErrMiss if there is no live entry for key.
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
@@ -0,0 +1,76 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type entry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// memoryCache keeps entries in process. Expired entries are evicted on
+// access and every sweepInterval.
+type memoryCache struct {
+	mu            sync.Mutex
+	entries       map[string]entry
+	sweepInterval time.Duration
+	lastSweep     time.Time
+	now           func() time.Time
+}
+
+func NewMemory(sweepInterval time.Duration) *memoryCache {
+	return &memoryCache{
+		entries:       make(map[string]entry),
+		sweepInterval: sweepInterval,
+		now:           time.Now,
+	}
+}
+
+func (c *memoryCache) Get(_ context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, ErrMiss
+	}
+	if !c.now().Before(e.expiresAt) {
+		delete(c.entries, key)
+		return nil, ErrMiss
+	}
+	return e.value, nil
+}
+
+func (c *memoryCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if now.Sub(c.lastSweep) >= c.sweepInterval {
+		c.sweep(now)
+	}
+	c.entries[key] = entry{value: value, expiresAt: now.Add(ttl)}
+	return nil
+}
+
+func (c *memoryCache) Delete(_ context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		delete(c.entries, key)
+	}
+	return nil
+}
+
+func (c *memoryCache) sweep(now time.Time) {
+	for key, e := range c.entries {
+		if !now.Before(e.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+	c.lastSweep = now
+}
@@ -0,0 +1,52 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCache(t *testing.T) {
+	ctx := context.Background()
+	start := time.Now()
+	c := NewMemory(time.Hour)
+	c.now = func() time.Time { return start }
+
+	_, err := c.Get(ctx, "a")
+	assert.ErrorIs(t, err, ErrMiss)
+
+	require.NoError(t, c.Set(ctx, "a", []byte("1"), time.Minute))
+	require.NoError(t, c.Set(ctx, "b", []byte("2"), time.Minute))
+
+	got, err := c.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("1"), got)
+
+	require.NoError(t, c.Delete(ctx, "a"))
+	_, err = c.Get(ctx, "a")
+	assert.ErrorIs(t, err, ErrMiss)
+
+	c.now = func() time.Time { return start.Add(time.Minute) }
+	_, err = c.Get(ctx, "b")
+	assert.ErrorIs(t, err, ErrMiss, "entry must expire after ttl")
+}
+
+func TestMemoryCache_Sweep(t *testing.T) {
+	ctx := context.Background()
+	start := time.Now()
+	c := NewMemory(time.Minute)
+	c.now = func() time.Time { return start }
+
+	require.NoError(t, c.Set(ctx, "short", nil, time.Second))
+	require.NoError(t, c.Set(ctx, "long", nil, time.Hour))
+
+	c.now = func() time.Time { return start.Add(time.Minute) }
+	require.NoError(t, c.Set(ctx, "new", nil, time.Hour))
+
+	assert.NotContains(t, c.entries, "short")
+	assert.Contains(t, c.entries, "long")
+	assert.Contains(t, c.entries, "new")
+}
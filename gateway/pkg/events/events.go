@@ -0,0 +1,9 @@
+package events
+
+const (
+	TopicProfileUpdated = "profile.updated"
+)
+
+type EventProfileUpdated struct {
+	UserID int `json:"user_id"`
+}
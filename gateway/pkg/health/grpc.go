@@ -0,0 +1,25 @@
+package health
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// GRPC checks a downstream service through its grpc.health.v1 endpoint. An
+// empty service is the whole server.
+func GRPC(conn grpc.ClientConnInterface, service string) Checker {
+	client := grpc_health_v1.NewHealthClient(conn)
+	return func(ctx context.Context) error {
+		resp, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: service})
+		if err != nil {
+			return err
+		}
+		if resp.Status != grpc_health_v1.HealthCheckResponse_SERVING {
+			return fmt.Errorf("service is %s", resp.Status)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,116 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const checkTimeout = 2 * time.Second
+
+// Checker reports whether a dependency is usable.
+type Checker func(ctx context.Context) error
+
+type Health struct {
+	mu       sync.RWMutex
+	checks   map[string]Checker
+	shutdown atomic.Bool
+}
+
+func New() *Health {
+	return &Health{checks: make(map[string]Checker)}
+}
+
+func (h *Health) Add(name string, check Checker) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.checks[name] = check
+}
+
+// Shutdown marks the service as not ready, so that traffic is drained before it stops.
+func (h *Health) Shutdown() {
+	h.shutdown.Store(true)
+}
+
+// Check runs all checks concurrently and returns their errors by name.
+func (h *Health) Check(ctx context.Context) map[string]error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	var (
+		wg  sync.WaitGroup
+		mu  sync.Mutex
+		res = make(map[string]error, len(h.checks))
+	)
+	for name, check := range h.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+			defer cancel()
+			err := check(ctx)
+			mu.Lock()
+			res[name] = err
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	return res
+}
+
+// Ready reports whether the service accepts traffic and all its dependencies are healthy.
+func (h *Health) Ready(ctx context.Context) bool {
+	if h.shutdown.Load() {
+		return false
+	}
+	for _, err := range h.Check(ctx) {
+		if err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+type Response struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+func (h *Health) Routes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /healthz", h.handleLiveness)
+	mux.HandleFunc("GET /readyz", h.handleReadiness)
+}
+
+func (h *Health) handleLiveness(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, Response{Status: "ok"}, http.StatusOK)
+}
+
+func (h *Health) handleReadiness(w http.ResponseWriter, r *http.Request) {
+	if h.shutdown.Load() {
+		writeJSON(w, Response{Status: "shutting down"}, http.StatusServiceUnavailable)
+		return
+	}
+
+	resp := Response{Status: "ok", Checks: make(map[string]string)}
+	code := http.StatusOK
+	for name, err := range h.Check(r.Context()) {
+		if err != nil {
+			resp.Checks[name] = err.Error()
+			resp.Status = "unavailable"
+			code = http.StatusServiceUnavailable
+			continue
+		}
+		resp.Checks[name] = "ok"
+	}
+	writeJSON(w, resp, code)
+}
+
+func writeJSON(w http.ResponseWriter, payload any, code int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(payload)
+}
@@ -0,0 +1,25 @@
+package logger
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// UnaryClientInterceptor forwards the request ID to downstream services.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if id := RequestID(ctx); id != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, RequestIDKey, id)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
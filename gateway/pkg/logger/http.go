@@ -1,14 +1,46 @@
 package logger
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"log/slog"
 	"net/http"
 )
 
+const RequestIDHeader = "X-Request-ID"
+
 func NewHttpMiddleware(logger *slog.Logger) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			next.ServeHTTP(w, r.WithContext(WithLogger(r.Context(), logger)))
+			id := r.Header.Get(RequestIDHeader)
+			if !isValidRequestID(id) {
+				id = newRequestID()
+			}
+			w.Header().Set(RequestIDHeader, id)
+
+			ctx := WithRequestID(WithLogger(r.Context(), logger), id)
+			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// isValidRequestID accepts client IDs that are safe to put into logs and headers.
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '_', c == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,19 @@
+package logger
+
+import "context"
+
+// RequestIDKey names the request ID in gRPC metadata and Kafka headers.
+const RequestIDKey = "x-request-id"
+
+type requestIDKey struct{}
+
+// WithRequestID stores the request ID in ctx and attaches it to the context logger.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	ctx = context.WithValue(ctx, requestIDKey{}, id)
+	return WithLogger(ctx, FromContext(ctx).With("request_id", id))
+}
+
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
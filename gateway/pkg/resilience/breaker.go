@@ -0,0 +1,161 @@
+package resilience
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	breakerState = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gateway_grpc_breaker_state",
+		Help: "State of the circuit breaker of a downstream service: 0 closed, 1 half-open, 2 open.",
+	}, []string{"target"})
+
+	breakerRejections = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gateway_grpc_breaker_rejections_total",
+		Help: "Number of calls rejected by an open circuit breaker, by downstream service.",
+	}, []string{"target"})
+)
+
+type State int
+
+const (
+	StateClosed State = iota
+	StateHalfOpen
+	StateOpen
+)
+
+// Breaker opens after a number of consecutive failures and rejects calls
+// until the open timeout passes. Then a single probe call is let through:
+// its success closes the breaker, its failure opens it again.
+type Breaker struct {
+	name        string
+	failures    int
+	openTimeout time.Duration
+	now         func() time.Time
+
+	mu          sync.Mutex
+	state       State
+	consecutive int
+	openedAt    time.Time
+	probing     bool
+}
+
+func NewBreaker(name string, failures int, openTimeout time.Duration) *Breaker {
+	b := &Breaker{
+		name:        name,
+		failures:    failures,
+		openTimeout: openTimeout,
+		now:         time.Now,
+	}
+	breakerState.WithLabelValues(name).Set(float64(StateClosed))
+	return b
+}
+
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// UnaryClientInterceptor fails fast with Unavailable while the breaker is open.
+func (b *Breaker) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if !b.allow() {
+			breakerRejections.WithLabelValues(b.name).Inc()
+			return status.Errorf(codes.Unavailable, "%s: circuit breaker is open", b.name)
+		}
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		b.record(status.Code(err))
+		return err
+	}
+}
+
+func (b *Breaker) allow() bool {
+	if b.failures <= 0 {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		if b.now().Sub(b.openedAt) < b.openTimeout {
+			return false
+		}
+		b.setState(StateHalfOpen)
+		b.probing = true
+		return true
+	case StateHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+func (b *Breaker) record(code codes.Code) {
+	if b.failures <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// the caller gave up, so the call says nothing about the service
+	if code == codes.Canceled {
+		b.probing = false
+		return
+	}
+	failed := code == codes.Unavailable || code == codes.DeadlineExceeded
+
+	switch b.state {
+	case StateHalfOpen:
+		b.probing = false
+		if failed {
+			b.open()
+		} else {
+			b.consecutive = 0
+			b.setState(StateClosed)
+		}
+	case StateClosed:
+		if !failed {
+			b.consecutive = 0
+			return
+		}
+		b.consecutive++
+		if b.consecutive >= b.failures {
+			b.open()
+		}
+	}
+}
+
+func (b *Breaker) open() {
+	b.openedAt = b.now()
+	b.consecutive = 0
+	b.setState(StateOpen)
+}
+
+func (b *Breaker) setState(state State) {
+	b.state = state
+	breakerState.WithLabelValues(b.name).Set(float64(state))
+}
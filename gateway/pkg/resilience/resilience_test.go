@@ -0,0 +1,187 @@
+package resilience
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const method = "/profile.ProfileService/GetProfile"
+
+// invoker returns the given codes one by one and counts the calls.
+func invoker(calls *int, results ...codes.Code) grpc.UnaryInvoker {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		code := results[min(*calls, len(results)-1)]
+		*calls++
+		if code == codes.OK {
+			return nil
+		}
+		return status.Error(code, code.String())
+	}
+}
+
+func TestRetry(t *testing.T) {
+	policy := RetryPolicy{
+		Attempts:   3,
+		Backoff:    time.Millisecond,
+		MaxBackoff: time.Millisecond,
+		Methods:    []string{"profile.ProfileService/GetProfile"},
+	}
+
+	testCases := []struct {
+		name      string
+		method    string
+		results   []codes.Code
+		wantCode  codes.Code
+		wantCalls int
+	}{
+		{
+			name:      "success",
+			method:    method,
+			results:   []codes.Code{codes.OK},
+			wantCode:  codes.OK,
+			wantCalls: 1,
+		},
+		{
+			name:      "recovers_after_unavailable",
+			method:    method,
+			results:   []codes.Code{codes.Unavailable, codes.Unavailable, codes.OK},
+			wantCode:  codes.OK,
+			wantCalls: 3,
+		},
+		{
+			name:      "gives_up_after_attempts",
+			method:    method,
+			results:   []codes.Code{codes.Unavailable},
+			wantCode:  codes.Unavailable,
+			wantCalls: 3,
+		},
+		{
+			name:      "other_codes_not_retried",
+			method:    method,
+			results:   []codes.Code{codes.NotFound},
+			wantCode:  codes.NotFound,
+			wantCalls: 1,
+		},
+		{
+			name:      "non_idempotent_method_not_retried",
+			method:    "/profile.ProfileService/UpdateProfile",
+			results:   []codes.Code{codes.Unavailable, codes.OK},
+			wantCode:  codes.Unavailable,
+			wantCalls: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls int
+			err := Retry(policy)(context.Background(), tc.method, nil, nil, nil, invoker(&calls, tc.results...))
+
+			assert.Equal(t, tc.wantCode, status.Code(err))
+			assert.Equal(t, tc.wantCalls, calls)
+		})
+	}
+}
+
+func TestRetry_ContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var calls int
+	policy := RetryPolicy{Attempts: 5, Backoff: time.Hour, MaxBackoff: time.Hour, Methods: []string{method}}
+	err := Retry(policy)(ctx, method, nil, nil, nil, invoker(&calls, codes.Unavailable))
+
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 1, calls)
+}
+
+func TestBreaker(t *testing.T) {
+	start := time.Now()
+	b := NewBreaker("test", 2, 10*time.Second)
+	b.now = func() time.Time { return start }
+	interceptor := b.UnaryClientInterceptor()
+
+	call := func(results ...codes.Code) (codes.Code, int) {
+		var calls int
+		err := interceptor(context.Background(), method, nil, nil, nil, invoker(&calls, results...))
+		return status.Code(err), calls
+	}
+
+	// business errors and cancellations don't count as failures
+	call(codes.NotFound)
+	call(codes.Unavailable)
+	call(codes.Canceled)
+	call(codes.OK)
+	call(codes.Unavailable)
+	require.Equal(t, StateClosed, b.State())
+
+	call(codes.DeadlineExceeded)
+	require.Equal(t, StateOpen, b.State())
+
+	code, calls := call(codes.OK)
+	assert.Equal(t, codes.Unavailable, code)
+	assert.Equal(t, 0, calls, "open breaker must not call the service")
+
+	// the probe fails and the breaker opens again
+	b.now = func() time.Time { return start.Add(10 * time.Second) }
+	code, calls = call(codes.Unavailable)
+	assert.Equal(t, codes.Unavailable, code)
+	assert.Equal(t, 1, calls)
+	require.Equal(t, StateOpen, b.State())
+
+	// the probe succeeds and the breaker closes
+	b.now = func() time.Time { return start.Add(20 * time.Second) }
+	code, calls = call(codes.OK)
+	assert.Equal(t, codes.OK, code)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, StateClosed, b.State())
+}
+
+func TestBreaker_SingleProbe(t *testing.T) {
+	start := time.Now()
+	b := NewBreaker("test", 1, time.Second)
+	b.now = func() time.Time { return start }
+	b.record(codes.Unavailable)
+	require.Equal(t, StateOpen, b.State())
+
+	b.now = func() time.Time { return start.Add(time.Second) }
+	assert.True(t, b.allow())
+	assert.Equal(t, StateHalfOpen, b.State())
+	assert.False(t, b.allow(), "only one probe is allowed while half-open")
+
+	b.record(codes.OK)
+	assert.True(t, b.allow())
+}
+
+func TestTimeout(t *testing.T) {
+	timeouts, err := ParseTimeouts(map[string]string{"/profile.ProfileService/UpdateProfile": "10s"})
+	require.NoError(t, err)
+
+	interceptor := Timeout(time.Second, timeouts)
+	deadline := func(ctx context.Context, method string) time.Duration {
+		var got time.Duration
+		interceptor(ctx, method, nil, nil, nil, func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			d, ok := ctx.Deadline()
+			require.True(t, ok)
+			got = time.Until(d)
+			return nil
+		})
+		return got
+	}
+
+	assert.InDelta(t, time.Second, deadline(context.Background(), method), float64(100*time.Millisecond))
+	assert.InDelta(t, 10*time.Second, deadline(context.Background(), "/profile.ProfileService/UpdateProfile"), float64(100*time.Millisecond))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.LessOrEqual(t, deadline(ctx, method), 100*time.Millisecond, "shorter caller deadline is kept")
+
+	_, err = ParseTimeouts(map[string]string{"x": "soon"})
+	assert.Error(t, err)
+}
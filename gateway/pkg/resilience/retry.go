@@ -0,0 +1,76 @@
+package resilience
+
+import (
+	"context"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var retries = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "gateway_grpc_client_retries_total",
+	Help: "Number of retried gRPC calls, by method.",
+}, []string{"method"})
+
+type RetryPolicy struct {
+	Attempts   int           // total attempts, including the first one
+	Backoff    time.Duration // base delay, doubled on every attempt
+	MaxBackoff time.Duration
+	Methods    []string // idempotent methods that are safe to retry
+}
+
+// Retry retries idempotent calls that failed with Unavailable, waiting a
+// random delay up to the exponential backoff between attempts.
+func Retry(policy RetryPolicy) grpc.UnaryClientInterceptor {
+	methods := make(map[string]bool, len(policy.Methods))
+	for _, m := range policy.Methods {
+		methods[strings.TrimPrefix(strings.TrimSpace(m), "/")] = true
+	}
+
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if policy.Attempts <= 1 || !methods[strings.TrimPrefix(method, "/")] {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		for attempt := 1; ; attempt++ {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if status.Code(err) != codes.Unavailable || attempt >= policy.Attempts {
+				return err
+			}
+
+			retries.WithLabelValues(method).Inc()
+			timer := time.NewTimer(policy.backoff(attempt))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+// backoff returns a full-jitter delay before the retry following attempt.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.Backoff << (attempt - 1)
+	if d <= 0 || (p.MaxBackoff > 0 && d > p.MaxBackoff) {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	return rand.N(d) + 1
+}
@@ -0,0 +1,48 @@
+package resilience
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// ParseTimeouts parses per-method deadlines keyed by full method name,
+// e.g. "profile.ProfileService/UpdateProfile" -> "10s".
+func ParseTimeouts(methods map[string]string) (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration, len(methods))
+	for method, s := range methods {
+		d, err := time.ParseDuration(s)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid timeout %q for %s", s, method)
+		}
+		timeouts[strings.TrimPrefix(method, "/")] = d
+	}
+	return timeouts, nil
+}
+
+// Timeout bounds every call with the method's deadline or def.
+// A shorter deadline already set on the context is kept.
+func Timeout(def time.Duration, methods map[string]time.Duration) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		d, ok := methods[strings.TrimPrefix(method, "/")]
+		if !ok {
+			d = def
+		}
+		if d > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, d)
+			defer cancel()
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
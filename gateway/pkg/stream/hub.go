@@ -0,0 +1,170 @@
+package stream
+
+import (
+	"sync"
+	"time"
+)
+
+// Event is a message for one user. IDs are opaque to clients, which send
+// the last one they saw back in Last-Event-ID when they reconnect.
+type Event struct {
+	ID   string
+	Type string
+	Data []byte
+}
+
+type bufferedEvent struct {
+	Event
+	at time.Time
+}
+
+type userStream struct {
+	events      []bufferedEvent
+	subscribers map[*Subscription]struct{}
+}
+
+// Subscription receives the events of a user. C is closed when the hub is
+// closed or the subscriber falls behind; it should reconnect then.
+type Subscription struct {
+	C      <-chan Event
+	c      chan Event
+	userID int64
+}
+
+// Hub fans events out to the subscribers of a user and keeps the latest
+// events of every user for retention, so that a reconnecting client can
+// resume from its last event.
+type Hub struct {
+	mu            sync.Mutex
+	users         map[int64]*userStream
+	closed        bool
+	bufferSize    int
+	retention     time.Duration
+	subscriberBuf int
+	lastSweep     time.Time
+	now           func() time.Time
+}
+
+func NewHub(bufferSize int, retention time.Duration) *Hub {
+	return &Hub{
+		users:         make(map[int64]*userStream),
+		bufferSize:    bufferSize,
+		retention:     retention,
+		subscriberBuf: 16,
+		now:           time.Now,
+	}
+}
+
+// Subscribe registers a subscriber for userID. If lastEventID is set, it also
+// returns the buffered events after it; ok is false if that event is no longer
+// buffered and the client has to reload its state.
+func (h *Hub) Subscribe(userID int64, lastEventID string) (sub *Subscription, missed []Event, ok bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	c := make(chan Event, h.subscriberBuf)
+	sub = &Subscription{C: c, c: c, userID: userID}
+	if h.closed {
+		close(c)
+		return sub, nil, true
+	}
+
+	u := h.user(userID)
+	u.subscribers[sub] = struct{}{}
+
+	if lastEventID == "" {
+		return sub, nil, true
+	}
+	for i, e := range u.events {
+		if e.ID == lastEventID {
+			for _, e := range u.events[i+1:] {
+				missed = append(missed, e.Event)
+			}
+			return sub, missed, true
+		}
+	}
+	return sub, nil, false
+}
+
+// Unsubscribe removes sub. It is safe to call more than once.
+func (h *Hub) Unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	u, ok := h.users[sub.userID]
+	if !ok {
+		return
+	}
+	if _, ok := u.subscribers[sub]; ok {
+		delete(u.subscribers, sub)
+		close(sub.c)
+	}
+}
+
+// Publish buffers the event and sends it to the subscribers of userID.
+// Subscribers that are not keeping up are dropped instead of blocking.
+func (h *Hub) Publish(userID int64, e Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return
+	}
+	now := h.now()
+	if now.Sub(h.lastSweep) >= h.retention {
+		h.sweep(now)
+	}
+
+	u := h.user(userID)
+	u.events = append(u.events, bufferedEvent{Event: e, at: now})
+	if len(u.events) > h.bufferSize {
+		u.events = u.events[len(u.events)-h.bufferSize:]
+	}
+
+	for sub := range u.subscribers {
+		select {
+		case sub.c <- e:
+		default:
+			delete(u.subscribers, sub)
+			close(sub.c)
+		}
+	}
+}
+
+// Close ends all subscriptions, so that open streams finish before shutdown.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for _, u := range h.users {
+		for sub := range u.subscribers {
+			close(sub.c)
+		}
+		u.subscribers = nil
+	}
+}
+
+func (h *Hub) user(userID int64) *userStream {
+	u, ok := h.users[userID]
+	if !ok {
+		u = &userStream{subscribers: make(map[*Subscription]struct{})}
+		h.users[userID] = u
+	}
+	return u
+}
+
+// sweep drops events older than retention and users with nothing left.
+func (h *Hub) sweep(now time.Time) {
+	for userID, u := range h.users {
+		i := 0
+		for i < len(u.events) && now.Sub(u.events[i].at) >= h.retention {
+			i++
+		}
+		u.events = u.events[i:]
+		if len(u.events) == 0 && len(u.subscribers) == 0 {
+			delete(h.users, userID)
+		}
+	}
+	h.lastSweep = now
+}
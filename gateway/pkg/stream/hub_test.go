@@ -0,0 +1,102 @@
+package stream
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func event(id string) Event {
+	return Event{ID: id, Type: "profile.updated", Data: []byte(`{"user_id":1}`)}
+}
+
+func TestHub_Resume(t *testing.T) {
+	h := NewHub(3, time.Hour)
+	for _, id := range []string{"1", "2", "3", "4"} {
+		h.Publish(1, event(id))
+	}
+
+	testCases := []struct {
+		name        string
+		lastEventID string
+		wantMissed  []Event
+		wantOK      bool
+	}{
+		{name: "fresh_connection", wantOK: true},
+		{name: "resume", lastEventID: "2", wantMissed: []Event{event("3"), event("4")}, wantOK: true},
+		{name: "up_to_date", lastEventID: "4", wantOK: true},
+		{name: "evicted_event", lastEventID: "1", wantOK: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sub, missed, ok := h.Subscribe(1, tc.lastEventID)
+			defer h.Unsubscribe(sub)
+
+			assert.Equal(t, tc.wantOK, ok)
+			assert.Equal(t, tc.wantMissed, missed)
+		})
+	}
+}
+
+func TestHub_Publish(t *testing.T) {
+	h := NewHub(10, time.Hour)
+	sub, _, _ := h.Subscribe(1, "")
+	other, _, _ := h.Subscribe(2, "")
+
+	h.Publish(1, event("1"))
+	assert.Equal(t, event("1"), <-sub.C)
+	assert.Empty(t, other.C, "events are delivered only to their user")
+
+	h.Unsubscribe(sub)
+	h.Unsubscribe(sub)
+	_, open := <-sub.C
+	assert.False(t, open)
+}
+
+func TestHub_SlowSubscriberIsDropped(t *testing.T) {
+	h := NewHub(100, time.Hour)
+	sub, _, _ := h.Subscribe(1, "")
+
+	for i := 0; i <= h.subscriberBuf; i++ {
+		h.Publish(1, event("x"))
+	}
+
+	for range h.subscriberBuf {
+		<-sub.C
+	}
+	_, open := <-sub.C
+	assert.False(t, open)
+	h.Unsubscribe(sub)
+}
+
+func TestHub_Close(t *testing.T) {
+	h := NewHub(10, time.Hour)
+	sub, _, _ := h.Subscribe(1, "")
+
+	h.Close()
+	_, open := <-sub.C
+	assert.False(t, open)
+	h.Unsubscribe(sub)
+
+	late, _, _ := h.Subscribe(1, "")
+	_, open = <-late.C
+	assert.False(t, open, "subscriptions after close end immediately")
+}
+
+func TestHub_Retention(t *testing.T) {
+	start := time.Now()
+	h := NewHub(10, time.Minute)
+	h.now = func() time.Time { return start }
+	h.Publish(1, event("1"))
+	h.Publish(2, event("2"))
+
+	h.now = func() time.Time { return start.Add(time.Minute) }
+	h.Publish(2, event("3"))
+
+	require.NotContains(t, h.users, int64(1))
+	_, _, ok := h.Subscribe(2, "2")
+	assert.False(t, ok, "expired event cannot be resumed from")
+}
@@ -0,0 +1,76 @@
+package unsubscribe_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"FinanceTracker/gateway/pkg/unsubscribe"
+)
+
+func TestSigner_Verify(t *testing.T) {
+	signer := unsubscribe.New([]byte("secret"))
+	token := signer.Token(42, "digest")
+
+	testCases := []struct {
+		name         string
+		signer       *unsubscribe.Signer
+		token        string
+		wantUserID   int64
+		wantCategory string
+		wantErr      bool
+	}{
+		{
+			name:         "valid",
+			signer:       signer,
+			token:        token,
+			wantUserID:   42,
+			wantCategory: "digest",
+		},
+		{
+			name:    "other_user",
+			signer:  signer,
+			token:   strings.Replace(token, "42.", "43.", 1),
+			wantErr: true,
+		},
+		{
+			name:    "other_category",
+			signer:  signer,
+			token:   strings.Replace(token, ".digest.", ".reminders.", 1),
+			wantErr: true,
+		},
+		{
+			name:    "other_secret",
+			signer:  unsubscribe.New([]byte("other")),
+			token:   token,
+			wantErr: true,
+		},
+		{
+			name:    "empty_secret",
+			signer:  unsubscribe.New(nil),
+			token:   unsubscribe.New(nil).Token(42, "digest"),
+			wantErr: true,
+		},
+		{
+			name:    "malformed",
+			signer:  signer,
+			token:   "42",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userID, category, err := tc.signer.Verify(tc.token)
+			if tc.wantErr {
+				require.ErrorIs(t, err, unsubscribe.ErrInvalidToken)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantUserID, userID)
+			assert.Equal(t, tc.wantCategory, category)
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package utils
+
+import (
+	"FinanceTracker/gateway/pkg/logger"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CodeValidationFailed is returned when the gateway rejects a request body.
+const CodeValidationFailed = "VALIDATION_FAILED"
+
+const (
+	LangEn = "en"
+	LangRu = "ru"
+)
+
+// messages holds localized messages for error codes: reasons from
+// downstream ErrorInfo and the codes derived from HTTP statuses.
+var messages = map[string]map[string]string{
+	// auth
+	"OAUTH_FAILED": {
+		LangEn: "Failed to sign in with the provider",
+		LangRu: "Не удалось войти через провайдера",
+	},
+	"PROVIDER_MISMATCH": {
+		LangEn: "This email is registered with another sign-in method",
+		LangRu: "Этот email зарегистрирован с другим способом входа",
+	},
+	"INVALID_EMAIL": {
+		LangEn: "Invalid email format",
+		LangRu: "Некорректный формат email",
+	},
+	"OTP_REQUIRED": {
+		LangEn: "Code is required",
+		LangRu: "Введите код",
+	},
+	"INVALID_OTP": {
+		LangEn: "Invalid or expired code",
+		LangRu: "Неверный или просроченный код",
+	},
+	// profile
+	"PROFILE_NOT_FOUND": {
+		LangEn: "Profile not found",
+		LangRu: "Профиль не найден",
+	},
+	"INVALID_PREFERENCES": {
+		LangEn: "Invalid notification preferences",
+		LangRu: "Некорректные настройки уведомлений",
+	},
+	"INVALID_LOCALE": {
+		LangEn: "Unsupported notification language",
+		LangRu: "Язык уведомлений не поддерживается",
+	},
+	"INVALID_UNSUBSCRIBE_TOKEN": {
+		LangEn: "The unsubscribe link is invalid",
+		LangRu: "Ссылка для отписки недействительна",
+	},
+	// notification
+	"NOTIFICATION_NOT_FOUND": {
+		LangEn: "Notification not found",
+		LangRu: "Уведомление не найдено",
+	},
+	"INVALID_CURSOR": {
+		LangEn: "Invalid page cursor",
+		LangRu: "Некорректный курсор страницы",
+	},
+	"INVALID_PUSH_SUBSCRIPTION": {
+		LangEn: "This browser's push subscription can't be used",
+		LangRu: "Не удалось использовать push-подписку этого браузера",
+	},
+	"PUSH_DISABLED": {
+		LangEn: "Push notifications are not available",
+		LangRu: "Push-уведомления недоступны",
+	},
+	// generic
+	"BAD_REQUEST": {
+		LangEn: "Invalid request",
+		LangRu: "Некорректный запрос",
+	},
+	"UNAUTHORIZED": {
+		LangEn: "Authentication required",
+		LangRu: "Требуется авторизация",
+	},
+	"FORBIDDEN": {
+		LangEn: "Access denied",
+		LangRu: "Доступ запрещен",
+	},
+	"NOT_FOUND": {
+		LangEn: "Not found",
+		LangRu: "Не найдено",
+	},
+	"CONFLICT": {
+		LangEn: "Conflict with the current state",
+		LangRu: "Конфликт с текущим состоянием",
+	},
+	"TOO_MANY_REQUESTS": {
+		LangEn: "Too many requests. Please wait.",
+		LangRu: "Слишком много запросов. Подождите.",
+	},
+	"INTERNAL_SERVER_ERROR": {
+		LangEn: "Internal server error",
+		LangRu: "Внутренняя ошибка сервера",
+	},
+	"NOT_IMPLEMENTED": {
+		LangEn: "Not implemented",
+		LangRu: "Не реализовано",
+	},
+	"SERVICE_UNAVAILABLE": {
+		LangEn: "Service unavailable",
+		LangRu: "Сервис недоступен",
+	},
+	"GATEWAY_TIMEOUT": {
+		LangEn: "Service did not respond in time",
+		LangRu: "Сервис не ответил вовремя",
+	},
+}
+
+// WriteGRPCError translates a downstream gRPC error into an ErrorResponse.
+// The code is taken from ErrorInfo, field errors from BadRequest details.
+// Server-side errors are logged and never expose downstream messages.
+func WriteGRPCError(w http.ResponseWriter, r *http.Request, err error) error {
+	st := status.Convert(err)
+	httpStatus := HTTPStatus(st.Code())
+	lang := Language(r)
+
+	res := ErrorResponse{Code: StatusCode(httpStatus), Status: httpStatus}
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, d := range st.Details() {
+		switch d := d.(type) {
+		case *errdetails.ErrorInfo:
+			if httpStatus < http.StatusInternalServerError {
+				res.Code = d.Reason
+			}
+		case *errdetails.BadRequest:
+			violations = append(violations, d.FieldViolations...)
+		}
+	}
+
+	if httpStatus >= http.StatusInternalServerError {
+		logger.Error(r.Context(), "downstream request failed", "code", st.Code().String(), "err", st.Message())
+		res.Message = Localize(res.Code, lang, http.StatusText(httpStatus))
+	} else {
+		res.Message = Localize(res.Code, lang, st.Message())
+	}
+
+	if len(violations) > 0 {
+		res.Fields = make(map[string]string, len(violations))
+		for _, v := range violations {
+			res.Fields[v.Field] = Localize(res.Code, lang, v.Description)
+		}
+	}
+
+	return WriteJSON(w, res, httpStatus)
+}
+
+// ErrorReason returns the ErrorInfo reason of a gRPC error, if any.
+func ErrorReason(err error) string {
+	for _, d := range status.Convert(err).Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			return info.Reason
+		}
+	}
+	return ""
+}
+
+// HTTPStatus maps gRPC codes to HTTP statuses.
+func HTTPStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// StatusCode derives an error code from an HTTP status, e.g. 404 -> NOT_FOUND.
+func StatusCode(httpStatus int) string {
+	text := http.StatusText(httpStatus)
+	if text == "" {
+		return "HTTP_" + strconv.Itoa(httpStatus)
+	}
+	return strings.ToUpper(strings.ReplaceAll(text, " ", "_"))
+}
+
+// Localize returns the message for code in lang, or fallback if there is none.
+func Localize(code, lang, fallback string) string {
+	if msg, ok := messages[code][lang]; ok {
+		return msg
+	}
+	return fallback
+}
+
+// Language picks the supported language preferred by Accept-Language.
+func Language(r *http.Request) string {
+	lang, best := LangEn, 0.0
+	for _, part := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		primary, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		if primary != LangEn && primary != LangRu {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > best {
+			lang, best = primary, q
+		}
+	}
+	return lang
+}
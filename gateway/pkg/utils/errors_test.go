@@ -0,0 +1,135 @@
+package utils
+
+import (
+	"FinanceTracker/gateway/pkg/logger"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func withDetails(t *testing.T, st *status.Status, details ...*errdetails.ErrorInfo) error {
+	for _, d := range details {
+		var err error
+		st, err = st.WithDetails(d)
+		require.NoError(t, err)
+	}
+	return st.Err()
+}
+
+func TestWriteGRPCError(t *testing.T) {
+	notFound := withDetails(t, status.New(codes.NotFound, "profile not found"),
+		&errdetails.ErrorInfo{Reason: "PROFILE_NOT_FOUND", Domain: "financetracker"})
+
+	badEmail, err := status.New(codes.InvalidArgument, "invalid email format").WithDetails(
+		&errdetails.ErrorInfo{Reason: "INVALID_EMAIL", Domain: "financetracker"},
+		&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "email", Description: "invalid email format"},
+		}},
+	)
+	require.NoError(t, err)
+
+	internal := withDetails(t, status.New(codes.Internal, "failed to get profile: pq: connection refused"),
+		&errdetails.ErrorInfo{Reason: "INTERNAL", Domain: "financetracker"})
+
+	testCases := []struct {
+		name           string
+		err            error
+		acceptLanguage string
+		want           ErrorResponse
+	}{
+		{
+			name: "reason_from_error_info",
+			err:  notFound,
+			want: ErrorResponse{Code: "PROFILE_NOT_FOUND", Status: http.StatusNotFound, Message: "Profile not found"},
+		},
+		{
+			name:           "localized_message",
+			err:            notFound,
+			acceptLanguage: "ru-RU,ru;q=0.9,en;q=0.8",
+			want:           ErrorResponse{Code: "PROFILE_NOT_FOUND", Status: http.StatusNotFound, Message: "Профиль не найден"},
+		},
+		{
+			name:           "field_violations",
+			err:            badEmail.Err(),
+			acceptLanguage: "ru",
+			want: ErrorResponse{
+				Code:    "INVALID_EMAIL",
+				Status:  http.StatusBadRequest,
+				Message: "Некорректный формат email",
+				Fields:  map[string]string{"email": "Некорректный формат email"},
+			},
+		},
+		{
+			name: "unknown_reason_keeps_message",
+			err: withDetails(t, status.New(codes.FailedPrecondition, "something is off"),
+				&errdetails.ErrorInfo{Reason: "SOMETHING_OFF"}),
+			want: ErrorResponse{Code: "SOMETHING_OFF", Status: http.StatusBadRequest, Message: "something is off"},
+		},
+		{
+			name: "no_details",
+			err:  status.Error(codes.Unauthenticated, "invalid OTP"),
+			want: ErrorResponse{Code: "UNAUTHORIZED", Status: http.StatusUnauthorized, Message: "Authentication required"},
+		},
+		{
+			name: "internal_hides_details",
+			err:  internal,
+			want: ErrorResponse{Code: "INTERNAL_SERVER_ERROR", Status: http.StatusInternalServerError, Message: "Internal server error"},
+		},
+		{
+			name:           "unavailable",
+			err:            status.Error(codes.Unavailable, "connection refused"),
+			acceptLanguage: "ru",
+			want:           ErrorResponse{Code: "SERVICE_UNAVAILABLE", Status: http.StatusServiceUnavailable, Message: "Сервис недоступен"},
+		},
+		{
+			name: "not_a_status",
+			err:  errors.New("boom"),
+			want: ErrorResponse{Code: "INTERNAL_SERVER_ERROR", Status: http.StatusInternalServerError, Message: "Internal server error"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req = req.WithContext(logger.WithLogger(req.Context(), slog.New(slog.DiscardHandler)))
+			if tc.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tc.acceptLanguage)
+			}
+			rec := httptest.NewRecorder()
+
+			require.NoError(t, WriteGRPCError(rec, req, tc.err))
+
+			var got ErrorResponse
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+			assert.Equal(t, tc.want.Status, rec.Code)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestLanguage(t *testing.T) {
+	testCases := map[string]string{
+		"":                        LangEn,
+		"ru":                      LangRu,
+		"ru-RU,ru;q=0.9":          LangRu,
+		"en-US,en;q=0.9,ru;q=0.8": LangEn,
+		"de,ru;q=0.5,en;q=0.3":    LangRu,
+		"fr":                      LangEn,
+		"ru;q=x":                  LangEn,
+	}
+
+	for header, want := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept-Language", header)
+		assert.Equal(t, want, Language(req), header)
+	}
+}
@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// ClientIPKey names the client IP in gRPC metadata.
+const ClientIPKey = "x-client-ip"
+
+// UnaryClientInterceptor forwards the client IP to downstream services.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if ip := GetClientIP(ctx); ip != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, ClientIPKey, ip)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
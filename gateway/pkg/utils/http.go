@@ -2,8 +2,11 @@ package utils
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"net/http"
+	"strings"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -14,19 +17,45 @@ func WriteJSON(w http.ResponseWriter, payload any, code int) error {
 	return json.NewEncoder(w).Encode(payload)
 }
 
-func DecodeBody(r *http.Request, v any) error {
-	return json.NewDecoder(r.Body).Decode(v)
+// WriteETagJSON writes an encoded JSON body with an ETag, or answers
+// 304 Not Modified when If-None-Match already holds it.
+func WriteETagJSON(w http.ResponseWriter, r *http.Request, body []byte) error {
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	h := w.Header()
+	h.Set("ETag", etag)
+	h.Set("Cache-Control", "private, no-cache")
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+
+	h.Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, err := w.Write(body)
+	return err
 }
 
-// ValidationErrorResponse contains field-specific validation messages
-// swagger:model ValidationErrorResponse
-type ValidationErrorResponse struct {
-	Message string            `json:"message"`
-	Fields  map[string]string `json:"fields"`
+// etagMatches uses the weak comparison required for If-None-Match.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+func DecodeBody(r *http.Request, v any) error {
+	return json.NewDecoder(r.Body).Decode(v)
 }
 
 func WriteValidationError(w http.ResponseWriter, err error) error {
-	res := ValidationErrorResponse{
+	res := ErrorResponse{
+		Code:    CodeValidationFailed,
+		Status:  http.StatusBadRequest,
 		Message: "invalid request",
 		Fields:  make(map[string]string),
 	}
@@ -41,14 +70,18 @@ func WriteValidationError(w http.ResponseWriter, err error) error {
 	return WriteJSON(w, res, http.StatusBadRequest)
 }
 
-// ErrorResponse describes a standard error response
+// ErrorResponse describes a standard error response.
+// Code is a machine-readable reason, Fields lists invalid request fields.
 // swagger:model ErrorResponse
 type ErrorResponse struct {
-	Message string `json:"message"`
+	Code    string            `json:"code" example:"PROFILE_NOT_FOUND"`
+	Status  int               `json:"status" example:"404"`
+	Message string            `json:"message"`
+	Fields  map[string]string `json:"fields,omitempty"`
 }
 
 func WriteError(w http.ResponseWriter, message string, code int) error {
-	return WriteJSON(w, ErrorResponse{Message: message}, code)
+	return WriteJSON(w, ErrorResponse{Code: StatusCode(code), Status: code, Message: message}, code)
 }
 
 // MessageResponse describes a simple message response
@@ -73,3 +106,16 @@ func GetUserID(ctx context.Context) int64 {
 func WithUserID(ctx context.Context, userID int64) context.Context {
 	return context.WithValue(ctx, userIdKey{}, userID)
 }
+
+type clientIPKey struct{}
+
+func GetClientIP(ctx context.Context) string {
+	if ip, ok := ctx.Value(clientIPKey{}).(string); ok {
+		return ip
+	}
+	return ""
+}
+
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, ip)
+}
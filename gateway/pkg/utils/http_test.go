@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteETagJSON(t *testing.T) {
+	body := []byte(`{"user_id":1}`)
+
+	rec := httptest.NewRecorder()
+	require.NoError(t, WriteETagJSON(rec, httptest.NewRequest(http.MethodGet, "/", nil), body))
+	etag := rec.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, body, rec.Body.Bytes())
+
+	testCases := []struct {
+		name        string
+		ifNoneMatch string
+		wantStatus  int
+	}{
+		{name: "same_etag", ifNoneMatch: etag, wantStatus: http.StatusNotModified},
+		{name: "weak_etag", ifNoneMatch: "W/" + etag, wantStatus: http.StatusNotModified},
+		{name: "etag_in_list", ifNoneMatch: `"other", ` + etag, wantStatus: http.StatusNotModified},
+		{name: "any", ifNoneMatch: "*", wantStatus: http.StatusNotModified},
+		{name: "stale_etag", ifNoneMatch: `"other"`, wantStatus: http.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("If-None-Match", tc.ifNoneMatch)
+			rec := httptest.NewRecorder()
+
+			require.NoError(t, WriteETagJSON(rec, req, body))
+
+			assert.Equal(t, tc.wantStatus, rec.Code)
+			assert.Equal(t, etag, rec.Header().Get("ETag"))
+			if tc.wantStatus == http.StatusNotModified {
+				assert.Empty(t, rec.Body.Bytes())
+			}
+		})
+	}
+}
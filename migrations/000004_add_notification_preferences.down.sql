@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS notification_preferences;
+DROP TYPE IF EXISTS notification_mode;
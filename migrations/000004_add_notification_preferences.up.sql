@@ -0,0 +1,13 @@
+CREATE TYPE notification_mode AS ENUM('immediate', 'digest');
+
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id INT PRIMARY KEY REFERENCES users (user_id) ON DELETE CASCADE,
+    channels TEXT[] NOT NULL DEFAULT '{email,in_app}',
+    reminder_lead_days INT NOT NULL DEFAULT 1,
+    -- minutes since midnight in timezone, both NULL when quiet hours are off
+    quiet_hours_start SMALLINT,
+    quiet_hours_end SMALLINT,
+    timezone TEXT NOT NULL DEFAULT 'UTC',
+    mode notification_mode NOT NULL DEFAULT 'immediate',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS telegram_link_codes (
+    code TEXT PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS telegram_link_codes_user_id_idx ON telegram_link_codes (user_id);
+
+CREATE TABLE IF NOT EXISTS telegram_links (
+    user_id INT PRIMARY KEY REFERENCES users (user_id) ON DELETE CASCADE,
+    -- a chat receives messages of one user only
+    chat_id BIGINT NOT NULL UNIQUE,
+    username TEXT NOT NULL DEFAULT '',
+    linked_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1,5 @@
+ALTER TABLE notification_preferences
+    DROP COLUMN IF EXISTS digest_day,
+    DROP COLUMN IF EXISTS digest_frequency;
+
+DROP TYPE IF EXISTS digest_frequency;
@@ -0,0 +1,6 @@
+CREATE TYPE digest_frequency AS ENUM('daily', 'weekly');
+
+ALTER TABLE notification_preferences
+    ADD COLUMN IF NOT EXISTS digest_frequency digest_frequency NOT NULL DEFAULT 'weekly',
+    -- day of week of weekly digests, 0 is Sunday
+    ADD COLUMN IF NOT EXISTS digest_day SMALLINT NOT NULL DEFAULT 1 CHECK (digest_day BETWEEN 0 AND 6);
@@ -0,0 +1,2 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS locale TEXT NOT NULL DEFAULT 'ru' CHECK (locale IN ('ru', 'en'));
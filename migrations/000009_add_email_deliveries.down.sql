@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS email_deliveries;
+
+DROP TYPE IF EXISTS email_delivery_status;
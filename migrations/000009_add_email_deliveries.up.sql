@@ -0,0 +1,22 @@
+CREATE TYPE email_delivery_status AS ENUM('queued', 'sent', 'failed');
+
+CREATE TABLE IF NOT EXISTS email_deliveries (
+    delivery_id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    email TEXT NOT NULL,
+    -- security, transactional or digest
+    type TEXT NOT NULL,
+    template TEXT NOT NULL,
+    locale TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    -- template data for resending, NULL for emails with secrets such as login codes
+    payload JSONB,
+    status email_delivery_status NOT NULL DEFAULT 'queued',
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    sent_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS email_deliveries_email_idx ON email_deliveries (lower(email), delivery_id DESC);
+CREATE INDEX IF NOT EXISTS email_deliveries_created_at_idx ON email_deliveries (created_at);
@@ -0,0 +1,3 @@
+ALTER TABLE notification_preferences
+    -- email categories turned off by unsubscribe links: reminders, digest
+    ADD COLUMN IF NOT EXISTS email_unsubscribed TEXT[] NOT NULL DEFAULT '{}';
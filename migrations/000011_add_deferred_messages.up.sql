@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS deferred_messages (
+    message_id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    -- identifies the kafka message, so that a redelivery is not deferred twice
+    dedup_key TEXT NOT NULL UNIQUE,
+    user_id INT NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    topic TEXT NOT NULL,
+    -- channels that wait, e.g. {email,telegram}
+    channels TEXT[] NOT NULL,
+    -- the event as it was consumed
+    payload JSONB NOT NULL,
+    -- end of the user's quiet hours
+    deliver_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS deferred_messages_deliver_at_idx ON deferred_messages (deliver_at);
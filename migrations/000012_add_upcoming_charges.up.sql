@@ -0,0 +1,14 @@
+-- the next charge of every subscription, as planned by the scheduler; the
+-- digests are built from it
+CREATE TABLE IF NOT EXISTS upcoming_charges (
+    subscription_id INT PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    service TEXT NOT NULL,
+    -- in minor units, e.g. kopecks
+    amount BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    charge_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS upcoming_charges_charge_at_idx ON upcoming_charges (charge_at);
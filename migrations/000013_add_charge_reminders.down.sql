@@ -0,0 +1,2 @@
+ALTER TABLE upcoming_charges
+    DROP COLUMN IF EXISTS reminded_at;
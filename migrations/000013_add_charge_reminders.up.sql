@@ -0,0 +1,4 @@
+-- set when the reminder of the charge at charge_at is claimed for sending;
+-- rescheduling the charge clears it
+ALTER TABLE upcoming_charges
+    ADD COLUMN IF NOT EXISTS reminded_at TIMESTAMPTZ;
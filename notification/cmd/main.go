@@ -1,33 +1,173 @@
 package main
 
 import (
+	"FinanceTracker/notification/internal/app"
+	"FinanceTracker/notification/internal/bot"
 	"FinanceTracker/notification/internal/config"
 	"FinanceTracker/notification/internal/consumer"
+	"FinanceTracker/notification/internal/controller"
+	"FinanceTracker/notification/internal/domain"
+	"FinanceTracker/notification/internal/emails"
+	"FinanceTracker/notification/internal/i18n"
+	"FinanceTracker/notification/internal/repo"
 	"FinanceTracker/notification/internal/service"
+	notificationPb "FinanceTracker/notification/pkg/api/notification"
+	profilePb "FinanceTracker/notification/pkg/api/profile"
+	"FinanceTracker/notification/pkg/health"
 	"FinanceTracker/notification/pkg/logger"
+	"FinanceTracker/notification/pkg/mail"
+	"FinanceTracker/notification/pkg/postgres"
+	"FinanceTracker/notification/pkg/resilience"
+	"FinanceTracker/notification/pkg/telegram"
+	"FinanceTracker/notification/pkg/tracing"
+	"FinanceTracker/notification/pkg/unsubscribe"
+	"FinanceTracker/notification/pkg/webpush"
+	"FinanceTracker/notification/templates"
 
 	"context"
+	"os"
 	"os/signal"
 	"syscall"
+	_ "time/tzdata" // quiet hours are evaluated in the user's timezone
 
 	"github.com/joho/godotenv"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "preview" {
+		os.Exit(preview(os.Args[2:]))
+	}
+
 	conf := config.New()
 	log := logger.New(conf.Env)
 
-	mailService := service.NewMailService(conf.SMTP)
-	consumer := consumer.New(conf.KafkaBrokers, conf.KafkaGroupID, mailService)
+	tracer := tracing.MustNew(context.Background(), "notification", conf.Tracing.Exporter, conf.Tracing.Endpoint)
+
+	postgres := postgres.MustNew(conf.PostgresURL)
+	defer postgres.Close()
+	log.Info("postgres connected")
+
+	renderer, err := emails.New()
+	if err != nil {
+		log.Error("failed to parse email templates", "err", err)
+		os.Exit(1)
+	}
+	// the inbox, push and telegram texts
+	catalog, err := i18n.Load(templates.I18n)
+	if err != nil {
+		log.Error("failed to load message catalog", "err", err)
+		os.Exit(1)
+	}
+	var transport mail.Transport
+	switch conf.Mail.Transport {
+	case "smtp":
+		smtpTransport := mail.NewSMTP(conf.SMTP.Host, conf.SMTP.Port, conf.SMTP.User, conf.SMTP.Pass, conf.SMTP.MaxConns, conf.SMTP.IdleTimeout)
+		defer smtpTransport.Close()
+		transport = smtpTransport
+	case "file":
+		transport, err = mail.NewDir(conf.Mail.Dir)
+		if err != nil {
+			log.Error("failed to create mail directory", "err", err)
+			os.Exit(1)
+		}
+		log.Info("emails are dropped as files", "dir", conf.Mail.Dir)
+	default:
+		log.Error("unknown mail transport", "transport", conf.Mail.Transport)
+		os.Exit(1)
+	}
+	var unsubscribeLinks *service.UnsubscribeLinks
+	if conf.Unsubscribe.Secret != "" {
+		unsubscribeLinks = &service.UnsubscribeLinks{
+			Signer:   unsubscribe.New([]byte(conf.Unsubscribe.Secret)),
+			OneClick: conf.Unsubscribe.URL,
+			Page:     conf.Unsubscribe.PageURL,
+		}
+	}
+	mailService := service.NewMailService(conf.Mail.From, transport, renderer, repo.NewDeliveryRepo(postgres),
+		service.RetryPolicy{MaxAttempts: conf.Mail.MaxAttempts, Backoff: conf.Mail.RetryBackoff}, unsubscribeLinks)
+	notificationService := service.NewNotificationService(repo.NewNotificationRepo(postgres), catalog)
+	profileConn, err := grpc.NewClient(conf.ProfileServiceAddr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithUnaryInterceptor(resilience.Timeout(conf.ProfileServiceTimeout)),
+	)
+	if err != nil {
+		log.Error("failed to create grpc profile client", "err", err)
+		os.Exit(1)
+	}
+	defer profileConn.Close()
+	profileService := profilePb.NewProfileServiceClient(profileConn)
+	preferences := repo.NewPreferencesRepo(profileService, conf.PreferencesCacheTTL)
+
+	notifiers := make(map[string]consumer.Notifier)
+	var telegramBot interface{ Run(context.Context) }
+	if conf.Telegram.Token != "" {
+		telegramClient := telegram.New(conf.Telegram.APIURL, conf.Telegram.Token, conf.Telegram.RateLimit)
+		telegramRepo := repo.NewTelegramRepo(profileService)
+		telegramService := service.NewTelegramService(telegramClient, telegramRepo, catalog)
+		notifiers[domain.ChannelTelegram] = telegramService
+		if conf.Telegram.Polling {
+			telegramBot = bot.New(telegramClient, telegramRepo, telegramService, conf.Telegram.PollTimeout)
+		}
+	}
+
+	var pushSender service.PushSender
+	if conf.WebPush.VAPIDPublicKey != "" {
+		client, err := webpush.New(conf.WebPush.VAPIDPublicKey, conf.WebPush.VAPIDPrivateKey, conf.WebPush.Subject, conf.WebPush.TTL)
+		if err != nil {
+			log.Error("failed to create web push client", "err", err)
+			os.Exit(1)
+		}
+		pushSender = client
+	}
+	pushService := service.NewPushService(repo.NewPushRepo(postgres), preferences, pushSender, conf.WebPush.AllowedHosts, catalog)
+	if pushSender != nil {
+		notifiers[domain.ChannelWebPush] = pushService
+	}
+
+	chargesRepo := repo.NewChargesRepo(postgres)
+	consumer := consumer.New(conf.KafkaBrokers, conf.KafkaGroupID, mailService, notificationService, preferences, repo.NewDeferredRepo(postgres), chargesRepo, notifiers)
+	recipientsRepo := repo.NewRecipientsRepo(profileService)
+	digestService := service.NewDigestService(chargesRepo, preferences, recipientsRepo, mailService)
+	reminderService := service.NewReminderService(chargesRepo, preferences, recipientsRepo, mailService)
+	notificationController := controller.NewNotificationController(notificationService, pushService)
+	adminController := controller.NewAdminController(mailService)
+
+	checks := health.New()
+	checks.Add("postgres", postgres.PingContext)
+	checks.Add("kafka", health.Kafka(conf.KafkaBrokers))
+	if pinger, ok := transport.(interface{ Ping(context.Context) error }); ok {
+		checks.Add("smtp", pinger.Ping)
+	}
+	// the inbox and push subscriptions only need the database, the gateway
+	// keeps serving them while mail or kafka is down
+	checks.Service(notificationPb.NotificationService_ServiceDesc.ServiceName, "postgres")
+
+	app := app.New(log, checks, []app.Controller{adminController}, notificationController)
 
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
 	defer stop()
 
+	app.Start(conf.Host, conf.Port, conf.HttpPort, conf.AdminAddr)
 	loggerCtx := logger.WithLogger(ctx, log)
 	consumer.Start(loggerCtx)
 	log.Info("consumer started")
+	go reminderService.Run(loggerCtx)
+	if conf.Digests {
+		go digestService.Run(loggerCtx)
+		log.Info("digests started")
+	}
+	if telegramBot != nil {
+		go telegramBot.Run(loggerCtx)
+		log.Info("telegram bot started")
+	}
 	<-ctx.Done()
 	consumer.Stop()
+	app.Stop()
+	tracer.Shutdown(context.Background())
 }
 
 func init() {
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"FinanceTracker/notification/internal/emails"
+	"FinanceTracker/notification/internal/i18n"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// preview renders an email template with fixture data to a local file:
+//
+//	notification preview [-o out.html] [-text] [-locale en] <template>
+func preview(args []string) int {
+	fs := flag.NewFlagSet("preview", flag.ContinueOnError)
+	out := fs.String("o", "", "output file, <template>.html or <template>.txt by default")
+	text := fs.Bool("text", false, "render the plain text alternative")
+	locale := fs.String("locale", string(i18n.Default), "language of the email, ru or en")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: notification preview [-o file] [-text] [-locale ru|en] <%s>\n", strings.Join(emails.Templates, "|"))
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+
+	name := fs.Arg(0)
+	msg, ok := emails.Fixtures()[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown template %q\n", name)
+		fs.Usage()
+		return 2
+	}
+
+	renderer, err := emails.New()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	// a sample link shows where the footer puts it
+	var unsubscribeURL string
+	if _, ok := msg.(emails.Unsubscribable); ok {
+		unsubscribeURL = "http://localhost:3000/unsubscribe?token=preview"
+	}
+	rendered, err := renderer.Render(*locale, msg, unsubscribeURL)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	body, ext := rendered.HTML, ".html"
+	if *text {
+		body, ext = rendered.Text, ".txt"
+	}
+	if *out == "" {
+		*out = name + ext
+	}
+	if err := os.WriteFile(*out, []byte(body), 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	fmt.Println(*out)
+	return 0
+}
@@ -0,0 +1,17 @@
+// Command vapidkeys prints a new VAPID key pair for the web push channel.
+package main
+
+import (
+	"FinanceTracker/notification/pkg/webpush"
+	"fmt"
+	"os"
+)
+
+func main() {
+	public, private, err := webpush.GenerateVAPIDKeys()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to generate keys:", err)
+		os.Exit(1)
+	}
+	fmt.Printf("WEB_PUSH_VAPID_PUBLIC_KEY=%s\nWEB_PUSH_VAPID_PRIVATE_KEY=%s\n", public, private)
+}
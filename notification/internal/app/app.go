@@ -0,0 +1,102 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"FinanceTracker/notification/pkg/health"
+	log "FinanceTracker/notification/pkg/logger"
+	"FinanceTracker/notification/pkg/metrics"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+)
+
+type app struct {
+	logger   *slog.Logger
+	health   *health.Health
+	srv      *grpc.Server
+	adminSrv *grpc.Server
+	httpSrv  *http.Server
+}
+
+type Controller interface {
+	Register(server *grpc.Server)
+}
+
+// New serves controllers to the gateway and admin on a separate server for
+// support tools, which must not be reachable from outside.
+func New(logger *slog.Logger, health *health.Health, admin []Controller, controllers ...Controller) *app {
+	server := newServer(logger)
+	for _, c := range controllers {
+		c.Register(server)
+	}
+	health.Register(server)
+
+	adminServer := newServer(logger)
+	for _, c := range admin {
+		c.Register(adminServer)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", metrics.Handler())
+	health.Routes(mux)
+
+	return &app{logger: logger, health: health, srv: server, adminSrv: adminServer, httpSrv: &http.Server{Handler: mux}}
+}
+
+func newServer(logger *slog.Logger) *grpc.Server {
+	return grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(
+			log.UnaryInterceptor(logger),
+			metrics.UnaryInterceptor(),
+			log.RecoveryInterceptor(),
+		),
+	)
+}
+
+func (a *app) Start(host string, port, httpPort int, adminAddr string) {
+	go a.serve(a.srv, fmt.Sprintf("%s:%d", host, port), "server")
+	go a.serve(a.adminSrv, adminAddr, "admin server")
+
+	a.httpSrv.Addr = fmt.Sprintf("%s:%d", host, httpPort)
+	go func() {
+		a.logger.Info("http server started", "addr", a.httpSrv.Addr)
+		if err := a.httpSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			exitIfErr(a.logger, err, "failed to serve http")
+		}
+	}()
+}
+
+func (a *app) serve(srv *grpc.Server, addr, name string) {
+	lis, err := net.Listen("tcp", addr)
+	exitIfErr(a.logger, err, "failed to listen")
+	a.logger.Info(name+" started", "addr", lis.Addr())
+	err = srv.Serve(lis)
+	exitIfErr(a.logger, err, "failed to serve")
+}
+
+func (a *app) Stop() {
+	a.health.Shutdown()
+	a.srv.GracefulStop()
+	a.adminSrv.GracefulStop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	a.httpSrv.Shutdown(ctx)
+
+	a.logger.Info("server stopped")
+}
+
+func exitIfErr(logger *slog.Logger, err error, msg string) {
+	if err != nil {
+		logger.Error(msg, "err", err)
+		os.Exit(1)
+	}
+}
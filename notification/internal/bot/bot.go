@@ -0,0 +1,104 @@
+// Package bot runs the Telegram bot that links chats to users.
+package bot
+
+import (
+	"FinanceTracker/notification/internal/domain"
+	"FinanceTracker/notification/pkg/logger"
+	"FinanceTracker/notification/pkg/telegram"
+	"context"
+	"errors"
+	"time"
+)
+
+const maxBackoff = time.Minute
+
+type Updates interface {
+	GetUpdates(ctx context.Context, offset int64, timeout time.Duration) ([]telegram.Update, error)
+}
+
+// Linker redeems a link code issued by profile for a chat.
+type Linker interface {
+	Link(ctx context.Context, code string, chatID int64, username string) error
+}
+
+type Replier interface {
+	Send(ctx context.Context, chatID int64, locale, kind string, data any) error
+}
+
+type bot struct {
+	updates     Updates
+	linker      Linker
+	replier     Replier
+	pollTimeout time.Duration
+}
+
+func New(updates Updates, linker Linker, replier Replier, pollTimeout time.Duration) *bot {
+	return &bot{
+		updates:     updates,
+		linker:      linker,
+		replier:     replier,
+		pollTimeout: pollTimeout,
+	}
+}
+
+// Run long polls for updates until ctx is canceled. The Bot API allows one
+// poller per token, so only one instance of the service may run it.
+func (b *bot) Run(ctx context.Context) {
+	var offset int64
+	backoff := time.Second
+	for {
+		updates, err := b.updates.GetUpdates(ctx, offset, b.pollTimeout)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			logger.Error(ctx, "failed to get telegram updates", "err", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			backoff = min(backoff*2, maxBackoff)
+			continue
+		}
+		backoff = time.Second
+
+		for _, u := range updates {
+			offset = u.UpdateID + 1
+			b.handle(ctx, u)
+		}
+	}
+}
+
+func (b *bot) handle(ctx context.Context, u telegram.Update) {
+	m := u.Message
+	if m == nil || m.Chat.Type != telegram.ChatPrivate {
+		return
+	}
+	code, ok := telegram.ParseStart(m.Text)
+	if !ok {
+		return
+	}
+
+	// replies are in the language of the user's Telegram app
+	var username, locale string
+	if m.From != nil {
+		username, locale = m.From.Username, m.From.LanguageCode
+	}
+	reply := domain.TelegramLinked
+	if code == "" {
+		reply = domain.TelegramStart
+	} else {
+		err := b.linker.Link(ctx, code, m.Chat.ID, username)
+		if errors.Is(err, domain.ErrInvalidLinkCode) {
+			reply = domain.TelegramLinkFailed
+		} else if err != nil {
+			logger.Error(ctx, "failed to link telegram", "chatID", m.Chat.ID, "err", err)
+			return
+		}
+	}
+
+	if err := b.replier.Send(ctx, m.Chat.ID, locale, reply, nil); err != nil {
+		logger.Error(ctx, "failed to reply in telegram", "chatID", m.Chat.ID, "err", err)
+	}
+}
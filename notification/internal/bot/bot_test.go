@@ -0,0 +1,115 @@
+package bot_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"FinanceTracker/notification/internal/bot"
+	bmocks "FinanceTracker/notification/internal/bot/mocks"
+	"FinanceTracker/notification/internal/domain"
+	"FinanceTracker/notification/internal/i18n"
+	"FinanceTracker/notification/internal/service"
+	"FinanceTracker/notification/pkg/logger"
+	"FinanceTracker/notification/pkg/telegram"
+	"FinanceTracker/notification/pkg/telegram/telegramtest"
+	"FinanceTracker/notification/templates"
+)
+
+func TestBot_Start(t *testing.T) {
+	const chatID = int64(42)
+	catalog, err := i18n.Load(templates.I18n)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name         string
+		text         string
+		language     string
+		mockBehavior func(linker *bmocks.MockLinker)
+		wantReply    string
+	}{
+		{
+			name: "linked",
+			text: "/start c0de",
+			mockBehavior: func(linker *bmocks.MockLinker) {
+				linker.EXPECT().Link(mock.Anything, "c0de", chatID, "alice").Return(nil)
+			},
+			wantReply: "Telegram подключен",
+		},
+		{
+			name: "invalid_code",
+			text: "/start expired",
+			mockBehavior: func(linker *bmocks.MockLinker) {
+				linker.EXPECT().Link(mock.Anything, "expired", chatID, "alice").Return(domain.ErrInvalidLinkCode)
+			},
+			wantReply: "недействительна",
+		},
+		{
+			name:      "without_code",
+			text:      "/start",
+			wantReply: "Подключить Telegram",
+		},
+		{
+			name:      "english_app",
+			text:      "/start",
+			language:  "en-US",
+			wantReply: "Connect Telegram",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := telegramtest.NewServer(t)
+			client := telegram.New(server.URL, telegramtest.Token, 0)
+			linker := bmocks.NewMockLinker(t)
+			if tc.mockBehavior != nil {
+				tc.mockBehavior(linker)
+			}
+			replier := service.NewTelegramService(client, nil, catalog)
+
+			ctx, cancel := context.WithCancel(logger.WithLogger(context.Background(), slog.New(slog.DiscardHandler)))
+			done := make(chan struct{})
+			go func() {
+				bot.New(client, linker, replier, time.Second).Run(ctx)
+				close(done)
+			}()
+			t.Cleanup(func() {
+				cancel()
+				<-done
+			})
+
+			server.SendTextIn(chatID, "alice", tc.language, tc.text)
+
+			assert.Eventually(t, func() bool { return len(server.Sent()) == 1 }, 2*time.Second, 10*time.Millisecond)
+			sent := server.Sent()
+			if assert.Len(t, sent, 1) {
+				assert.Equal(t, chatID, sent[0].ChatID)
+				assert.Contains(t, sent[0].Text, tc.wantReply)
+			}
+		})
+	}
+}
+
+func TestBot_IgnoresOtherTexts(t *testing.T) {
+	server := telegramtest.NewServer(t)
+	client := telegram.New(server.URL, telegramtest.Token, 0)
+	linker := bmocks.NewMockLinker(t)
+	catalog, err := i18n.Load(templates.I18n)
+	require.NoError(t, err)
+	replier := service.NewTelegramService(client, nil, catalog)
+
+	ctx, cancel := context.WithCancel(logger.WithLogger(context.Background(), slog.New(slog.DiscardHandler)))
+	defer cancel()
+	go bot.New(client, linker, replier, time.Second).Run(ctx)
+
+	server.SendText(42, "alice", "hello")
+	server.SendText(42, "alice", "/start")
+
+	assert.Eventually(t, func() bool { return len(server.Sent()) == 1 }, 2*time.Second, 10*time.Millisecond)
+	assert.Never(t, func() bool { return len(server.Sent()) > 1 }, 100*time.Millisecond, 10*time.Millisecond)
+}
@@ -4,34 +4,144 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type Config struct {
-	Env string
+	Env      string
+	Host     string
+	Port     int
+	HttpPort int // metrics and health endpoints
+	// AdminAddr serves NotificationAdminService apart from the port the
+	// gateway calls. It has no authentication, so it listens on localhost
+	// unless an internal address is given.
+	AdminAddr string
+
+	PostgresURL string
+
+	ProfileServiceAddr    string
+	ProfileServiceTimeout time.Duration // per call, so a hung profile doesn't stall the consumers
+	PreferencesCacheTTL   time.Duration
 
 	KafkaGroupID string
 	KafkaBrokers []string
 
-	SMTP SMTP
+	// Digests sends the digest emails. Turn it on in one replica only,
+	// otherwise users get a digest per replica.
+	Digests bool
+
+	Mail        Mail
+	SMTP        SMTP
+	Unsubscribe Unsubscribe
+
+	Telegram Telegram
+	WebPush  WebPush
+
+	Tracing Tracing
+}
+
+type Tracing struct {
+	Exporter string // none, stdout or otlp
+	Endpoint string // otlp collector address
+}
+
+type Telegram struct {
+	Token       string // the channel and the bot are off without it
+	APIURL      string
+	PollTimeout time.Duration
+	RateLimit   int // messages per second
+	// Polling runs the bot that answers /start. The Bot API allows a single
+	// getUpdates poller per token, so only one replica may turn it on.
+	Polling bool
+}
+
+type WebPush struct {
+	VAPIDPublicKey  string // the channel is off without the key pair
+	VAPIDPrivateKey string
+	Subject         string // mailto: or https: contact for push services
+	TTL             time.Duration
+	AllowedHosts    []string // push services endpoints may point to, with subdomains
+}
+
+type Mail struct {
+	Transport    string // smtp, or file to drop .eml files in Dir instead of sending
+	Dir          string
+	From         string
+	MaxAttempts  int // per email, when the transport fails transiently
+	RetryBackoff time.Duration
+}
+
+type Unsubscribe struct {
+	Secret  string // shared with the gateway, mail goes out without the links if empty
+	URL     string // one-click endpoint of the gateway
+	PageURL string // web page confirming the unsubscribe
 }
 
 type SMTP struct {
-	Host string
-	Port int
-	User string
-	Pass string
+	Host        string
+	Port        int
+	User        string
+	Pass        string
+	MaxConns    int
+	IdleTimeout time.Duration // pooled connections idle for longer are closed
 }
 
 func New() *Config {
 	return &Config{
-		Env:          env("ENV", "development"),
-		KafkaGroupID: env("KAFKA_GROUP_ID", "notification-service"),
-		KafkaBrokers: envArray("KAFKA_BROKERS", "localhost:9092"),
+		Env:                   env("ENV", "development"),
+		Host:                  env("HOST", "localhost"),
+		Port:                  envInt("PORT", 50053),
+		HttpPort:              envInt("HTTP_PORT", 9053),
+		AdminAddr:             env("ADMIN_ADDR", "localhost:51053"),
+		PostgresURL:           env("POSTGRES_URL"),
+		ProfileServiceAddr:    env("PROFILE_SERVICE_ADDR", "localhost:50052"),
+		ProfileServiceTimeout: envDuration("PROFILE_SERVICE_TIMEOUT", 3*time.Second),
+		PreferencesCacheTTL:   envDuration("PREFERENCES_CACHE_TTL", time.Minute),
+		KafkaGroupID:          env("KAFKA_GROUP_ID", "notification-service"),
+		KafkaBrokers:          envArray("KAFKA_BROKERS", "localhost:9092"),
+		Digests:               envBool("DIGESTS"),
+		Mail: Mail{
+			Transport:    env("MAIL_TRANSPORT", "smtp"),
+			Dir:          env("MAIL_DIR", "outbox"),
+			From:         env("MAIL_FROM", env("SMTP_USER")),
+			MaxAttempts:  envInt("MAIL_MAX_ATTEMPTS", 3),
+			RetryBackoff: envDuration("MAIL_RETRY_BACKOFF", time.Second),
+		},
 		SMTP: SMTP{
-			Host: env("SMTP_HOST"),
-			Port: envInt("SMTP_PORT"),
-			User: env("SMTP_USER"),
-			Pass: env("SMTP_PASS"),
+			Host:        env("SMTP_HOST"),
+			Port:        envInt("SMTP_PORT"),
+			User:        env("SMTP_USER"),
+			Pass:        env("SMTP_PASS"),
+			MaxConns:    envInt("SMTP_MAX_CONNS", 4),
+			IdleTimeout: envDuration("SMTP_IDLE_TIMEOUT", time.Minute),
+		},
+		Unsubscribe: Unsubscribe{
+			Secret:  env("UNSUBSCRIBE_SECRET"),
+			URL:     env("UNSUBSCRIBE_URL", "http://localhost:8080/notifications/unsubscribe"),
+			PageURL: env("UNSUBSCRIBE_PAGE_URL", "http://localhost:3000/unsubscribe"),
+		},
+		Telegram: Telegram{
+			Token:       env("TELEGRAM_BOT_TOKEN"),
+			APIURL:      env("TELEGRAM_API_URL", "https://api.telegram.org"),
+			PollTimeout: envDuration("TELEGRAM_POLL_TIMEOUT", 30*time.Second),
+			RateLimit:   envInt("TELEGRAM_RATE_LIMIT", 25),
+			Polling:     envBool("TELEGRAM_POLLING"),
+		},
+		WebPush: WebPush{
+			VAPIDPublicKey:  env("WEB_PUSH_VAPID_PUBLIC_KEY"),
+			VAPIDPrivateKey: env("WEB_PUSH_VAPID_PRIVATE_KEY"),
+			Subject:         env("WEB_PUSH_SUBJECT", "mailto:support@financetracker.local"),
+			TTL:             envDuration("WEB_PUSH_TTL", 24*time.Hour),
+			AllowedHosts: envArray("WEB_PUSH_ALLOWED_HOSTS",
+				"fcm.googleapis.com",
+				"updates.push.services.mozilla.com",
+				"notify.windows.com",
+				"push.apple.com",
+			),
+		},
+		Tracing: Tracing{
+			Exporter: env("TRACING_EXPORTER", "none"),
+			Endpoint: env("TRACING_ENDPOINT", "localhost:4317"),
 		},
 	}
 }
@@ -68,3 +178,29 @@ func envInt(key string, fallback ...int) int {
 	}
 	return fallback[0]
 }
+
+func envBool(key string, fallback ...bool) bool {
+	if value, ok := os.LookupEnv(key); ok {
+		b, err := strconv.ParseBool(value)
+		if err == nil {
+			return b
+		}
+	}
+	if len(fallback) == 0 {
+		return false
+	}
+	return fallback[0]
+}
+
+func envDuration(key string, fallback ...time.Duration) time.Duration {
+	if value, ok := os.LookupEnv(key); ok {
+		d, err := time.ParseDuration(value)
+		if err == nil {
+			return d
+		}
+	}
+	if len(fallback) == 0 {
+		return 0
+	}
+	return fallback[0]
+}
@@ -2,11 +2,15 @@ package consumer
 
 import (
 	"FinanceTracker/notification/pkg/logger"
+	"FinanceTracker/notification/pkg/metrics"
+	"FinanceTracker/notification/pkg/tracing"
 	"context"
 	"errors"
 	"io"
+	"time"
 
 	kafka "github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/codes"
 )
 
 type consumerFactory struct {
@@ -55,8 +59,9 @@ func (r *consumer) Consume(ctx context.Context) {
 				continue
 			}
 		}
-		if err := r.handler(ctx, m); err != nil {
-			logger.Error(ctx, "failed to handle message", "err", err)
+		if err := r.process(ctx, m); err != nil {
+			// the message is not fetched again: committing the next one
+			// moves the offset past it
 			continue
 		}
 		if err := r.reader.CommitMessages(ctx, m); err != nil {
@@ -65,6 +70,31 @@ func (r *consumer) Consume(ctx context.Context) {
 	}
 }
 
+func (r *consumer) process(ctx context.Context, m kafka.Message) (err error) {
+	ctx, span := tracing.StartConsumerSpan(ctx, m)
+	defer span.End()
+	if id := requestID(m); id != "" {
+		ctx = logger.WithRequestID(ctx, id)
+	}
+	defer func(start time.Time) { metrics.ObserveMessage(m, start, err) }(time.Now())
+
+	if err := r.handler(ctx, m); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		logger.Error(ctx, "failed to handle message", "err", err)
+		return err
+	}
+	return nil
+}
+
 func (r *consumer) Close() error {
 	return r.reader.Close()
 }
+
+func requestID(m kafka.Message) string {
+	for _, h := range m.Headers {
+		if h.Key == logger.RequestIDKey {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
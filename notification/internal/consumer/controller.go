@@ -7,24 +7,27 @@ import (
 
 type controller struct {
 	consumers []Consumer
+	handler   *handler
 }
 
-func New(brokers []string, groupID string, svc MailService) *controller {
+func New(brokers []string, groupID string, svc MailService, inbox Inbox, prefs Preferences, deferred Deferred, charges Charges, notifiers map[string]Notifier) *controller {
 	factory := NewConsumerFactory(brokers, groupID)
-	handler := NewHandler(svc)
+	handler := NewHandler(svc, inbox, prefs, deferred, charges, notifiers)
 
 	consumers := []Consumer{
 		factory.Create(events.TopicOTPGenerated, handler.OTPGenerated),
 		factory.Create(events.TopicRegistered, handler.UserRegistered),
+		factory.Create(events.TopicChargeScheduled, handler.ChargeScheduled),
 	}
 
-	return &controller{consumers}
+	return &controller{consumers: consumers, handler: handler}
 }
 
 func (c *controller) Start(ctx context.Context) {
 	for _, consumer := range c.consumers {
 		go consumer.Consume(ctx)
 	}
+	go c.handler.RunDeferred(ctx)
 }
 
 func (c *controller) Stop() {
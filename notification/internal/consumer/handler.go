@@ -1,34 +1,80 @@
 package consumer
 
 import (
+	"FinanceTracker/notification/internal/domain"
 	"FinanceTracker/notification/pkg/events"
+	"FinanceTracker/notification/pkg/logger"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	kafka "github.com/segmentio/kafka-go"
 )
 
 type MailService interface {
-	SendOTP(ctx context.Context, email, code string) error
-	SendRegistered(ctx context.Context, email, name string) error
+	SendOTP(ctx context.Context, email, locale, code string, validFor time.Duration) error
+	SendRegistered(ctx context.Context, email, locale, name string) error
 }
 
+// Inbox stores in-app notifications next to the emails.
+type Inbox interface {
+	NotifyRegistered(ctx context.Context, userID int, locale, dedupKey string) error
+}
+
+// Preferences tells which channels a user wants non-security messages on.
+type Preferences interface {
+	Get(ctx context.Context, userID int) (domain.Preferences, error)
+}
+
+// Deferred keeps messages for the channels that wait for the end of the
+// quiet hours.
+type Deferred interface {
+	Save(ctx context.Context, msg domain.DeferredMessage) error
+	TakeDue(ctx context.Context, now time.Time, limit int) ([]domain.DeferredMessage, error)
+}
+
+// Charges keeps the upcoming charges the digests are built from.
+type Charges interface {
+	Schedule(ctx context.Context, charge domain.UpcomingCharge) error
+	Cancel(ctx context.Context, subscriptionID int) error
+}
+
+// Notifier delivers messages to a channel other than email and the inbox.
+type Notifier interface {
+	NotifyRegistered(ctx context.Context, userID int, locale, name string) error
+}
+
+const (
+	deferredInterval = time.Minute
+	// deferredBatch bounds the messages taken from the database at once
+	deferredBatch = 100
+)
+
 type handler struct {
-	svc MailService
+	svc       MailService
+	inbox     Inbox
+	prefs     Preferences
+	deferred  Deferred
+	charges   Charges
+	notifiers map[string]Notifier // by channel
+	now       func() time.Time
 }
 
-func NewHandler(svc MailService) *handler {
-	return &handler{svc: svc}
+func NewHandler(svc MailService, inbox Inbox, prefs Preferences, deferred Deferred, charges Charges, notifiers map[string]Notifier) *handler {
+	return &handler{svc: svc, inbox: inbox, prefs: prefs, deferred: deferred, charges: charges, notifiers: notifiers, now: time.Now}
 }
 
+// OTPGenerated bypasses preferences: a login code must always be delivered.
 func (h *handler) OTPGenerated(ctx context.Context, m kafka.Message) error {
 	var event events.EventOTPGenerated
 	if err := decodeMessage(m, &event); err != nil {
 		return fmt.Errorf("failed to decode message: %w", err)
 	}
 
-	return h.svc.SendOTP(ctx, event.Email, event.Code)
+	return h.svc.SendOTP(ctx, event.Email, event.Locale, event.Code, event.ExpiresAt.Sub(event.CreatedAt))
 }
 
 func (h *handler) UserRegistered(ctx context.Context, m kafka.Message) error {
@@ -37,7 +83,129 @@ func (h *handler) UserRegistered(ctx context.Context, m kafka.Message) error {
 		return fmt.Errorf("failed to decode message: %w", err)
 	}
 
-	return h.svc.SendRegistered(ctx, event.Email, event.FullName)
+	prefs, err := h.prefs.Get(ctx, event.UserID)
+	if err != nil {
+		// the message is not retried, a profile outage must not lose it
+		logger.Error(ctx, "failed to get preferences, using the defaults", "userID", event.UserID, "err", err)
+		prefs = domain.DefaultPreferences()
+	}
+	channels, later, at := prefs.Deliverable(h.now())
+	if len(later) > 0 {
+		err := h.deferred.Save(ctx, domain.DeferredMessage{
+			DedupKey:  dedupKey(m),
+			UserID:    event.UserID,
+			Topic:     events.TopicRegistered,
+			Channels:  later,
+			Payload:   m.Value,
+			DeliverAt: at,
+		})
+		if err != nil {
+			// better during the quiet hours than never
+			logger.Error(ctx, "failed to defer message, sending it now", "userID", event.UserID, "err", err)
+			channels = append(channels, later...)
+		}
+	}
+	return h.registered(ctx, event, channels, dedupKey(m))
+}
+
+// ChargeScheduled keeps the next charge of a subscription for the digests.
+func (h *handler) ChargeScheduled(ctx context.Context, m kafka.Message) error {
+	var event events.EventChargeScheduled
+	if err := decodeMessage(m, &event); err != nil {
+		return fmt.Errorf("failed to decode message: %w", err)
+	}
+
+	if event.Cancelled {
+		return h.charges.Cancel(ctx, event.SubscriptionID)
+	}
+	return h.charges.Schedule(ctx, domain.UpcomingCharge{
+		SubscriptionID: event.SubscriptionID,
+		UserID:         event.UserID,
+		Service:        event.Service,
+		Amount:         event.Amount,
+		Currency:       event.Currency,
+		ChargeAt:       event.ChargeAt,
+	})
+}
+
+func (h *handler) registered(ctx context.Context, event events.EventUserRegistered, channels []string, dedupKey string) error {
+	// Failed messages are not retried: the consumer logs the error and the
+	// next commit moves past the message. A message is only fetched again if
+	// the service stops before committing it, so the inbox and the deferred
+	// messages ignore such redeliveries, while emails and the other channels
+	// may go out twice.
+	if slices.Contains(channels, domain.ChannelInApp) {
+		if err := h.inbox.NotifyRegistered(ctx, event.UserID, event.Locale, dedupKey); err != nil {
+			return fmt.Errorf("failed to add notification: %w", err)
+		}
+	}
+
+	// one failing channel must not hold back the others; the errors are only
+	// logged
+	var errs []error
+	if slices.Contains(channels, domain.ChannelEmail) {
+		errs = append(errs, h.svc.SendRegistered(ctx, event.Email, event.Locale, event.FullName))
+	}
+	for _, channel := range channels {
+		if notifier, ok := h.notifiers[channel]; ok {
+			errs = append(errs, notifier.NotifyRegistered(ctx, event.UserID, event.Locale, event.FullName))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RunDeferred sends the messages whose quiet hours are over every
+// deferredInterval until ctx is done.
+func (h *handler) RunDeferred(ctx context.Context) {
+	ticker := time.NewTicker(deferredInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := h.SendDeferred(ctx); err != nil {
+			logger.Error(ctx, "failed to send deferred messages", "err", err)
+		}
+	}
+}
+
+// SendDeferred sends the due messages to the channels that waited. Like
+// consumed messages, a message that fails is logged and not retried.
+func (h *handler) SendDeferred(ctx context.Context) error {
+	for {
+		msgs, err := h.deferred.TakeDue(ctx, h.now(), deferredBatch)
+		if err != nil {
+			return fmt.Errorf("failed to take deferred messages: %w", err)
+		}
+		for _, msg := range msgs {
+			if err := h.sendDeferred(ctx, msg); err != nil {
+				logger.Error(ctx, "failed to send deferred message", "messageID", msg.ID, "userID", msg.UserID, "err", err)
+			}
+		}
+		if len(msgs) < deferredBatch {
+			return nil
+		}
+	}
+}
+
+func (h *handler) sendDeferred(ctx context.Context, msg domain.DeferredMessage) error {
+	switch msg.Topic {
+	case events.TopicRegistered:
+		var event events.EventUserRegistered
+		if err := json.Unmarshal(msg.Payload, &event); err != nil {
+			return fmt.Errorf("failed to decode message: %w", err)
+		}
+		return h.registered(ctx, event, msg.Channels, msg.DedupKey)
+	default:
+		return fmt.Errorf("unknown topic %q", msg.Topic)
+	}
+}
+
+// dedupKey identifies a message across redeliveries.
+func dedupKey(m kafka.Message) string {
+	return fmt.Sprintf("%s:%d:%d", m.Topic, m.Partition, m.Offset)
 }
 
 func decodeMessage(m kafka.Message, event any) error {
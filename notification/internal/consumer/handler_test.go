@@ -0,0 +1,188 @@
+package consumer
+
+import (
+	"FinanceTracker/notification/internal/domain"
+	"FinanceTracker/notification/pkg/events"
+	"FinanceTracker/notification/pkg/logger"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	kafka "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeMail struct{ registered []string }
+
+func (f *fakeMail) SendOTP(context.Context, string, string, string, time.Duration) error { return nil }
+
+func (f *fakeMail) SendRegistered(_ context.Context, email, _, _ string) error {
+	f.registered = append(f.registered, email)
+	return nil
+}
+
+type fakeInbox struct{ users []int }
+
+func (f *fakeInbox) NotifyRegistered(_ context.Context, userID int, _, _ string) error {
+	f.users = append(f.users, userID)
+	return nil
+}
+
+type fakePreferences struct {
+	prefs domain.Preferences
+	err   error
+}
+
+func (f fakePreferences) Get(context.Context, int) (domain.Preferences, error) {
+	return f.prefs, f.err
+}
+
+type fakeNotifier struct{ users []int }
+
+func (f *fakeNotifier) NotifyRegistered(_ context.Context, userID int, _, _ string) error {
+	f.users = append(f.users, userID)
+	return nil
+}
+
+type fakeDeferred struct{ msgs []domain.DeferredMessage }
+
+func (f *fakeDeferred) Save(_ context.Context, msg domain.DeferredMessage) error {
+	f.msgs = append(f.msgs, msg)
+	return nil
+}
+
+func (f *fakeDeferred) TakeDue(_ context.Context, now time.Time, _ int) ([]domain.DeferredMessage, error) {
+	var due, rest []domain.DeferredMessage
+	for _, msg := range f.msgs {
+		if msg.DeliverAt.After(now) {
+			rest = append(rest, msg)
+		} else {
+			due = append(due, msg)
+		}
+	}
+	f.msgs = rest
+	return due, nil
+}
+
+type fakeCharges map[int]domain.UpcomingCharge // by subscription
+
+func (f fakeCharges) Schedule(_ context.Context, charge domain.UpcomingCharge) error {
+	f[charge.SubscriptionID] = charge
+	return nil
+}
+
+func (f fakeCharges) Cancel(_ context.Context, subscriptionID int) error {
+	delete(f, subscriptionID)
+	return nil
+}
+
+func TestHandler_UserRegistered(t *testing.T) {
+	noon := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name         string
+		prefs        fakePreferences
+		wantEmail    bool
+		wantInbox    bool
+		wantTelegram bool
+	}{
+		{
+			name: "preferences",
+			prefs: fakePreferences{prefs: domain.Preferences{
+				Channels: []string{domain.ChannelInApp, domain.ChannelTelegram},
+				Location: time.UTC,
+				Mode:     domain.ModeImmediate,
+			}},
+			wantInbox:    true,
+			wantTelegram: true,
+		},
+		{
+			name:      "profile_unavailable_uses_defaults",
+			prefs:     fakePreferences{err: errors.New("deadline exceeded")},
+			wantEmail: true,
+			wantInbox: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mail, inbox, telegram := &fakeMail{}, &fakeInbox{}, &fakeNotifier{}
+			h := NewHandler(mail, inbox, tc.prefs, &fakeDeferred{}, nil, map[string]Notifier{domain.ChannelTelegram: telegram})
+			h.now = func() time.Time { return noon }
+
+			value, err := json.Marshal(events.EventUserRegistered{UserID: 7, Email: "alice@example.com"})
+			require.NoError(t, err)
+			ctx := logger.WithLogger(context.Background(), slog.New(slog.DiscardHandler))
+			require.NoError(t, h.UserRegistered(ctx, kafka.Message{Topic: events.TopicRegistered, Value: value}))
+
+			assert.Equal(t, tc.wantEmail, len(mail.registered) == 1)
+			assert.Equal(t, tc.wantInbox, len(inbox.users) == 1)
+			assert.Equal(t, tc.wantTelegram, len(telegram.users) == 1)
+		})
+	}
+}
+
+func TestHandler_QuietHours(t *testing.T) {
+	prefs := fakePreferences{prefs: domain.Preferences{
+		Channels:   []string{domain.ChannelEmail, domain.ChannelInApp, domain.ChannelTelegram},
+		QuietHours: &domain.QuietHours{Start: 22 * 60, End: 8 * 60},
+		Location:   time.UTC,
+		Mode:       domain.ModeImmediate,
+	}}
+	mail, inbox, telegram, deferred := &fakeMail{}, &fakeInbox{}, &fakeNotifier{}, &fakeDeferred{}
+	h := NewHandler(mail, inbox, prefs, deferred, nil, map[string]Notifier{domain.ChannelTelegram: telegram})
+	now := time.Date(2025, 1, 1, 23, 0, 0, 0, time.UTC)
+	h.now = func() time.Time { return now }
+
+	value, err := json.Marshal(events.EventUserRegistered{UserID: 7, Email: "alice@example.com"})
+	require.NoError(t, err)
+	ctx := logger.WithLogger(context.Background(), slog.New(slog.DiscardHandler))
+	require.NoError(t, h.UserRegistered(ctx, kafka.Message{Topic: events.TopicRegistered, Offset: 3, Value: value}))
+
+	// only the inbox gets it at night
+	assert.Equal(t, []int{7}, inbox.users)
+	assert.Empty(t, mail.registered)
+	assert.Empty(t, telegram.users)
+	require.Len(t, deferred.msgs, 1)
+	assert.Equal(t, []string{domain.ChannelEmail, domain.ChannelTelegram}, deferred.msgs[0].Channels)
+	assert.Equal(t, time.Date(2025, 1, 2, 8, 0, 0, 0, time.UTC), deferred.msgs[0].DeliverAt)
+
+	// nothing is due before the morning
+	now = time.Date(2025, 1, 2, 7, 59, 0, 0, time.UTC)
+	require.NoError(t, h.SendDeferred(ctx))
+	assert.Empty(t, mail.registered)
+
+	now = time.Date(2025, 1, 2, 8, 0, 0, 0, time.UTC)
+	require.NoError(t, h.SendDeferred(ctx))
+	assert.Equal(t, []string{"alice@example.com"}, mail.registered)
+	assert.Equal(t, []int{7}, telegram.users)
+	assert.Equal(t, []int{7}, inbox.users, "the inbox is not notified twice")
+	assert.Empty(t, deferred.msgs)
+}
+
+func TestHandler_ChargeScheduled(t *testing.T) {
+	charges := fakeCharges{}
+	h := NewHandler(&fakeMail{}, &fakeInbox{}, fakePreferences{}, &fakeDeferred{}, charges, nil)
+	ctx := context.Background()
+	chargeAt := time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC)
+
+	send := func(event events.EventChargeScheduled) {
+		value, err := json.Marshal(event)
+		require.NoError(t, err)
+		require.NoError(t, h.ChargeScheduled(ctx, kafka.Message{Topic: events.TopicChargeScheduled, Value: value}))
+	}
+
+	send(events.EventChargeScheduled{SubscriptionID: 1, UserID: 7, Service: "spotify", Amount: 1099, Currency: "USD", ChargeAt: chargeAt})
+	send(events.EventChargeScheduled{SubscriptionID: 2, UserID: 7, Service: "vpn", Amount: 500, Currency: "USD", ChargeAt: chargeAt})
+	// the next charge replaces the previous one
+	send(events.EventChargeScheduled{SubscriptionID: 1, UserID: 7, Service: "spotify", Amount: 1099, Currency: "USD", ChargeAt: chargeAt.AddDate(0, 1, 0)})
+	send(events.EventChargeScheduled{SubscriptionID: 2, UserID: 7, Cancelled: true})
+
+	assert.Equal(t, fakeCharges{
+		1: {SubscriptionID: 1, UserID: 7, Service: "spotify", Amount: 1099, Currency: "USD", ChargeAt: chargeAt.AddDate(0, 1, 0)},
+	}, charges)
+}
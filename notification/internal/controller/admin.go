@@ -0,0 +1,130 @@
+package controller
+
+import (
+	"FinanceTracker/notification/internal/domain"
+	pb "FinanceTracker/notification/pkg/api/notification"
+	"FinanceTracker/notification/pkg/grpcerr"
+	"FinanceTracker/notification/pkg/logger"
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// ReasonDeliveryNotFound is reported when the delivery log has no such email.
+	ReasonDeliveryNotFound = "EMAIL_DELIVERY_NOT_FOUND"
+	// ReasonNotResendable is reported for emails whose data is not kept, such as login codes.
+	ReasonNotResendable = "EMAIL_NOT_RESENDABLE"
+	// ReasonInvalidDeliveryFilter is reported for an unknown type or status in a search.
+	ReasonInvalidDeliveryFilter = "INVALID_DELIVERY_FILTER"
+)
+
+type DeliveryService interface {
+	Search(ctx context.Context, dto domain.SearchDeliveriesDto) (domain.DeliveriesPage, error)
+	Resend(ctx context.Context, id int64) (domain.Delivery, error)
+}
+
+// adminController serves support tools. It has no authentication and is
+// served on the admin address only, apart from the services the gateway
+// calls.
+type adminController struct {
+	pb.UnimplementedNotificationAdminServiceServer
+	svc DeliveryService
+}
+
+func NewAdminController(svc DeliveryService) *adminController {
+	return &adminController{svc: svc}
+}
+
+func (c *adminController) Register(server *grpc.Server) {
+	pb.RegisterNotificationAdminServiceServer(server, c)
+}
+
+func (c *adminController) SearchEmailDeliveries(ctx context.Context, req *pb.SearchEmailDeliveriesRequest) (*pb.SearchEmailDeliveriesResponse, error) {
+	if req.Type != "" && !slices.Contains([]string{domain.EmailSecurity, domain.EmailTransactional, domain.EmailReminder, domain.EmailDigest}, req.Type) {
+		return nil, grpcerr.InvalidArgument(ReasonInvalidDeliveryFilter, "type", "type must be security, transactional or digest")
+	}
+	if req.Status != "" && !slices.Contains([]string{domain.DeliveryQueued, domain.DeliverySent, domain.DeliveryFailed}, req.Status) {
+		return nil, grpcerr.InvalidArgument(ReasonInvalidDeliveryFilter, "status", "status must be queued, sent or failed")
+	}
+
+	page, err := c.svc.Search(ctx, domain.SearchDeliveriesDto{
+		Filter: domain.DeliveryFilter{
+			Email:       req.Email,
+			Type:        req.Type,
+			Template:    req.Template,
+			Status:      req.Status,
+			CreatedFrom: timeOrZero(req.CreatedFrom),
+			CreatedTo:   timeOrZero(req.CreatedTo),
+		},
+		PageSize: int(req.PageSize),
+		Cursor:   req.Cursor,
+	})
+	if errors.Is(err, domain.ErrInvalidCursor) {
+		return nil, grpcerr.InvalidArgument(ReasonInvalidCursor, "cursor", "invalid cursor")
+	}
+	if err != nil {
+		logger.Error(ctx, "failed to search email deliveries", "err", err)
+		return nil, grpcerr.Internal("failed to search email deliveries")
+	}
+
+	res := &pb.SearchEmailDeliveriesResponse{
+		Deliveries: make([]*pb.EmailDelivery, len(page.Deliveries)),
+		NextCursor: page.NextCursor,
+	}
+	for i, d := range page.Deliveries {
+		res.Deliveries[i] = deliveryToProto(d)
+	}
+	return res, nil
+}
+
+func (c *adminController) ResendEmail(ctx context.Context, req *pb.ResendEmailRequest) (*pb.EmailDelivery, error) {
+	d, err := c.svc.Resend(ctx, req.DeliveryId)
+	if errors.Is(err, domain.ErrDeliveryNotFound) {
+		return nil, grpcerr.New(codes.NotFound, ReasonDeliveryNotFound, "email delivery not found",
+			"delivery_id", strconv.FormatInt(req.DeliveryId, 10))
+	}
+	if errors.Is(err, domain.ErrNotResendable) {
+		return nil, grpcerr.New(codes.FailedPrecondition, ReasonNotResendable, "email can't be resent, login codes are not kept",
+			"delivery_id", strconv.FormatInt(req.DeliveryId, 10))
+	}
+	if err != nil {
+		logger.Error(ctx, "failed to resend email", "deliveryID", req.DeliveryId, "err", err)
+		return nil, grpcerr.Internal("failed to resend email")
+	}
+	return deliveryToProto(d), nil
+}
+
+func timeOrZero(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+func deliveryToProto(d domain.Delivery) *pb.EmailDelivery {
+	res := &pb.EmailDelivery{
+		DeliveryId: d.ID,
+		Email:      d.Email,
+		Type:       d.Type,
+		Template:   d.Template,
+		Locale:     d.Locale,
+		Subject:    d.Subject,
+		Status:     d.Status,
+		Attempts:   int32(d.Attempts),
+		LastError:  d.LastError,
+		Resendable: d.Resendable(),
+		CreatedAt:  timestamppb.New(d.CreatedAt),
+		UpdatedAt:  timestamppb.New(d.UpdatedAt),
+	}
+	if d.SentAt != nil {
+		res.SentAt = timestamppb.New(*d.SentAt)
+	}
+	return res
+}
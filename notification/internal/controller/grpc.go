@@ -0,0 +1,170 @@
+package controller
+
+import (
+	"FinanceTracker/notification/internal/domain"
+	pb "FinanceTracker/notification/pkg/api/notification"
+	"FinanceTracker/notification/pkg/grpcerr"
+	"FinanceTracker/notification/pkg/logger"
+	"context"
+	"errors"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// ReasonNotificationNotFound is reported in ErrorInfo when the user has no such notification.
+	ReasonNotificationNotFound = "NOTIFICATION_NOT_FOUND"
+	// ReasonInvalidCursor is reported when a page cursor cannot be decoded.
+	ReasonInvalidCursor = "INVALID_CURSOR"
+	// ReasonInvalidPushSubscription is reported when an endpoint or its keys can't be used.
+	ReasonInvalidPushSubscription = "INVALID_PUSH_SUBSCRIPTION"
+	// ReasonPushDisabled is reported when the service runs without VAPID keys.
+	ReasonPushDisabled = "PUSH_DISABLED"
+)
+
+type NotificationService interface {
+	List(ctx context.Context, dto domain.ListNotificationsDto) (domain.NotificationsPage, error)
+	MarkRead(ctx context.Context, userID int, notificationID int64) (domain.Notification, error)
+	MarkAllRead(ctx context.Context, userID int) (int64, error)
+	UnreadCount(ctx context.Context, userID int) (int64, error)
+}
+
+type PushService interface {
+	PublicKey() (string, error)
+	Register(ctx context.Context, sub domain.PushSubscription) (domain.PushSubscription, error)
+	Unregister(ctx context.Context, userID int, endpoint string) (bool, error)
+}
+
+type notificationController struct {
+	pb.UnimplementedNotificationServiceServer
+	svc  NotificationService
+	push PushService
+}
+
+func NewNotificationController(svc NotificationService, push PushService) *notificationController {
+	return &notificationController{svc: svc, push: push}
+}
+
+func (c *notificationController) Register(server *grpc.Server) {
+	pb.RegisterNotificationServiceServer(server, c)
+}
+
+func (c *notificationController) ListNotifications(ctx context.Context, req *pb.ListNotificationsRequest) (*pb.ListNotificationsResponse, error) {
+	page, err := c.svc.List(ctx, domain.ListNotificationsDto{
+		UserID:     int(req.UserId),
+		PageSize:   int(req.PageSize),
+		Cursor:     req.Cursor,
+		UnreadOnly: req.UnreadOnly,
+	})
+	if errors.Is(err, domain.ErrInvalidCursor) {
+		return nil, grpcerr.InvalidArgument(ReasonInvalidCursor, "cursor", "invalid cursor")
+	}
+	if err != nil {
+		logger.Error(ctx, "failed to list notifications", "userID", req.UserId, "err", err)
+		return nil, grpcerr.Internal("failed to list notifications")
+	}
+
+	res := &pb.ListNotificationsResponse{
+		Notifications: make([]*pb.Notification, len(page.Notifications)),
+		NextCursor:    page.NextCursor,
+	}
+	for i, n := range page.Notifications {
+		res.Notifications[i] = notificationToProto(n)
+	}
+	return res, nil
+}
+
+func (c *notificationController) MarkRead(ctx context.Context, req *pb.MarkReadRequest) (*pb.Notification, error) {
+	n, err := c.svc.MarkRead(ctx, int(req.UserId), req.NotificationId)
+	if errors.Is(err, domain.ErrNotificationNotFound) {
+		return nil, grpcerr.New(codes.NotFound, ReasonNotificationNotFound, "notification not found",
+			"notification_id", strconv.FormatInt(req.NotificationId, 10))
+	}
+	if err != nil {
+		logger.Error(ctx, "failed to mark notification read", "userID", req.UserId, "err", err)
+		return nil, grpcerr.Internal("failed to mark notification read")
+	}
+	return notificationToProto(n), nil
+}
+
+func (c *notificationController) MarkAllRead(ctx context.Context, req *pb.MarkAllReadRequest) (*pb.MarkAllReadResponse, error) {
+	updated, err := c.svc.MarkAllRead(ctx, int(req.UserId))
+	if err != nil {
+		logger.Error(ctx, "failed to mark notifications read", "userID", req.UserId, "err", err)
+		return nil, grpcerr.Internal("failed to mark notifications read")
+	}
+	return &pb.MarkAllReadResponse{Updated: updated}, nil
+}
+
+func (c *notificationController) GetUnreadCount(ctx context.Context, req *pb.GetUnreadCountRequest) (*pb.GetUnreadCountResponse, error) {
+	count, err := c.svc.UnreadCount(ctx, int(req.UserId))
+	if err != nil {
+		logger.Error(ctx, "failed to count unread notifications", "userID", req.UserId, "err", err)
+		return nil, grpcerr.Internal("failed to count unread notifications")
+	}
+	return &pb.GetUnreadCountResponse{Count: count}, nil
+}
+
+func (c *notificationController) GetPushPublicKey(ctx context.Context, _ *pb.GetPushPublicKeyRequest) (*pb.GetPushPublicKeyResponse, error) {
+	key, err := c.push.PublicKey()
+	if errors.Is(err, domain.ErrPushDisabled) {
+		return nil, grpcerr.New(codes.Unimplemented, ReasonPushDisabled, "web push is not configured")
+	}
+	if err != nil {
+		logger.Error(ctx, "failed to get push public key", "err", err)
+		return nil, grpcerr.Internal("failed to get push public key")
+	}
+	return &pb.GetPushPublicKeyResponse{PublicKey: key}, nil
+}
+
+func (c *notificationController) RegisterPushSubscription(ctx context.Context, req *pb.RegisterPushSubscriptionRequest) (*pb.PushSubscription, error) {
+	sub, err := c.push.Register(ctx, domain.PushSubscription{
+		UserID:   int(req.UserId),
+		Endpoint: req.Endpoint,
+		P256dh:   req.P256Dh,
+		Auth:     req.Auth,
+	})
+	if errors.Is(err, domain.ErrPushDisabled) {
+		return nil, grpcerr.New(codes.Unimplemented, ReasonPushDisabled, "web push is not configured")
+	}
+	if errors.Is(err, domain.ErrInvalidPushSubscription) {
+		return nil, grpcerr.InvalidArgument(ReasonInvalidPushSubscription, "subscription", err.Error())
+	}
+	if err != nil {
+		logger.Error(ctx, "failed to register push subscription", "userID", req.UserId, "err", err)
+		return nil, grpcerr.Internal("failed to register push subscription")
+	}
+	return &pb.PushSubscription{
+		SubscriptionId: sub.ID,
+		Endpoint:       sub.Endpoint,
+		CreatedAt:      timestamppb.New(sub.CreatedAt),
+	}, nil
+}
+
+func (c *notificationController) UnregisterPushSubscription(ctx context.Context, req *pb.UnregisterPushSubscriptionRequest) (*pb.UnregisterPushSubscriptionResponse, error) {
+	removed, err := c.push.Unregister(ctx, int(req.UserId), req.Endpoint)
+	if err != nil {
+		logger.Error(ctx, "failed to unregister push subscription", "userID", req.UserId, "err", err)
+		return nil, grpcerr.Internal("failed to unregister push subscription")
+	}
+	return &pb.UnregisterPushSubscriptionResponse{Removed: removed}, nil
+}
+
+func notificationToProto(n domain.Notification) *pb.Notification {
+	res := &pb.Notification{
+		NotificationId: n.ID,
+		UserId:         int64(n.UserID),
+		Type:           n.Type,
+		Title:          n.Title,
+		Body:           n.Body,
+		Link:           n.Link,
+		CreatedAt:      timestamppb.New(n.CreatedAt),
+	}
+	if n.ReadAt != nil {
+		res.ReadAt = timestamppb.New(*n.ReadAt)
+	}
+	return res
+}
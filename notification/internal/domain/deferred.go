@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// DeferredMessage is a consumed event whose delivery to some channels waits
+// for the end of the user's quiet hours.
+type DeferredMessage struct {
+	ID        int64
+	DedupKey  string
+	UserID    int
+	Topic     string
+	Channels  []string
+	Payload   []byte // the event as it was consumed
+	DeliverAt time.Time
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Email types.
+const (
+	EmailSecurity      = "security" // login codes, sent regardless of preferences
+	EmailTransactional = "transactional"
+	EmailReminder      = "reminder"
+	EmailDigest        = "digest"
+)
+
+// Delivery statuses. A delivery stays queued while transient failures are
+// retried.
+const (
+	DeliveryQueued = "queued"
+	DeliverySent   = "sent"
+	DeliveryFailed = "failed"
+)
+
+var (
+	ErrDeliveryNotFound = errors.New("email delivery not found")
+	// ErrNotResendable is returned for emails whose data is not kept, such as login codes.
+	ErrNotResendable = errors.New("email can't be resent")
+)
+
+// Delivery is an outbound email in the delivery log.
+type Delivery struct {
+	ID        int64
+	Email     string
+	Type      string
+	Template  string
+	Locale    string
+	Subject   string
+	Payload   []byte // template data as JSON, nil for emails with secrets
+	Status    string
+	Attempts  int
+	LastError string // of the last failed attempt
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	SentAt    *time.Time
+}
+
+// Resendable reports whether the email can be rendered again.
+func (d Delivery) Resendable() bool {
+	return d.Payload != nil
+}
+
+// DeliveryFilter narrows a search of the delivery log. Zero fields match
+// everything.
+type DeliveryFilter struct {
+	Email       string // case-insensitive
+	Type        string
+	Template    string
+	Status      string
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+}
+
+type SearchDeliveriesDto struct {
+	Filter   DeliveryFilter
+	PageSize int
+	Cursor   string
+}
+
+type DeliveriesPage struct {
+	Deliveries []Delivery
+	NextCursor string
+}
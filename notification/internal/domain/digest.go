@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// DigestHour is the local hour digests are sent at.
+const DigestHour = 9
+
+// UpcomingCharge is a subscription payment that is about to be charged.
+type UpcomingCharge struct {
+	SubscriptionID int
+	UserID         int
+	Service        string
+	Amount         int64  // in minor units, e.g. kopecks
+	Currency       string // ISO 4217 code
+	ChargeAt       time.Time
+}
+
+// Digest summarises the charges of one user over [From, To).
+type Digest struct {
+	UserID    int
+	Frequency string
+	From      time.Time // local midnight in the user's timezone
+	To        time.Time
+	Days      []DigestDay     // days with charges, in order
+	Totals    []CurrencyTotal // by currency code
+}
+
+type DigestDay struct {
+	Date    time.Time
+	Charges []UpcomingCharge
+}
+
+type CurrencyTotal struct {
+	Currency string
+	Amount   int64
+}
+
+// LastDay returns the start of the last day of the digest.
+func (d Digest) LastDay() time.Time {
+	return d.To.AddDate(0, 0, -1)
+}
+
+// ChargeCount returns the number of charges in the digest.
+func (d Digest) ChargeCount() int {
+	n := 0
+	for _, day := range d.Days {
+		n += len(day.Charges)
+	}
+	return n
+}
+
+// Empty reports whether there is nothing to tell the user about.
+func (d Digest) Empty() bool {
+	return len(d.Days) == 0
+}
+
+// DigestDue reports whether the digest of the user is sent during the hour of now.
+func (p Preferences) DigestDue(now time.Time) bool {
+	if p.Mode != ModeDigest || !slices.Contains(p.Channels, ChannelEmail) || slices.Contains(p.EmailUnsubscribed, EmailCategoryDigest) {
+		return false
+	}
+	local := now.In(p.Location)
+	if local.Hour() != DigestHour {
+		return false
+	}
+	return p.DigestFrequency == DigestDaily || local.Weekday() == p.DigestDay
+}
+
+// DigestPeriod returns the days covered by a digest sent at now: the rest of
+// today for a daily digest, today and the next six days for a weekly one.
+func (p Preferences) DigestPeriod(now time.Time) (from, to time.Time) {
+	y, m, d := now.In(p.Location).Date()
+	from = time.Date(y, m, d, 0, 0, 0, 0, p.Location)
+	days := 7
+	if p.DigestFrequency == DigestDaily {
+		days = 1
+	}
+	return from, from.AddDate(0, 0, days)
+}
+
+// BuildDigest groups the charges of a user in [from, to) by local day, where
+// the location of from is the user's timezone.
+func BuildDigest(userID int, frequency string, charges []UpcomingCharge, from, to time.Time) Digest {
+	digest := Digest{UserID: userID, Frequency: frequency, From: from, To: to}
+
+	var inPeriod []UpcomingCharge
+	for _, c := range charges {
+		if c.UserID == userID && !c.ChargeAt.Before(from) && c.ChargeAt.Before(to) {
+			inPeriod = append(inPeriod, c)
+		}
+	}
+	slices.SortStableFunc(inPeriod, func(a, b UpcomingCharge) int {
+		return cmp.Or(a.ChargeAt.Compare(b.ChargeAt), cmp.Compare(a.Service, b.Service))
+	})
+
+	totals := make(map[string]int64)
+	for _, c := range inPeriod {
+		y, m, d := c.ChargeAt.In(from.Location()).Date()
+		date := time.Date(y, m, d, 0, 0, 0, 0, from.Location())
+		if n := len(digest.Days); n == 0 || !digest.Days[n-1].Date.Equal(date) {
+			digest.Days = append(digest.Days, DigestDay{Date: date})
+		}
+		last := &digest.Days[len(digest.Days)-1]
+		last.Charges = append(last.Charges, c)
+		totals[c.Currency] += c.Amount
+	}
+
+	for currency, amount := range totals {
+		digest.Totals = append(digest.Totals, CurrencyTotal{Currency: currency, Amount: amount})
+	}
+	slices.SortFunc(digest.Totals, func(a, b CurrencyTotal) int {
+		return cmp.Compare(a.Currency, b.Currency)
+	})
+	return digest
+}
+
+// GroupChargesByUser splits charges of many users for BuildDigest.
+func GroupChargesByUser(charges []UpcomingCharge) map[int][]UpcomingCharge {
+	res := make(map[int][]UpcomingCharge)
+	for _, c := range charges {
+		res[c.UserID] = append(res[c.UserID], c)
+	}
+	return res
+}
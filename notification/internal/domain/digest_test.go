@@ -0,0 +1,122 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"FinanceTracker/notification/internal/domain"
+)
+
+func TestPreferences_DigestDue(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+	weekly := domain.Preferences{
+		Channels:        []string{domain.ChannelEmail},
+		Location:        moscow,
+		Mode:            domain.ModeDigest,
+		DigestFrequency: domain.DigestWeekly,
+		DigestDay:       time.Monday,
+	}
+	monday9 := time.Date(2025, 1, 6, 6, 0, 0, 0, time.UTC) // 09:00 in Moscow
+
+	with := func(f func(p *domain.Preferences)) domain.Preferences {
+		p := weekly
+		f(&p)
+		return p
+	}
+
+	testCases := []struct {
+		name  string
+		prefs domain.Preferences
+		now   time.Time
+		want  bool
+	}{
+		{
+			name:  "weekly_on_day_and_hour",
+			prefs: weekly,
+			now:   monday9,
+			want:  true,
+		},
+		{
+			name:  "weekly_other_hour",
+			prefs: weekly,
+			now:   monday9.Add(time.Hour),
+			want:  false,
+		},
+		{
+			name:  "weekly_other_day",
+			prefs: weekly,
+			now:   monday9.AddDate(0, 0, 1),
+			want:  false,
+		},
+		{
+			name:  "hour_in_user_timezone",
+			prefs: weekly,
+			now:   time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC), // 12:00 in Moscow
+			want:  false,
+		},
+		{
+			name:  "daily_any_day",
+			prefs: with(func(p *domain.Preferences) { p.DigestFrequency = domain.DigestDaily }),
+			now:   monday9.AddDate(0, 0, 3),
+			want:  true,
+		},
+		{
+			name:  "immediate_mode",
+			prefs: with(func(p *domain.Preferences) { p.Mode = domain.ModeImmediate }),
+			now:   monday9,
+			want:  false,
+		},
+		{
+			name:  "email_disabled",
+			prefs: with(func(p *domain.Preferences) { p.Channels = []string{domain.ChannelInApp} }),
+			now:   monday9,
+			want:  false,
+		},
+		{
+			name:  "unsubscribed_by_link",
+			prefs: with(func(p *domain.Preferences) { p.EmailUnsubscribed = []string{domain.EmailCategoryDigest} }),
+			now:   monday9,
+			want:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.prefs.DigestDue(tc.now))
+		})
+	}
+}
+
+func TestBuildDigest(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+	prefs := domain.Preferences{Location: moscow, DigestFrequency: domain.DigestWeekly}
+	from, to := prefs.DigestPeriod(time.Date(2025, 1, 6, 6, 0, 0, 0, time.UTC))
+	require.Equal(t, time.Date(2025, 1, 6, 0, 0, 0, 0, moscow), from)
+	require.Equal(t, time.Date(2025, 1, 13, 0, 0, 0, 0, moscow), to)
+
+	charges := []domain.UpcomingCharge{
+		{UserID: 1, Service: "yandex_plus", Amount: 39900, Currency: "RUB", ChargeAt: time.Date(2025, 1, 8, 10, 0, 0, 0, time.UTC)},
+		{UserID: 1, Service: "spotify", Amount: 1099, Currency: "USD", ChargeAt: time.Date(2025, 1, 6, 22, 0, 0, 0, time.UTC)}, // Jan 7 in Moscow
+		{UserID: 1, Service: "icloud", Amount: 14900, Currency: "RUB", ChargeAt: time.Date(2025, 1, 7, 9, 0, 0, 0, time.UTC)},
+		{UserID: 1, Service: "vpn", Amount: 500, Currency: "USD", ChargeAt: time.Date(2025, 1, 13, 0, 0, 0, 0, moscow)}, // next period
+		{UserID: 2, Service: "music", Amount: 16900, Currency: "RUB", ChargeAt: time.Date(2025, 1, 7, 9, 0, 0, 0, time.UTC)},
+	}
+
+	digest := domain.BuildDigest(1, domain.DigestWeekly, domain.GroupChargesByUser(charges)[1], from, to)
+
+	require.Len(t, digest.Days, 2)
+	assert.Equal(t, time.Date(2025, 1, 7, 0, 0, 0, 0, moscow), digest.Days[0].Date)
+	assert.Equal(t, "spotify", digest.Days[0].Charges[0].Service)
+	assert.Equal(t, "icloud", digest.Days[0].Charges[1].Service)
+	assert.Equal(t, time.Date(2025, 1, 8, 0, 0, 0, 0, moscow), digest.Days[1].Date)
+	assert.Equal(t, []domain.CurrencyTotal{
+		{Currency: "RUB", Amount: 54800},
+		{Currency: "USD", Amount: 1099},
+	}, digest.Totals)
+	assert.False(t, digest.Empty())
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Notification types.
+const (
+	TypeWelcome = "welcome"
+)
+
+type Notification struct {
+	ID        int64
+	UserID    int
+	Type      string
+	Title     string
+	Body      string
+	Link      string     // web app path, e.g. /subscriptions/42
+	DedupKey  string     // source event, so that redeliveries are ignored
+	ReadAt    *time.Time // nil while unread
+	CreatedAt time.Time
+}
+
+// Recipient is where an email to a user goes.
+type Recipient struct {
+	Email  string
+	Name   string
+	Locale string // ru or en
+}
+
+var (
+	ErrNotificationNotFound = errors.New("notification not found")
+	ErrInvalidCursor        = errors.New("invalid cursor")
+)
+
+type ListNotificationsDto struct {
+	UserID     int
+	PageSize   int
+	Cursor     string
+	UnreadOnly bool
+}
+
+type NotificationsPage struct {
+	Notifications []Notification
+	NextCursor    string
+}
@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// Delivery channels.
+const (
+	ChannelEmail    = "email"
+	ChannelInApp    = "in_app"
+	ChannelTelegram = "telegram"
+	ChannelWebPush  = "web_push"
+)
+
+// Delivery modes.
+const (
+	ModeImmediate = "immediate"
+	ModeDigest    = "digest"
+)
+
+// Digest frequencies.
+const (
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+)
+
+// Email categories users can unsubscribe from with a link in the email.
+const (
+	EmailCategoryReminders = "reminders"
+	EmailCategoryDigest    = "digest"
+)
+
+// Preferences is the notification side of the preferences stored by the
+// profile service.
+type Preferences struct {
+	Channels          []string
+	ReminderLeadDays  int
+	QuietHours        *QuietHours // nil when quiet hours are off
+	Location          *time.Location
+	Mode              string
+	DigestFrequency   string
+	DigestDay         time.Weekday // of weekly digests
+	EmailUnsubscribed []string     // email categories turned off by unsubscribe links
+}
+
+// DefaultPreferences mirror the defaults of the profile service, for users
+// whose preferences can't be read.
+func DefaultPreferences() Preferences {
+	return Preferences{
+		Channels:         []string{ChannelEmail, ChannelInApp},
+		ReminderLeadDays: 1,
+		Location:         time.UTC,
+		Mode:             ModeImmediate,
+		DigestFrequency:  DigestWeekly,
+		DigestDay:        time.Monday,
+	}
+}
+
+// QuietHours is a daily interval in minutes since midnight. Start may be
+// after End, e.g. 22:00-08:00.
+type QuietHours struct {
+	Start int
+	End   int
+}
+
+// Contains reports whether the clock time of t falls within the quiet hours.
+func (q QuietHours) Contains(t time.Time) bool {
+	m := t.Hour()*60 + t.Minute()
+	if q.Start <= q.End {
+		return m >= q.Start && m < q.End
+	}
+	return m >= q.Start || m < q.End
+}
+
+// EndAfter returns the first end of the quiet hours after t, in the location
+// of t.
+func (q QuietHours) EndAfter(t time.Time) time.Time {
+	end := time.Date(t.Year(), t.Month(), t.Day(), q.End/60, q.End%60, 0, 0, t.Location())
+	if !end.After(t) {
+		end = time.Date(t.Year(), t.Month(), t.Day()+1, q.End/60, q.End%60, 0, 0, t.Location())
+	}
+	return end
+}
+
+// Deliverable splits the channels of a non-security message sent at now.
+// The inbox is quiet by nature and gets it right away if enabled. Channels
+// that interrupt the user wait for the end of the quiet hours: later lists
+// them and at is when the quiet hours end. Digest mode only batches charge
+// reminders into the digest, so it holds back no other message.
+func (p Preferences) Deliverable(now time.Time) (channels, later []string, at time.Time) {
+	if slices.Contains(p.Channels, ChannelInApp) {
+		channels = append(channels, ChannelInApp)
+	}
+	local := now.In(p.Location)
+	quiet := p.QuietHours != nil && p.QuietHours.Contains(local)
+	for _, c := range p.Channels {
+		switch {
+		case c == ChannelInApp:
+		case quiet:
+			later = append(later, c)
+		default:
+			channels = append(channels, c)
+		}
+	}
+	if len(later) > 0 {
+		at = p.QuietHours.EndAfter(local)
+	}
+	return channels, later, at
+}
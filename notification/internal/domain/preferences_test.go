@@ -0,0 +1,79 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"FinanceTracker/notification/internal/domain"
+)
+
+func TestPreferences_Deliverable(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	all := []string{domain.ChannelEmail, domain.ChannelInApp, domain.ChannelTelegram, domain.ChannelWebPush}
+	interrupting := []string{domain.ChannelEmail, domain.ChannelTelegram, domain.ChannelWebPush}
+	night := &domain.QuietHours{Start: 22 * 60, End: 8 * 60}
+
+	testCases := []struct {
+		name      string
+		prefs     domain.Preferences
+		now       time.Time
+		want      []string
+		wantLater []string
+		wantAt    time.Time
+	}{
+		{
+			name:  "immediate",
+			prefs: domain.Preferences{Channels: all, Location: time.UTC, Mode: domain.ModeImmediate},
+			now:   time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+			want:  append([]string{domain.ChannelInApp}, interrupting...),
+		},
+		{
+			name:  "digest_mode_does_not_hold_back",
+			prefs: domain.Preferences{Channels: all, Location: time.UTC, Mode: domain.ModeDigest},
+			now:   time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+			want:  append([]string{domain.ChannelInApp}, interrupting...),
+		},
+		{
+			name:      "quiet_hours_before_midnight_wait_for_morning",
+			prefs:     domain.Preferences{Channels: all, QuietHours: night, Location: moscow, Mode: domain.ModeImmediate},
+			now:       time.Date(2025, 1, 1, 20, 0, 0, 0, time.UTC), // 23:00 in Moscow
+			want:      []string{domain.ChannelInApp},
+			wantLater: interrupting,
+			wantAt:    time.Date(2025, 1, 2, 5, 0, 0, 0, time.UTC), // 08:00 in Moscow
+		},
+		{
+			name:      "quiet_hours_after_midnight_wait_for_same_morning",
+			prefs:     domain.Preferences{Channels: all, QuietHours: night, Location: moscow, Mode: domain.ModeImmediate},
+			now:       time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC), // 04:00 in Moscow
+			want:      []string{domain.ChannelInApp},
+			wantLater: interrupting,
+			wantAt:    time.Date(2025, 1, 1, 5, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "after_quiet_hours",
+			prefs: domain.Preferences{Channels: all, QuietHours: night, Location: moscow, Mode: domain.ModeImmediate},
+			now:   time.Date(2025, 1, 1, 5, 0, 0, 0, time.UTC), // 08:00 in Moscow
+			want:  append([]string{domain.ChannelInApp}, interrupting...),
+		},
+		{
+			name:      "inbox_disabled",
+			prefs:     domain.Preferences{Channels: []string{domain.ChannelEmail}, QuietHours: night, Location: moscow, Mode: domain.ModeImmediate},
+			now:       time.Date(2025, 1, 1, 20, 0, 0, 0, time.UTC),
+			wantLater: []string{domain.ChannelEmail},
+			wantAt:    time.Date(2025, 1, 2, 5, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, later, at := tc.prefs.Deliverable(tc.now)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.wantLater, later)
+			assert.True(t, tc.wantAt.Equal(at), "at %s, want %s", at, tc.wantAt)
+		})
+	}
+}
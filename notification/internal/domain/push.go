@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidPushSubscription = errors.New("invalid push subscription")
+	ErrPushDisabled            = errors.New("web push is not configured")
+)
+
+// PushSubscription is a browser registered for Web Push.
+type PushSubscription struct {
+	ID        int64
+	UserID    int
+	Endpoint  string
+	P256dh    string
+	Auth      string
+	CreatedAt time.Time
+}
+
+// PushMessage is the payload the service worker shows as a notification.
+type PushMessage struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	URL   string `json:"url,omitempty"` // web app path opened on click
+	Tag   string `json:"tag,omitempty"` // replaces a shown notification with the same tag
+}
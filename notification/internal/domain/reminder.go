@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// Reminder tells a user about one charge ReminderLeadDays ahead.
+type Reminder struct {
+	Charge UpcomingCharge
+	Day    time.Time // local midnight of the charge day
+	Today  time.Time // local midnight of the day the reminder is sent
+}
+
+// ReminderDue returns the reminder of charge if it is due at now: from
+// DigestHour on the local day ReminderLeadDays before the charge, outside the
+// quiet hours. Digest mode has no reminders, the digest lists the charges.
+func (p Preferences) ReminderDue(charge UpcomingCharge, now time.Time) (Reminder, bool) {
+	if p.Mode != ModeImmediate || !slices.Contains(p.Channels, ChannelEmail) || slices.Contains(p.EmailUnsubscribed, EmailCategoryReminders) {
+		return Reminder{}, false
+	}
+	local := now.In(p.Location)
+	if local.Hour() < DigestHour || p.QuietHours != nil && p.QuietHours.Contains(local) || !charge.ChargeAt.After(now) {
+		return Reminder{}, false
+	}
+
+	today := startOfDay(local)
+	day := startOfDay(charge.ChargeAt.In(p.Location))
+	if !today.AddDate(0, 0, p.ReminderLeadDays).Equal(day) {
+		return Reminder{}, false
+	}
+	return Reminder{Charge: charge, Day: day, Today: today}, true
+}
+
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
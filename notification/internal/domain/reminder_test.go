@@ -0,0 +1,98 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"FinanceTracker/notification/internal/domain"
+)
+
+func TestPreferences_ReminderDue(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+	prefs := domain.Preferences{
+		Channels:         []string{domain.ChannelEmail},
+		ReminderLeadDays: 1,
+		Location:         moscow,
+		Mode:             domain.ModeImmediate,
+	}
+	monday9 := time.Date(2025, 1, 6, 6, 0, 0, 0, time.UTC) // 09:00 in Moscow
+	// 01:00 on Tuesday in Moscow, still Monday in UTC
+	charge := domain.UpcomingCharge{UserID: 1, Service: "spotify", ChargeAt: time.Date(2025, 1, 6, 22, 0, 0, 0, time.UTC)}
+
+	with := func(f func(p *domain.Preferences)) domain.Preferences {
+		p := prefs
+		f(&p)
+		return p
+	}
+
+	testCases := []struct {
+		name  string
+		prefs domain.Preferences
+		now   time.Time
+		want  bool
+	}{
+		{
+			name:  "lead_day_in_user_timezone",
+			prefs: prefs,
+			now:   monday9,
+			want:  true,
+		},
+		{
+			name:  "later_that_day",
+			prefs: prefs,
+			now:   monday9.Add(5 * time.Hour),
+			want:  true,
+		},
+		{
+			name:  "before_digest_hour",
+			prefs: prefs,
+			now:   monday9.Add(-time.Hour),
+		},
+		{
+			name:  "other_lead_days",
+			prefs: with(func(p *domain.Preferences) { p.ReminderLeadDays = 3 }),
+			now:   monday9,
+		},
+		{
+			name:  "charge_passed",
+			prefs: with(func(p *domain.Preferences) { p.ReminderLeadDays = 0 }),
+			now:   monday9.AddDate(0, 0, 1),
+		},
+		{
+			name:  "quiet_hours",
+			prefs: with(func(p *domain.Preferences) { p.QuietHours = &domain.QuietHours{Start: 8 * 60, End: 10 * 60} }),
+			now:   monday9,
+		},
+		{
+			name:  "digest_mode",
+			prefs: with(func(p *domain.Preferences) { p.Mode = domain.ModeDigest }),
+			now:   monday9,
+		},
+		{
+			name:  "email_off",
+			prefs: with(func(p *domain.Preferences) { p.Channels = []string{domain.ChannelInApp} }),
+			now:   monday9,
+		},
+		{
+			name:  "unsubscribed",
+			prefs: with(func(p *domain.Preferences) { p.EmailUnsubscribed = []string{domain.EmailCategoryReminders} }),
+			now:   monday9,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reminder, ok := tc.prefs.ReminderDue(charge, tc.now)
+
+			assert.Equal(t, tc.want, ok)
+			if ok {
+				assert.Equal(t, time.Date(2025, 1, 7, 0, 0, 0, 0, moscow), reminder.Day)
+				assert.Equal(t, time.Date(2025, 1, 6, 0, 0, 0, 0, moscow), reminder.Today)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "errors"
+
+// Telegram message types, each rendered from templates/telegram/<type>.html.
+const (
+	TelegramRegistered = "registered"
+	TelegramStart      = "start"
+	TelegramLinked     = "linked"
+	TelegramLinkFailed = "link_failed"
+)
+
+var (
+	ErrTelegramNotLinked = errors.New("telegram is not linked")
+	ErrInvalidLinkCode   = errors.New("invalid or expired link code")
+)
+
+// TelegramRegisteredData is the data of the TelegramRegistered message.
+type TelegramRegisteredData struct {
+	Name string
+}
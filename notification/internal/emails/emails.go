@@ -0,0 +1,149 @@
+// Package emails renders the emails of the notification service from the
+// embedded templates.
+package emails
+
+import (
+	"FinanceTracker/notification/internal/i18n"
+	"FinanceTracker/notification/templates"
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"math"
+	texttemplate "text/template"
+	"time"
+)
+
+// Message is the typed data of an email. Template names the files in
+// templates/mail without the extension, SubjectKey the subject in the
+// message catalog and Type is one of the domain email types.
+type Message interface {
+	Template() string
+	SubjectKey() string
+	Type() string
+}
+
+// Rendered is an email ready to be sent.
+type Rendered struct {
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// Unsubscribable is implemented by reminders, digests and other mail users
+// can opt out of. Such mail carries an unsubscribe link for the user and
+// the category, security and transactional mail never does.
+type Unsubscribable interface {
+	Message
+	Unsubscribe() (userID int, category string)
+}
+
+// envelope is what the layouts are executed with.
+type envelope struct {
+	Locale         i18n.Locale
+	Subject        string
+	UnsubscribeURL string // empty for mail that can't be unsubscribed from
+	Data           Message
+}
+
+// Renderer holds the templates of every message in every locale, parsed once.
+type Renderer struct {
+	catalog *i18n.Catalog
+	html    map[i18n.Locale]map[string]*htmltemplate.Template
+	text    map[i18n.Locale]map[string]*texttemplate.Template
+}
+
+// New loads the message catalog and parses the layouts and partials and
+// every message template with them. The templates are shared by all locales,
+// their texts come from the catalog.
+func New() (*Renderer, error) {
+	catalog, err := i18n.Load(templates.I18n)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load message catalog: %w", err)
+	}
+
+	baseHTML, err := htmltemplate.New("").Funcs(funcs(catalog, i18n.Default)).ParseFS(templates.Mail, "mail/layout.html", "mail/partials.html")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse html layout: %w", err)
+	}
+	baseText, err := texttemplate.New("").Funcs(funcs(catalog, i18n.Default)).ParseFS(templates.Mail, "mail/layout.txt", "mail/partials.txt")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse text layout: %w", err)
+	}
+
+	r := &Renderer{
+		catalog: catalog,
+		html:    make(map[i18n.Locale]map[string]*htmltemplate.Template),
+		text:    make(map[i18n.Locale]map[string]*texttemplate.Template),
+	}
+	for _, l := range i18n.Locales {
+		r.html[l] = make(map[string]*htmltemplate.Template)
+		r.text[l] = make(map[string]*texttemplate.Template)
+		for _, name := range Templates {
+			html, err := htmltemplate.Must(baseHTML.Clone()).Funcs(funcs(catalog, l)).ParseFS(templates.Mail, "mail/"+name+".html")
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s.html: %w", name, err)
+			}
+			text, err := texttemplate.Must(baseText.Clone()).Funcs(funcs(catalog, l)).ParseFS(templates.Mail, "mail/"+name+".txt")
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s.txt: %w", name, err)
+			}
+			r.html[l][name], r.text[l][name] = html, text
+		}
+	}
+	return r, nil
+}
+
+// Render executes both templates of the message in the locale, falling back
+// to i18n.Default for unsupported ones. The footer links to unsubscribeURL
+// unless it is empty.
+func (r *Renderer) Render(locale string, msg Message, unsubscribeURL string) (Rendered, error) {
+	l := i18n.Parse(locale)
+	name := msg.Template()
+	html, ok := r.html[l][name]
+	if !ok {
+		return Rendered{}, fmt.Errorf("unknown template %q: %w", name, fs.ErrNotExist)
+	}
+	data := envelope{Locale: l, Subject: r.catalog.T(l, msg.SubjectKey()), UnsubscribeURL: unsubscribeURL, Data: msg}
+
+	var htmlBody, textBody bytes.Buffer
+	if err := html.ExecuteTemplate(&htmlBody, "layout", data); err != nil {
+		return Rendered{}, fmt.Errorf("failed to execute %s.html: %w", name, err)
+	}
+	if err := r.text[l][name].ExecuteTemplate(&textBody, "layout", data); err != nil {
+		return Rendered{}, fmt.Errorf("failed to execute %s.txt: %w", name, err)
+	}
+	return Rendered{Subject: data.Subject, HTML: htmlBody.String(), Text: textBody.String()}, nil
+}
+
+// funcs are available to all templates and bound to the locale.
+func funcs(c *i18n.Catalog, l i18n.Locale) map[string]any {
+	return map[string]any{
+		"t": func(key string, args ...any) string {
+			return c.T(l, key, args...)
+		},
+		"plural": func(key string, n int) string {
+			return c.Plural(l, key, n)
+		},
+		"money": func(amount int64, currency string) string {
+			return i18n.FormatMoney(l, amount, currency)
+		},
+		"date": func(t time.Time) string {
+			return i18n.FormatDate(l, t)
+		},
+		"daymonth": func(t time.Time) string {
+			return i18n.FormatDayMonth(l, t)
+		},
+		// relday tells how far day is from today, both local midnights
+		"relday": func(day, today time.Time) string {
+			switch n := int(math.Round(day.Sub(today).Hours() / 24)); n {
+			case 0:
+				return c.T(l, "days.today")
+			case 1:
+				return c.T(l, "days.tomorrow")
+			default:
+				return c.Plural(l, "days.in", n)
+			}
+		},
+	}
+}
@@ -0,0 +1,145 @@
+package emails_test
+
+import (
+	"html"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"FinanceTracker/notification/internal/domain"
+	"FinanceTracker/notification/internal/emails"
+)
+
+func TestRenderer_Render(t *testing.T) {
+	r, err := emails.New()
+	require.NoError(t, err)
+
+	fixtures := emails.Fixtures()
+	require.Len(t, fixtures, len(emails.Templates))
+
+	testCases := []struct {
+		name        string
+		template    string
+		locale      string
+		wantSubject string
+		contains    []string
+	}{
+		{
+			name:        "otp_ru",
+			template:    "otp",
+			locale:      "ru",
+			wantSubject: "Код для входа в Finance Tracker",
+			contains:    []string{"482913", "Код действителен 5 минут."},
+		},
+		{
+			name:        "otp_en",
+			template:    "otp",
+			locale:      "en",
+			wantSubject: "Your Finance Tracker sign-in code",
+			contains:    []string{"482913", "The code is valid for 5 minutes."},
+		},
+		{
+			name:        "registered_ru",
+			template:    "registered",
+			locale:      "ru",
+			wantSubject: "Добро пожаловать в Finance Tracker",
+			contains:    []string{"Здравствуйте, Иван!"},
+		},
+		{
+			name:        "registered_unknown_locale",
+			template:    "registered",
+			locale:      "de",
+			wantSubject: "Добро пожаловать в Finance Tracker",
+			contains:    []string{"Здравствуйте, Иван!"},
+		},
+		{
+			name:        "reminder_en",
+			template:    "reminder",
+			locale:      "en",
+			wantSubject: "Upcoming subscription charge",
+			contains:    []string{"Your Яндекс Плюс charge is tomorrow, Tuesday, January\u00a07.", "₽399.00"},
+		},
+		{
+			name:        "digest_ru",
+			template:    "digest",
+			locale:      "ru",
+			wantSubject: "Списания на неделю",
+			contains:    []string{"548,00\u00a0₽", "вторник, 7\u00a0января · завтра", "через 4 дня", "3 списания"},
+		},
+		{
+			name:        "digest_en",
+			template:    "digest",
+			locale:      "en",
+			wantSubject: "This week's charges",
+			contains:    []string{"₽548.00", "$10.99", "Tuesday, January\u00a07 · tomorrow", "in 4 days", "3 charges"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := r.Render(tc.locale, fixtures[tc.template], "")
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.wantSubject, got.Subject)
+			assert.Contains(t, got.HTML, "<title>"+html.EscapeString(tc.wantSubject)+"</title>")
+			assert.Contains(t, got.HTML, "Finance Tracker</div>")
+			assert.NotContains(t, got.Text, "<")
+			for _, s := range tc.contains {
+				assert.Contains(t, got.Text, s)
+				assert.Contains(t, got.HTML, s)
+			}
+		})
+	}
+}
+
+func TestRenderer_Render_EscapesHTML(t *testing.T) {
+	r, err := emails.New()
+	require.NoError(t, err)
+
+	got, err := r.Render("en", emails.Registered{Name: "<script>"}, "")
+	require.NoError(t, err)
+
+	assert.NotContains(t, got.HTML, "<script>")
+	assert.Contains(t, got.Text, "<script>")
+}
+
+func TestDecode(t *testing.T) {
+	r, err := emails.New()
+	require.NoError(t, err)
+
+	for _, name := range emails.Templates {
+		t.Run(name, func(t *testing.T) {
+			msg := emails.Fixtures()[name]
+			payload, err := emails.Payload(msg)
+			require.NoError(t, err)
+
+			decoded, err := emails.Decode(name, payload)
+			if name == "otp" {
+				// login codes are never stored
+				assert.Nil(t, payload)
+				require.ErrorIs(t, err, domain.ErrNotResendable)
+				return
+			}
+			require.NoError(t, err)
+
+			want, err := r.Render("ru", msg, "")
+			require.NoError(t, err)
+			got, err := r.Render("ru", decoded, "")
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestRenderer_Render_UnsubscribeLink(t *testing.T) {
+	r, err := emails.New()
+	require.NoError(t, err)
+	url := "https://example.com/unsubscribe?token=1.digest.sig"
+
+	got, err := r.Render("en", emails.Fixtures()["digest"], url)
+	require.NoError(t, err)
+
+	assert.Contains(t, got.HTML, `<a href="`+url+`">Unsubscribe from these emails</a>`)
+	assert.Contains(t, got.Text, "Unsubscribe from these emails: "+url)
+}
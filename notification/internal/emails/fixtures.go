@@ -0,0 +1,28 @@
+package emails
+
+import (
+	"FinanceTracker/notification/internal/domain"
+	"time"
+)
+
+// Fixtures returns sample data of every message type for previews and tests.
+func Fixtures() map[string]Message {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		moscow = time.UTC
+	}
+	prefs := domain.Preferences{Location: moscow, DigestFrequency: domain.DigestWeekly}
+	from, to := prefs.DigestPeriod(time.Date(2025, 1, 6, 6, 0, 0, 0, time.UTC))
+	charges := []domain.UpcomingCharge{
+		{UserID: 1, Service: "Яндекс Плюс", Amount: 39900, Currency: "RUB", ChargeAt: from.Add(34 * time.Hour)},
+		{UserID: 1, Service: "Spotify", Amount: 1099, Currency: "USD", ChargeAt: from.Add(34 * time.Hour)},
+		{UserID: 1, Service: "iCloud", Amount: 14900, Currency: "RUB", ChargeAt: from.Add(100 * time.Hour)},
+	}
+
+	return map[string]Message{
+		"otp":        OTP{Code: "482913", ValidMinutes: 5},
+		"registered": Registered{Name: "Иван"},
+		"reminder":   Reminder{Name: "Иван", Reminder: domain.Reminder{Charge: charges[0], Day: from.AddDate(0, 0, 1), Today: from}},
+		"digest":     Digest{Name: "Иван", Digest: domain.BuildDigest(1, domain.DigestWeekly, charges, from, to)},
+	}
+}
@@ -0,0 +1,99 @@
+package emails
+
+import (
+	"FinanceTracker/notification/internal/domain"
+	"encoding/json"
+	"fmt"
+)
+
+// Templates lists the message templates parsed by New.
+var Templates = []string{"otp", "registered", "reminder", "digest"}
+
+// OTP carries a login code.
+type OTP struct {
+	Code         string
+	ValidMinutes int
+}
+
+func (OTP) Template() string   { return "otp" }
+func (OTP) SubjectKey() string { return "otp.subject" }
+func (OTP) Type() string       { return domain.EmailSecurity }
+func (OTP) secret()            {}
+
+// Registered welcomes a new user.
+type Registered struct {
+	Name string
+}
+
+func (Registered) Template() string   { return "registered" }
+func (Registered) SubjectKey() string { return "registered.subject" }
+func (Registered) Type() string       { return domain.EmailTransactional }
+
+// Reminder tells about an upcoming charge.
+type Reminder struct {
+	Name     string
+	Reminder domain.Reminder
+}
+
+func (Reminder) Template() string   { return "reminder" }
+func (Reminder) SubjectKey() string { return "reminder.subject" }
+func (Reminder) Type() string       { return domain.EmailReminder }
+
+func (r Reminder) Unsubscribe() (int, string) {
+	return r.Reminder.Charge.UserID, domain.EmailCategoryReminders
+}
+
+// Digest lists the upcoming charges of a user.
+type Digest struct {
+	Name   string
+	Digest domain.Digest
+}
+
+func (Digest) Template() string { return "digest" }
+func (Digest) Type() string     { return domain.EmailDigest }
+
+func (d Digest) Unsubscribe() (int, string) {
+	return d.Digest.UserID, domain.EmailCategoryDigest
+}
+
+func (d Digest) SubjectKey() string {
+	return "digest.subject." + d.Digest.Frequency
+}
+
+// secret is implemented by messages that must never be stored.
+type secret interface {
+	secret()
+}
+
+// Payload returns the data of msg for the delivery log, or nil if msg
+// carries a secret.
+func Payload(msg Message) ([]byte, error) {
+	if _, ok := msg.(secret); ok {
+		return nil, nil
+	}
+	return json.Marshal(msg)
+}
+
+// decoders restore the messages that are kept in the delivery log.
+var decoders = map[string]func(payload []byte) (Message, error){
+	"registered": decode[Registered],
+	"reminder":   decode[Reminder],
+	"digest":     decode[Digest],
+}
+
+// Decode restores a message of the template from its payload.
+func Decode(template string, payload []byte) (Message, error) {
+	d, ok := decoders[template]
+	if !ok || payload == nil {
+		return nil, domain.ErrNotResendable
+	}
+	return d(payload)
+}
+
+func decode[T Message](payload []byte) (Message, error) {
+	var msg T
+	if err := json.Unmarshal(payload, &msg); err != nil {
+		return nil, fmt.Errorf("failed to decode %s payload: %w", msg.Template(), err)
+	}
+	return msg, nil
+}
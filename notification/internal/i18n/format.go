@@ -0,0 +1,82 @@
+package i18n
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// nbsp keeps amounts and dates from wrapping.
+const nbsp = '\u00a0'
+
+var currencySymbols = map[string]string{
+	"RUB": "₽",
+	"USD": "$",
+	"EUR": "€",
+}
+
+var (
+	ruMonths   = [...]string{"января", "февраля", "марта", "апреля", "мая", "июня", "июля", "августа", "сентября", "октября", "ноября", "декабря"}
+	ruWeekdays = [...]string{"воскресенье", "понедельник", "вторник", "среда", "четверг", "пятница", "суббота"}
+)
+
+// FormatMoney formats an amount in minor units: 1 299,00 ₽ in Russian,
+// ₽1,299.00 in English.
+func FormatMoney(l Locale, amount int64, currency string) string {
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	symbol, ok := currencySymbols[currency]
+	if !ok {
+		symbol = currency
+	}
+
+	switch l {
+	case Ru:
+		units := group(amount/100, nbsp)
+		return fmt.Sprintf("%s%s,%02d%c%s", sign, units, amount%100, nbsp, symbol)
+	default:
+		units := group(amount/100, ',')
+		if !ok {
+			// codes read better apart from the number
+			symbol += string(nbsp)
+		}
+		return fmt.Sprintf("%s%s%s.%02d", sign, symbol, units, amount%100)
+	}
+}
+
+// group separates thousands of n with sep.
+func group(n int64, sep rune) string {
+	digits := strconv.FormatInt(n, 10)
+	var b strings.Builder
+	for i, r := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteRune(sep)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// FormatDate formats a day with its weekday: "вторник, 7 января" or
+// "Tuesday, January 7".
+func FormatDate(l Locale, t time.Time) string {
+	switch l {
+	case Ru:
+		return ruWeekdays[t.Weekday()] + ", " + FormatDayMonth(l, t)
+	default:
+		return t.Weekday().String() + ", " + FormatDayMonth(l, t)
+	}
+}
+
+// FormatDayMonth formats a day without the year: "7 января" or "January 7".
+func FormatDayMonth(l Locale, t time.Time) string {
+	switch l {
+	case Ru:
+		return fmt.Sprintf("%d%c%s", t.Day(), nbsp, ruMonths[t.Month()-1])
+	default:
+		return fmt.Sprintf("%s%c%d", t.Month(), nbsp, t.Day())
+	}
+}
@@ -0,0 +1,139 @@
+// Package i18n holds the message catalog and the locale rules of the
+// notification texts.
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"maps"
+	"slices"
+	"strings"
+)
+
+type Locale string
+
+const (
+	Ru Locale = "ru"
+	En Locale = "en"
+
+	// Default is used for users who have never chosen a language.
+	Default = Ru
+)
+
+// Locales lists the supported locales.
+var Locales = []Locale{Ru, En}
+
+// Parse returns the supported locale of a language tag such as "en-US", or
+// Default.
+func Parse(tag string) Locale {
+	primary, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+	if l := Locale(primary); slices.Contains(Locales, l) {
+		return l
+	}
+	return Default
+}
+
+// entry is a plain text or a set of plural forms by CLDR category.
+type entry struct {
+	text  string
+	forms map[string]string
+}
+
+func (e *entry) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &e.text); err == nil {
+		return nil
+	}
+	return json.Unmarshal(data, &e.forms)
+}
+
+// Catalog translates message keys.
+type Catalog struct {
+	messages map[Locale]map[string]entry
+}
+
+// Load reads i18n/<locale>.json of every locale from fsys. Every locale must
+// have the same keys and every plural form its rules can select.
+func Load(fsys fs.FS) (*Catalog, error) {
+	c := &Catalog{messages: make(map[Locale]map[string]entry)}
+	for _, l := range Locales {
+		data, err := fs.ReadFile(fsys, "i18n/"+string(l)+".json")
+		if err != nil {
+			return nil, err
+		}
+		var messages map[string]entry
+		if err := json.Unmarshal(data, &messages); err != nil {
+			return nil, fmt.Errorf("failed to parse %s catalog: %w", l, err)
+		}
+		for key, e := range messages {
+			if e.forms == nil {
+				continue
+			}
+			for _, category := range categories[l] {
+				if _, ok := e.forms[category]; !ok {
+					return nil, fmt.Errorf("%s: %q has no %q form", l, key, category)
+				}
+			}
+		}
+		c.messages[l] = messages
+	}
+
+	keys := slices.Sorted(maps.Keys(c.messages[Default]))
+	for _, l := range Locales {
+		if other := slices.Sorted(maps.Keys(c.messages[l])); !slices.Equal(keys, other) {
+			return nil, fmt.Errorf("catalogs of %s and %s have different keys", Default, l)
+		}
+	}
+	return c, nil
+}
+
+// T returns the text of key formatted with args. A missing key is returned
+// as is, so that it is visible in the message instead of breaking it.
+func (c *Catalog) T(l Locale, key string, args ...any) string {
+	e, ok := c.messages[l][key]
+	if !ok || e.forms != nil {
+		return key
+	}
+	if len(args) == 0 {
+		return e.text
+	}
+	return fmt.Sprintf(e.text, args...)
+}
+
+// Plural returns the form of key for n formatted with n, e.g. "через 3 дня".
+func (c *Catalog) Plural(l Locale, key string, n int) string {
+	e, ok := c.messages[l][key]
+	if !ok || e.forms == nil {
+		return key
+	}
+	return fmt.Sprintf(e.forms[PluralCategory(l, n)], n)
+}
+
+// categories are the CLDR plural categories of integers in each locale.
+var categories = map[Locale][]string{
+	Ru: {"one", "few", "many"},
+	En: {"one", "other"},
+}
+
+// PluralCategory returns the CLDR plural category of the integer n.
+func PluralCategory(l Locale, n int) string {
+	if n < 0 {
+		n = -n
+	}
+	switch l {
+	case Ru:
+		switch mod10, mod100 := n%10, n%100; {
+		case mod10 == 1 && mod100 != 11:
+			return "one"
+		case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+			return "few"
+		default:
+			return "many"
+		}
+	default:
+		if n == 1 {
+			return "one"
+		}
+		return "other"
+	}
+}
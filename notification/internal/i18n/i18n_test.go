@@ -0,0 +1,69 @@
+package i18n_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"FinanceTracker/notification/internal/i18n"
+	"FinanceTracker/notification/templates"
+)
+
+func TestCatalog_Plural(t *testing.T) {
+	catalog, err := i18n.Load(templates.I18n)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name   string
+		locale i18n.Locale
+		n      int
+		want   string
+	}{
+		{name: "ru_one", locale: i18n.Ru, n: 1, want: "через 1 день"},
+		{name: "ru_few", locale: i18n.Ru, n: 3, want: "через 3 дня"},
+		{name: "ru_many", locale: i18n.Ru, n: 5, want: "через 5 дней"},
+		{name: "ru_teens", locale: i18n.Ru, n: 12, want: "через 12 дней"},
+		{name: "ru_twenty_one", locale: i18n.Ru, n: 21, want: "через 21 день"},
+		{name: "ru_hundred_eleven", locale: i18n.Ru, n: 111, want: "через 111 дней"},
+		{name: "en_one", locale: i18n.En, n: 1, want: "in 1 day"},
+		{name: "en_other", locale: i18n.En, n: 3, want: "in 3 days"},
+		{name: "en_twenty_one", locale: i18n.En, n: 21, want: "in 21 days"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, catalog.Plural(tc.locale, "days.in", tc.n))
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	date := time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{name: "money_ru", got: i18n.FormatMoney(i18n.Ru, 129999, "RUB"), want: "1\u00a0299,99\u00a0₽"},
+		{name: "money_en", got: i18n.FormatMoney(i18n.En, 129999, "USD"), want: "$1,299.99"},
+		{name: "money_en_unknown_currency", got: i18n.FormatMoney(i18n.En, 50000, "KZT"), want: "KZT\u00a0500.00"},
+		{name: "date_ru", got: i18n.FormatDate(i18n.Ru, date), want: "вторник, 7\u00a0января"},
+		{name: "date_en", got: i18n.FormatDate(i18n.En, date), want: "Tuesday, January\u00a07"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.got)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	assert.Equal(t, i18n.En, i18n.Parse("en-US"))
+	assert.Equal(t, i18n.Ru, i18n.Parse("ru"))
+	assert.Equal(t, i18n.Default, i18n.Parse(""))
+	assert.Equal(t, i18n.Default, i18n.Parse("de"))
+}
@@ -0,0 +1,109 @@
+package repo
+
+import (
+	"FinanceTracker/notification/internal/domain"
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+type UpcomingCharge struct {
+	SubscriptionID int       `db:"subscription_id"`
+	UserID         int       `db:"user_id"`
+	Service        string    `db:"service"`
+	Amount         int64     `db:"amount"`
+	Currency       string    `db:"currency"`
+	ChargeAt       time.Time `db:"charge_at"`
+}
+
+func (c UpcomingCharge) ToDomain() domain.UpcomingCharge {
+	return domain.UpcomingCharge{
+		SubscriptionID: c.SubscriptionID,
+		UserID:         c.UserID,
+		Service:        c.Service,
+		Amount:         c.Amount,
+		Currency:       c.Currency,
+		ChargeAt:       c.ChargeAt,
+	}
+}
+
+// chargesRepo keeps the next charge of every subscription.
+type chargesRepo struct {
+	storage *sqlx.DB
+	qb      sq.StatementBuilderType
+}
+
+func NewChargesRepo(storage *sqlx.DB) *chargesRepo {
+	return &chargesRepo{
+		storage: storage,
+		qb:      sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+// Schedule replaces the next charge of the subscription. A charge moved to
+// another time is reminded of again.
+func (r *chargesRepo) Schedule(ctx context.Context, charge domain.UpcomingCharge) error {
+	query, args := r.qb.Insert("upcoming_charges").
+		Columns("subscription_id", "user_id", "service", "amount", "currency", "charge_at").
+		Values(charge.SubscriptionID, charge.UserID, charge.Service, charge.Amount, charge.Currency, charge.ChargeAt).
+		Suffix(`ON CONFLICT (subscription_id) DO UPDATE SET
+			user_id = EXCLUDED.user_id,
+			service = EXCLUDED.service,
+			amount = EXCLUDED.amount,
+			currency = EXCLUDED.currency,
+			charge_at = EXCLUDED.charge_at,
+			reminded_at = CASE WHEN upcoming_charges.charge_at = EXCLUDED.charge_at THEN upcoming_charges.reminded_at END,
+			updated_at = now()`).
+		MustSql()
+
+	_, err := r.storage.ExecContext(ctx, query, args...)
+	return err
+}
+
+// Cancel drops the charge of a cancelled subscription.
+func (r *chargesRepo) Cancel(ctx context.Context, subscriptionID int) error {
+	query, args := r.qb.Delete("upcoming_charges").
+		Where(sq.Eq{"subscription_id": subscriptionID}).
+		MustSql()
+
+	_, err := r.storage.ExecContext(ctx, query, args...)
+	return err
+}
+
+// MarkReminded claims the reminder of the charge, only the first call for a
+// charge returns true.
+func (r *chargesRepo) MarkReminded(ctx context.Context, charge domain.UpcomingCharge) (bool, error) {
+	query, args := r.qb.Update("upcoming_charges").
+		Set("reminded_at", sq.Expr("now()")).
+		Where(sq.Eq{"subscription_id": charge.SubscriptionID, "charge_at": charge.ChargeAt, "reminded_at": nil}).
+		MustSql()
+
+	res, err := r.storage.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+// Upcoming returns the charges in [from, to) of all users.
+func (r *chargesRepo) Upcoming(ctx context.Context, from, to time.Time) ([]domain.UpcomingCharge, error) {
+	query, args := r.qb.Select("subscription_id", "user_id", "service", "amount", "currency", "charge_at").
+		From("upcoming_charges").
+		Where(sq.GtOrEq{"charge_at": from}).
+		Where(sq.Lt{"charge_at": to}).
+		OrderBy("charge_at").
+		MustSql()
+
+	var rows []UpcomingCharge
+	if err := r.storage.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, err
+	}
+	res := make([]domain.UpcomingCharge, len(rows))
+	for i, row := range rows {
+		res[i] = row.ToDomain()
+	}
+	return res, nil
+}
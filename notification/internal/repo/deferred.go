@@ -0,0 +1,82 @@
+package repo
+
+import (
+	"FinanceTracker/notification/internal/domain"
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type DeferredMessage struct {
+	ID        int64          `db:"message_id"`
+	DedupKey  string         `db:"dedup_key"`
+	UserID    int            `db:"user_id"`
+	Topic     string         `db:"topic"`
+	Channels  pq.StringArray `db:"channels"`
+	Payload   []byte         `db:"payload"`
+	DeliverAt time.Time      `db:"deliver_at"`
+}
+
+func (m DeferredMessage) ToDomain() domain.DeferredMessage {
+	return domain.DeferredMessage{
+		ID:        m.ID,
+		DedupKey:  m.DedupKey,
+		UserID:    m.UserID,
+		Topic:     m.Topic,
+		Channels:  m.Channels,
+		Payload:   m.Payload,
+		DeliverAt: m.DeliverAt,
+	}
+}
+
+type deferredRepo struct {
+	storage *sqlx.DB
+	qb      sq.StatementBuilderType
+}
+
+func NewDeferredRepo(storage *sqlx.DB) *deferredRepo {
+	return &deferredRepo{
+		storage: storage,
+		qb:      sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+// Save stores a message once, a redelivered event is ignored.
+func (r *deferredRepo) Save(ctx context.Context, msg domain.DeferredMessage) error {
+	query, args := r.qb.Insert("deferred_messages").
+		Columns("dedup_key", "user_id", "topic", "channels", "payload", "deliver_at").
+		Values(msg.DedupKey, msg.UserID, msg.Topic, pq.StringArray(msg.Channels), msg.Payload, msg.DeliverAt).
+		Suffix("ON CONFLICT (dedup_key) DO NOTHING").
+		MustSql()
+
+	_, err := r.storage.ExecContext(ctx, query, args...)
+	return err
+}
+
+// TakeDue removes and returns up to limit messages due at now. Replicas
+// take different messages, and a message is taken once even if sending it
+// fails afterwards.
+func (r *deferredRepo) TakeDue(ctx context.Context, now time.Time, limit int) ([]domain.DeferredMessage, error) {
+	due, args := r.qb.Select("message_id").
+		From("deferred_messages").
+		Where(sq.LtOrEq{"deliver_at": now}).
+		OrderBy("deliver_at").
+		Limit(uint64(limit)).
+		Suffix("FOR UPDATE SKIP LOCKED").
+		MustSql()
+	query := `DELETE FROM deferred_messages WHERE message_id IN (` + due + `)
+		RETURNING message_id, dedup_key, user_id, topic, channels, payload, deliver_at`
+
+	var rows []DeferredMessage
+	if err := r.storage.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, err
+	}
+	res := make([]domain.DeferredMessage, len(rows))
+	for i, row := range rows {
+		res[i] = row.ToDomain()
+	}
+	return res, nil
+}
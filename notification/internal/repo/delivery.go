@@ -0,0 +1,158 @@
+package repo
+
+import (
+	"FinanceTracker/notification/internal/domain"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+type Delivery struct {
+	ID        int64          `db:"delivery_id"`
+	Email     string         `db:"email"`
+	Type      string         `db:"type"`
+	Template  string         `db:"template"`
+	Locale    string         `db:"locale"`
+	Subject   string         `db:"subject"`
+	Payload   []byte         `db:"payload"`
+	Status    string         `db:"status"`
+	Attempts  int            `db:"attempts"`
+	LastError sql.NullString `db:"last_error"`
+	CreatedAt time.Time      `db:"created_at"`
+	UpdatedAt time.Time      `db:"updated_at"`
+	SentAt    sql.NullTime   `db:"sent_at"`
+}
+
+func (d Delivery) ToDomain() domain.Delivery {
+	res := domain.Delivery{
+		ID:        d.ID,
+		Email:     d.Email,
+		Type:      d.Type,
+		Template:  d.Template,
+		Locale:    d.Locale,
+		Subject:   d.Subject,
+		Payload:   d.Payload,
+		Status:    d.Status,
+		Attempts:  d.Attempts,
+		LastError: d.LastError.String,
+		CreatedAt: d.CreatedAt,
+		UpdatedAt: d.UpdatedAt,
+	}
+	if d.SentAt.Valid {
+		res.SentAt = &d.SentAt.Time
+	}
+	return res
+}
+
+var deliveryColumns = []string{"delivery_id", "email", "type", "template", "locale", "subject", "payload",
+	"status", "attempts", "last_error", "created_at", "updated_at", "sent_at"}
+
+type deliveryRepo struct {
+	storage *sqlx.DB
+	qb      sq.StatementBuilderType
+}
+
+func NewDeliveryRepo(storage *sqlx.DB) *deliveryRepo {
+	return &deliveryRepo{
+		storage: storage,
+		qb:      sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+// Create adds a queued delivery and returns its ID.
+func (r *deliveryRepo) Create(ctx context.Context, d domain.Delivery) (int64, error) {
+	// lib/pq sends []byte as bytea, which jsonb does not accept
+	payload := sql.NullString{String: string(d.Payload), Valid: d.Payload != nil}
+	query, args := r.qb.Insert("email_deliveries").
+		Columns("email", "type", "template", "locale", "subject", "payload").
+		Values(d.Email, d.Type, d.Template, d.Locale, d.Subject, payload).
+		Suffix("RETURNING delivery_id").
+		MustSql()
+
+	var id int64
+	err := r.storage.GetContext(ctx, &id, query, args...)
+	return id, err
+}
+
+// RecordAttempt counts an attempt to send the email and sets its status. The
+// last error is kept after a successful retry.
+func (r *deliveryRepo) RecordAttempt(ctx context.Context, id int64, status, lastError string) error {
+	q := r.qb.Update("email_deliveries").
+		Set("status", status).
+		Set("attempts", sq.Expr("attempts + 1")).
+		Set("updated_at", sq.Expr("now()")).
+		Where(sq.Eq{"delivery_id": id})
+	if lastError != "" {
+		q = q.Set("last_error", lastError)
+	}
+	if status == domain.DeliverySent {
+		q = q.Set("sent_at", sq.Expr("now()"))
+	}
+	query, args := q.MustSql()
+
+	_, err := r.storage.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (r *deliveryRepo) Get(ctx context.Context, id int64) (domain.Delivery, error) {
+	query, args := r.qb.Select(deliveryColumns...).
+		From("email_deliveries").
+		Where(sq.Eq{"delivery_id": id}).
+		MustSql()
+
+	var row Delivery
+	err := r.storage.GetContext(ctx, &row, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Delivery{}, domain.ErrDeliveryNotFound
+	}
+	if err != nil {
+		return domain.Delivery{}, err
+	}
+	return row.ToDomain(), nil
+}
+
+// Search returns up to limit deliveries matching the filter older than
+// beforeID, newest first. A zero beforeID starts from the newest one.
+func (r *deliveryRepo) Search(ctx context.Context, filter domain.DeliveryFilter, beforeID int64, limit int) ([]domain.Delivery, error) {
+	q := r.qb.Select(deliveryColumns...).
+		From("email_deliveries").
+		OrderBy("delivery_id DESC").
+		Limit(uint64(limit))
+	if beforeID > 0 {
+		q = q.Where(sq.Lt{"delivery_id": beforeID})
+	}
+	if filter.Email != "" {
+		q = q.Where("lower(email) = lower(?)", filter.Email)
+	}
+	if filter.Type != "" {
+		q = q.Where(sq.Eq{"type": filter.Type})
+	}
+	if filter.Template != "" {
+		q = q.Where(sq.Eq{"template": filter.Template})
+	}
+	if filter.Status != "" {
+		q = q.Where(sq.Eq{"status": filter.Status})
+	}
+	if !filter.CreatedFrom.IsZero() {
+		q = q.Where(sq.GtOrEq{"created_at": filter.CreatedFrom})
+	}
+	if !filter.CreatedTo.IsZero() {
+		q = q.Where(sq.Lt{"created_at": filter.CreatedTo})
+	}
+	query, args := q.MustSql()
+
+	var rows []Delivery
+	if err := r.storage.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.Delivery, len(rows))
+	for i, row := range rows {
+		res[i] = row.ToDomain()
+	}
+	return res, nil
+}
@@ -0,0 +1,143 @@
+package repo
+
+import (
+	"FinanceTracker/notification/internal/domain"
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+type Notification struct {
+	ID        int64          `db:"notification_id"`
+	UserID    int            `db:"user_id"`
+	Type      string         `db:"type"`
+	Title     string         `db:"title"`
+	Body      string         `db:"body"`
+	Link      sql.NullString `db:"link"`
+	ReadAt    sql.NullTime   `db:"read_at"`
+	CreatedAt time.Time      `db:"created_at"`
+}
+
+func (n Notification) ToDomain() domain.Notification {
+	res := domain.Notification{
+		ID:        n.ID,
+		UserID:    n.UserID,
+		Type:      n.Type,
+		Title:     n.Title,
+		Body:      n.Body,
+		Link:      n.Link.String,
+		CreatedAt: n.CreatedAt,
+	}
+	if n.ReadAt.Valid {
+		res.ReadAt = &n.ReadAt.Time
+	}
+	return res
+}
+
+var notificationColumns = []string{"notification_id", "user_id", "type", "title", "body", "link", "read_at", "created_at"}
+
+type notificationRepo struct {
+	storage *sqlx.DB
+	qb      sq.StatementBuilderType
+}
+
+func NewNotificationRepo(storage *sqlx.DB) *notificationRepo {
+	qb := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	return &notificationRepo{
+		storage: storage,
+		qb:      qb,
+	}
+}
+
+// Create inserts the notification unless one with the same dedup key exists.
+func (r *notificationRepo) Create(ctx context.Context, n domain.Notification) error {
+	query, args := r.qb.Insert("notifications").
+		Columns("user_id", "type", "title", "body", "link", "dedup_key").
+		Values(n.UserID, n.Type, n.Title, n.Body, nullString(n.Link), nullString(n.DedupKey)).
+		Suffix("ON CONFLICT (dedup_key) DO NOTHING").
+		MustSql()
+
+	_, err := r.storage.ExecContext(ctx, query, args...)
+	return err
+}
+
+// List returns up to limit notifications of a user older than beforeID, newest first.
+// A zero beforeID starts from the newest one.
+func (r *notificationRepo) List(ctx context.Context, userID int, beforeID int64, limit int, unreadOnly bool) ([]domain.Notification, error) {
+	q := r.qb.Select(notificationColumns...).
+		From("notifications").
+		Where(sq.Eq{"user_id": userID}).
+		OrderBy("notification_id DESC").
+		Limit(uint64(limit))
+	if beforeID > 0 {
+		q = q.Where(sq.Lt{"notification_id": beforeID})
+	}
+	if unreadOnly {
+		q = q.Where(sq.Eq{"read_at": nil})
+	}
+	query, args := q.MustSql()
+
+	var rows []Notification
+	if err := r.storage.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.Notification, len(rows))
+	for i, row := range rows {
+		res[i] = row.ToDomain()
+	}
+	return res, nil
+}
+
+// MarkRead sets read_at of a user's notification, keeping the first read time.
+func (r *notificationRepo) MarkRead(ctx context.Context, userID int, notificationID int64) (domain.Notification, error) {
+	query, args := r.qb.Update("notifications").
+		Set("read_at", sq.Expr("COALESCE(read_at, now())")).
+		Where(sq.Eq{"notification_id": notificationID, "user_id": userID}).
+		Suffix("RETURNING " + strings.Join(notificationColumns, ", ")).
+		MustSql()
+
+	var row Notification
+	err := r.storage.GetContext(ctx, &row, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Notification{}, domain.ErrNotificationNotFound
+	}
+	if err != nil {
+		return domain.Notification{}, err
+	}
+	return row.ToDomain(), nil
+}
+
+// MarkAllRead marks all unread notifications of a user and returns their number.
+func (r *notificationRepo) MarkAllRead(ctx context.Context, userID int) (int64, error) {
+	query, args := r.qb.Update("notifications").
+		Set("read_at", sq.Expr("now()")).
+		Where(sq.Eq{"user_id": userID, "read_at": nil}).
+		MustSql()
+
+	res, err := r.storage.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *notificationRepo) CountUnread(ctx context.Context, userID int) (int64, error) {
+	query, args := r.qb.Select("count(*)").
+		From("notifications").
+		Where(sq.Eq{"user_id": userID, "read_at": nil}).
+		MustSql()
+
+	var count int64
+	err := r.storage.GetContext(ctx, &count, query, args...)
+	return count, err
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
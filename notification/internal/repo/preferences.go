@@ -0,0 +1,135 @@
+package repo
+
+import (
+	"FinanceTracker/notification/internal/domain"
+	pb "FinanceTracker/notification/pkg/api/profile"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+type cachedPreferences struct {
+	prefs     domain.Preferences
+	expiresAt time.Time
+}
+
+// preferencesRepo reads preferences from the profile service and keeps them
+// for ttl, so that a burst of messages to a user costs one call.
+type preferencesRepo struct {
+	client pb.ProfileServiceClient
+	ttl    time.Duration
+
+	mu      sync.Mutex
+	entries map[int]cachedPreferences
+	now     func() time.Time
+}
+
+func NewPreferencesRepo(client pb.ProfileServiceClient, ttl time.Duration) *preferencesRepo {
+	return &preferencesRepo{
+		client:  client,
+		ttl:     ttl,
+		entries: make(map[int]cachedPreferences),
+		now:     time.Now,
+	}
+}
+
+func (r *preferencesRepo) Get(ctx context.Context, userID int) (domain.Preferences, error) {
+	now := r.now()
+	r.mu.Lock()
+	e, ok := r.entries[userID]
+	r.mu.Unlock()
+	if ok && now.Before(e.expiresAt) {
+		return e.prefs, nil
+	}
+
+	resp, err := r.client.GetNotificationPreferences(ctx, &pb.GetNotificationPreferencesRequest{UserId: int64(userID)})
+	if err != nil {
+		return domain.Preferences{}, err
+	}
+	prefs, err := preferencesFromProto(resp)
+	if err != nil {
+		return domain.Preferences{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, e := range r.entries {
+		if !now.Before(e.expiresAt) {
+			delete(r.entries, id)
+		}
+	}
+	r.entries[userID] = cachedPreferences{prefs: prefs, expiresAt: now.Add(r.ttl)}
+	return prefs, nil
+}
+
+// EnableChannel turns a channel on in the profile service and caches the
+// result, so that the next message already goes to it.
+func (r *preferencesRepo) EnableChannel(ctx context.Context, userID int, channel string) error {
+	resp, err := r.client.EnableChannel(ctx, &pb.EnableChannelRequest{UserId: int64(userID), Channel: channel})
+	if err != nil {
+		return err
+	}
+	prefs, err := preferencesFromProto(resp)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries[userID] = cachedPreferences{prefs: prefs, expiresAt: r.now().Add(r.ttl)}
+	return nil
+}
+
+func preferencesFromProto(resp *pb.NotificationPreferences) (domain.Preferences, error) {
+	loc, err := time.LoadLocation(resp.Timezone)
+	if err != nil {
+		return domain.Preferences{}, fmt.Errorf("invalid timezone %q: %w", resp.Timezone, err)
+	}
+	prefs := domain.Preferences{
+		Channels:          resp.Channels,
+		ReminderLeadDays:  int(resp.ReminderLeadDays),
+		Location:          loc,
+		Mode:              resp.Mode,
+		DigestFrequency:   resp.DigestFrequency,
+		DigestDay:         time.Monday,
+		EmailUnsubscribed: resp.EmailUnsubscribed,
+	}
+	if resp.DigestDay != "" {
+		day, err := parseWeekday(resp.DigestDay)
+		if err != nil {
+			return domain.Preferences{}, err
+		}
+		prefs.DigestDay = day
+	}
+	if resp.QuietHoursStart != "" && resp.QuietHoursEnd != "" {
+		start, err := parseClock(resp.QuietHoursStart)
+		if err != nil {
+			return domain.Preferences{}, err
+		}
+		end, err := parseClock(resp.QuietHoursEnd)
+		if err != nil {
+			return domain.Preferences{}, err
+		}
+		prefs.QuietHours = &domain.QuietHours{Start: start, End: end}
+	}
+	return prefs, nil
+}
+
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid clock %q: %w", s, err)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func parseWeekday(s string) (time.Weekday, error) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(s, d.String()) {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid weekday %q", s)
+}
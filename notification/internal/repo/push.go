@@ -0,0 +1,104 @@
+package repo
+
+import (
+	"FinanceTracker/notification/internal/domain"
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+type PushSubscription struct {
+	ID        int64     `db:"subscription_id"`
+	UserID    int       `db:"user_id"`
+	Endpoint  string    `db:"endpoint"`
+	P256dh    string    `db:"p256dh"`
+	Auth      string    `db:"auth"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (s PushSubscription) ToDomain() domain.PushSubscription {
+	return domain.PushSubscription{
+		ID:        s.ID,
+		UserID:    s.UserID,
+		Endpoint:  s.Endpoint,
+		P256dh:    s.P256dh,
+		Auth:      s.Auth,
+		CreatedAt: s.CreatedAt,
+	}
+}
+
+type pushRepo struct {
+	storage *sqlx.DB
+	qb      sq.StatementBuilderType
+}
+
+func NewPushRepo(storage *sqlx.DB) *pushRepo {
+	return &pushRepo{
+		storage: storage,
+		qb:      sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+// Save stores the subscription. An endpoint belongs to the last user who
+// registered it, as a browser is shared after signing out.
+func (r *pushRepo) Save(ctx context.Context, sub domain.PushSubscription) (domain.PushSubscription, error) {
+	query, args := r.qb.Insert("push_subscriptions").
+		Columns("user_id", "endpoint", "p256dh", "auth").
+		Values(sub.UserID, sub.Endpoint, sub.P256dh, sub.Auth).
+		Suffix(`ON CONFLICT (endpoint) DO UPDATE SET
+			user_id = EXCLUDED.user_id,
+			p256dh = EXCLUDED.p256dh,
+			auth = EXCLUDED.auth
+			RETURNING subscription_id, user_id, endpoint, p256dh, auth, created_at`).
+		MustSql()
+
+	var row PushSubscription
+	if err := r.storage.GetContext(ctx, &row, query, args...); err != nil {
+		return domain.PushSubscription{}, err
+	}
+	return row.ToDomain(), nil
+}
+
+func (r *pushRepo) ListByUser(ctx context.Context, userID int) ([]domain.PushSubscription, error) {
+	query, args := r.qb.Select("subscription_id", "user_id", "endpoint", "p256dh", "auth", "created_at").
+		From("push_subscriptions").
+		Where(sq.Eq{"user_id": userID}).
+		OrderBy("subscription_id").
+		MustSql()
+
+	var rows []PushSubscription
+	if err := r.storage.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, err
+	}
+	res := make([]domain.PushSubscription, len(rows))
+	for i, row := range rows {
+		res[i] = row.ToDomain()
+	}
+	return res, nil
+}
+
+// Delete removes a subscription of a user and reports whether it existed.
+func (r *pushRepo) Delete(ctx context.Context, userID int, endpoint string) (bool, error) {
+	query, args := r.qb.Delete("push_subscriptions").
+		Where(sq.Eq{"user_id": userID, "endpoint": endpoint}).
+		MustSql()
+
+	res, err := r.storage.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+	aff, err := res.RowsAffected()
+	return aff > 0, err
+}
+
+// DeleteByID removes a subscription the push service reported as gone.
+func (r *pushRepo) DeleteByID(ctx context.Context, subscriptionID int64) error {
+	query, args := r.qb.Delete("push_subscriptions").
+		Where(sq.Eq{"subscription_id": subscriptionID}).
+		MustSql()
+
+	_, err := r.storage.ExecContext(ctx, query, args...)
+	return err
+}
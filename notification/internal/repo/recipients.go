@@ -0,0 +1,24 @@
+package repo
+
+import (
+	"FinanceTracker/notification/internal/domain"
+	pb "FinanceTracker/notification/pkg/api/profile"
+	"context"
+)
+
+// recipientsRepo reads email addresses and languages from the profile service.
+type recipientsRepo struct {
+	client pb.ProfileServiceClient
+}
+
+func NewRecipientsRepo(client pb.ProfileServiceClient) *recipientsRepo {
+	return &recipientsRepo{client: client}
+}
+
+func (r *recipientsRepo) Recipient(ctx context.Context, userID int) (domain.Recipient, error) {
+	resp, err := r.client.GetProfile(ctx, &pb.GetProfileRequest{UserId: int64(userID)})
+	if err != nil {
+		return domain.Recipient{}, err
+	}
+	return domain.Recipient{Email: resp.Email, Name: resp.FullName, Locale: resp.Locale}, nil
+}
@@ -0,0 +1,42 @@
+package repo
+
+import (
+	"FinanceTracker/notification/internal/domain"
+	pb "FinanceTracker/notification/pkg/api/profile"
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// telegramRepo reads and creates Telegram links in the profile service.
+type telegramRepo struct {
+	client pb.ProfileServiceClient
+}
+
+func NewTelegramRepo(client pb.ProfileServiceClient) *telegramRepo {
+	return &telegramRepo{client: client}
+}
+
+func (r *telegramRepo) GetChatID(ctx context.Context, userID int) (int64, error) {
+	resp, err := r.client.GetTelegramLink(ctx, &pb.GetTelegramLinkRequest{UserId: int64(userID)})
+	if err != nil {
+		return 0, err
+	}
+	if !resp.Linked {
+		return 0, domain.ErrTelegramNotLinked
+	}
+	return resp.ChatId, nil
+}
+
+func (r *telegramRepo) Link(ctx context.Context, code string, chatID int64, username string) error {
+	_, err := r.client.LinkTelegram(ctx, &pb.LinkTelegramRequest{
+		Code:     code,
+		ChatId:   chatID,
+		Username: username,
+	})
+	if status.Code(err) == codes.InvalidArgument {
+		return domain.ErrInvalidLinkCode
+	}
+	return err
+}
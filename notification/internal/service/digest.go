@@ -0,0 +1,103 @@
+package service
+
+import (
+	"FinanceTracker/notification/internal/domain"
+	"FinanceTracker/notification/pkg/logger"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ChargeSource lists the upcoming subscription charges of all users.
+type ChargeSource interface {
+	Upcoming(ctx context.Context, from, to time.Time) ([]domain.UpcomingCharge, error)
+}
+
+type DigestPreferences interface {
+	Get(ctx context.Context, userID int) (domain.Preferences, error)
+}
+
+// Recipients resolves the address, name and language a digest is sent in.
+type Recipients interface {
+	Recipient(ctx context.Context, userID int) (domain.Recipient, error)
+}
+
+type DigestMailer interface {
+	SendDigest(ctx context.Context, to domain.Recipient, digest domain.Digest) error
+}
+
+type digestService struct {
+	charges    ChargeSource
+	prefs      DigestPreferences
+	recipients Recipients
+	mailer     DigestMailer
+	now        func() time.Time
+}
+
+func NewDigestService(charges ChargeSource, prefs DigestPreferences, recipients Recipients, mailer DigestMailer) *digestService {
+	return &digestService{
+		charges:    charges,
+		prefs:      prefs,
+		recipients: recipients,
+		mailer:     mailer,
+		now:        time.Now,
+	}
+}
+
+// Run sends the due digests at the start of every hour until ctx is done.
+// Only one replica may run it, otherwise users get a digest per replica.
+func (s *digestService) Run(ctx context.Context) {
+	for {
+		next := s.now().Truncate(time.Hour).Add(time.Hour)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Until(next)):
+		}
+
+		if err := s.SendDue(ctx, next); err != nil {
+			logger.Error(ctx, "failed to send digests", "err", err)
+		}
+	}
+}
+
+// SendDue sends a digest to every user with charges whose digest is due at
+// now. Users with nothing to pay get no email.
+func (s *digestService) SendDue(ctx context.Context, now time.Time) error {
+	// a weekly digest covers up to 7 local days starting at most a day before now
+	charges, err := s.charges.Upcoming(ctx, now.Add(-24*time.Hour), now.AddDate(0, 0, 8))
+	if err != nil {
+		return fmt.Errorf("failed to list upcoming charges: %w", err)
+	}
+
+	var errs []error
+	for userID, userCharges := range domain.GroupChargesByUser(charges) {
+		if err := s.sendDigest(ctx, now, userID, userCharges); err != nil {
+			errs = append(errs, fmt.Errorf("user %d: %w", userID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *digestService) sendDigest(ctx context.Context, now time.Time, userID int, charges []domain.UpcomingCharge) error {
+	prefs, err := s.prefs.Get(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get preferences: %w", err)
+	}
+	if !prefs.DigestDue(now) {
+		return nil
+	}
+
+	from, to := prefs.DigestPeriod(now)
+	digest := domain.BuildDigest(userID, prefs.DigestFrequency, charges, from, to)
+	if digest.Empty() {
+		return nil
+	}
+
+	recipient, err := s.recipients.Recipient(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get recipient: %w", err)
+	}
+	return s.mailer.SendDigest(ctx, recipient, digest)
+}
@@ -0,0 +1,65 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"FinanceTracker/notification/internal/domain"
+	"FinanceTracker/notification/internal/service"
+	smocks "FinanceTracker/notification/internal/service/mocks"
+)
+
+func TestDigestService_SendDue(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+	now := time.Date(2025, 1, 6, 6, 0, 0, 0, time.UTC) // Monday 09:00 in Moscow
+	weekly := domain.Preferences{
+		Channels:        []string{domain.ChannelEmail},
+		Location:        moscow,
+		Mode:            domain.ModeDigest,
+		DigestFrequency: domain.DigestWeekly,
+		DigestDay:       time.Monday,
+	}
+	immediate := weekly
+	immediate.Mode = domain.ModeImmediate
+
+	charges := []domain.UpcomingCharge{
+		{UserID: 1, Service: "spotify", Amount: 1099, Currency: "USD", ChargeAt: now.Add(24 * time.Hour)},
+		{UserID: 1, Service: "icloud", Amount: 14900, Currency: "RUB", ChargeAt: now.Add(48 * time.Hour)},
+		{UserID: 2, Service: "music", Amount: 16900, Currency: "RUB", ChargeAt: now.Add(24 * time.Hour)},
+		{UserID: 3, Service: "vpn", Amount: 500, Currency: "USD", ChargeAt: now.Add(24 * time.Hour)},
+	}
+	mailErr := errors.New("smtp down")
+
+	source := smocks.NewMockChargeSource(t)
+	source.EXPECT().Upcoming(mock.Anything, now.Add(-24*time.Hour), now.AddDate(0, 0, 8)).Return(charges, nil)
+
+	prefs := smocks.NewMockDigestPreferences(t)
+	prefs.EXPECT().Get(mock.Anything, 1).Return(weekly, nil)
+	prefs.EXPECT().Get(mock.Anything, 2).Return(immediate, nil)
+	prefs.EXPECT().Get(mock.Anything, 3).Return(weekly, nil)
+
+	recipients := smocks.NewMockRecipients(t)
+	one := domain.Recipient{Email: "one@example.com", Name: "Иван", Locale: "ru"}
+	three := domain.Recipient{Email: "three@example.com", Locale: "en"}
+	recipients.EXPECT().Recipient(mock.Anything, 1).Return(one, nil)
+	recipients.EXPECT().Recipient(mock.Anything, 3).Return(three, nil)
+
+	mailer := smocks.NewMockDigestMailer(t)
+	mailer.EXPECT().SendDigest(mock.Anything, one, mock.MatchedBy(func(d domain.Digest) bool {
+		return len(d.Days) == 2 && len(d.Totals) == 2
+	})).Return(nil)
+	mailer.EXPECT().SendDigest(mock.Anything, three, mock.Anything).Return(mailErr)
+
+	svc := service.NewDigestService(source, prefs, recipients, mailer)
+	err = svc.SendDue(context.Background(), now)
+
+	// one failing user does not hold back the others
+	assert.ErrorIs(t, err, mailErr)
+}
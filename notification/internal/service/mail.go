@@ -1,12 +1,16 @@
 package service
 
 import (
-	"FinanceTracker/notification/internal/config"
+	"FinanceTracker/notification/internal/domain"
+	"FinanceTracker/notification/internal/emails"
 	"FinanceTracker/notification/pkg/logger"
-	"bytes"
+	"FinanceTracker/notification/pkg/mail"
+	"FinanceTracker/notification/pkg/metrics"
+	"FinanceTracker/notification/pkg/unsubscribe"
 	"context"
 	"fmt"
-	"html/template"
+	netmail "net/mail"
+	"net/url"
 	"time"
 
 	"gopkg.in/gomail.v2"
@@ -16,107 +20,255 @@ const (
 	DefaultTimeout = 10 * time.Second
 )
 
+// DeliveryRepo is the log of outbound emails.
+type DeliveryRepo interface {
+	Create(ctx context.Context, d domain.Delivery) (int64, error)
+	RecordAttempt(ctx context.Context, id int64, status, lastError string) error
+	Get(ctx context.Context, id int64) (domain.Delivery, error)
+	Search(ctx context.Context, filter domain.DeliveryFilter, beforeID int64, limit int) ([]domain.Delivery, error)
+}
+
+// RetryPolicy tells how often an email is attempted when the transport
+// fails transiently.
+type RetryPolicy struct {
+	MaxAttempts int
+	Backoff     time.Duration // before the second attempt, doubled before every next one
+}
+
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 3, Backoff: time.Second}
+
+// UnsubscribeLinks are put in mail users can opt out of (RFC 8058).
+type UnsubscribeLinks struct {
+	Signer   *unsubscribe.Signer
+	OneClick string // gateway endpoint mail clients POST to, in List-Unsubscribe
+	Page     string // web page asking to confirm, linked from the footer
+}
+
+// urls returns both links with the token of the user and category.
+func (l *UnsubscribeLinks) urls(userID int, category string) (oneClick, page string, err error) {
+	token := l.Signer.Token(int64(userID), category)
+	if oneClick, err = withToken(l.OneClick, token); err != nil {
+		return "", "", err
+	}
+	if page, err = withToken(l.Page, token); err != nil {
+		return "", "", err
+	}
+	return oneClick, page, nil
+}
+
+func withToken(rawURL, token string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid unsubscribe url: %w", err)
+	}
+	q := u.Query()
+	q.Set("token", token)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
 type mailService struct {
-	conf    config.SMTP
-	timeout time.Duration
+	from        string
+	transport   mail.Transport
+	renderer    *emails.Renderer
+	deliveries  DeliveryRepo
+	retry       RetryPolicy
+	unsubscribe *UnsubscribeLinks // nil leaves the links out
+	timeout     time.Duration
 }
 
-func NewMailService(smtpConf config.SMTP) *mailService {
+func NewMailService(from string, transport mail.Transport, renderer *emails.Renderer, deliveries DeliveryRepo, retry RetryPolicy, unsubscribe *UnsubscribeLinks) *mailService {
 	return &mailService{
-		conf:    smtpConf,
-		timeout: DefaultTimeout,
+		from:        from,
+		transport:   transport,
+		renderer:    renderer,
+		deliveries:  deliveries,
+		retry:       retry,
+		unsubscribe: unsubscribe,
+		timeout:     DefaultTimeout,
 	}
 }
 
-func (s *mailService) SendOTP(ctx context.Context, email, code string) error {
-	const (
-		subject     = "Код для входа в Finance Tracker"
-		teplatePath = "templates/otp.html"
-	)
+func (s *mailService) SendOTP(ctx context.Context, email, locale, code string, validFor time.Duration) error {
+	return s.send(ctx, email, locale, emails.OTP{Code: code, ValidMinutes: int(validFor.Round(time.Minute).Minutes())})
+}
 
-	mail := gomail.NewMessage()
-	mail.SetHeader("From", s.conf.User)
-	mail.SetHeader("To", email)
-	mail.SetHeader("Subject", subject)
+func (s *mailService) SendRegistered(ctx context.Context, email, locale, name string) error {
+	return s.send(ctx, email, locale, emails.Registered{Name: name})
+}
 
-	tmpl, err := template.ParseFiles(teplatePath)
+func (s *mailService) SendReminder(ctx context.Context, to domain.Recipient, reminder domain.Reminder) error {
+	return s.send(ctx, to.Email, to.Locale, emails.Reminder{Name: to.Name, Reminder: reminder})
+}
+
+func (s *mailService) SendDigest(ctx context.Context, to domain.Recipient, digest domain.Digest) error {
+	return s.send(ctx, to.Email, to.Locale, emails.Digest{Name: to.Name, Digest: digest})
+}
+
+// send renders msg in the locale with a plain text alternative, logs it
+// and delivers it.
+func (s *mailService) send(ctx context.Context, email, locale string, msg emails.Message) (err error) {
+	defer func() { metrics.ObserveEmail(msg.Template(), err) }()
+
+	rendered, headers, err := s.render(locale, msg)
+	if err != nil {
+		return err
+	}
+	payload, err := emails.Payload(msg)
 	if err != nil {
-		return fmt.Errorf("failed to parse email template: %w", err)
+		return err
 	}
 
-	var body bytes.Buffer
-	err = tmpl.Execute(&body, map[string]string{"Code": code})
+	// a login code must go out even if the log is down
+	id, err := s.deliveries.Create(ctx, domain.Delivery{
+		Email:    email,
+		Type:     msg.Type(),
+		Template: msg.Template(),
+		Locale:   locale,
+		Subject:  rendered.Subject,
+		Payload:  payload,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to execute email template: %w", err)
+		logger.Error(ctx, "failed to log email delivery", "template", msg.Template(), "err", err)
 	}
 
-	mail.SetBody("text/html", body.String())
+	if err := s.deliver(ctx, id, email, rendered, headers); err != nil {
+		return fmt.Errorf("failed to send %s email: %w", msg.Template(), err)
+	}
+	logger.Debug(ctx, "email sent", "template", msg.Template(), "locale", locale, "email", email, "deliveryID", id)
+	return nil
+}
 
-	d := gomail.NewDialer(s.conf.Host, s.conf.Port, s.conf.User, s.conf.Pass)
+// render renders msg with the unsubscribe link in the footer and returns the
+// List-Unsubscribe headers for mail users can opt out of.
+func (s *mailService) render(locale string, msg emails.Message) (emails.Rendered, map[string]string, error) {
+	u, ok := msg.(emails.Unsubscribable)
+	if !ok || s.unsubscribe == nil {
+		rendered, err := s.renderer.Render(locale, msg, "")
+		return rendered, nil, err
+	}
 
-	errChan := make(chan error, 1)
-	go func() {
-		errChan <- d.DialAndSend(mail)
-	}()
+	oneClick, page, err := s.unsubscribe.urls(u.Unsubscribe())
+	if err != nil {
+		return emails.Rendered{}, nil, err
+	}
+	rendered, err := s.renderer.Render(locale, msg, page)
+	headers := map[string]string{
+		"List-Unsubscribe":      "<" + oneClick + ">",
+		"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+	}
+	return rendered, headers, err
+}
 
-	ctx, cancel := context.WithTimeout(ctx, s.timeout)
-	defer cancel()
+// deliver sends the email, retrying transient failures of the transport, and
+// records every attempt in the log unless id is zero.
+func (s *mailService) deliver(ctx context.Context, id int64, email string, rendered emails.Rendered, headers map[string]string) error {
+	m := gomail.NewMessage()
+	m.SetHeader("From", s.from)
+	m.SetHeader("To", email)
+	m.SetHeader("Subject", rendered.Subject)
+	for name, value := range headers {
+		m.SetHeader(name, value)
+	}
+	m.SetBody("text/plain", rendered.Text)
+	m.AddAlternative("text/html", rendered.HTML)
 
-	select {
-	case <-ctx.Done():
-		return fmt.Errorf("sending email canceled or timed out: %w", ctx.Err())
-	case err := <-errChan:
+	backoff := s.retry.Backoff
+	for attempt := 1; ; attempt++ {
+		err := s.attempt(ctx, email, m)
+		retry := err != nil && mail.Temporary(err) && attempt < s.retry.MaxAttempts && ctx.Err() == nil
+
+		status, lastError := domain.DeliverySent, ""
 		if err != nil {
-			return fmt.Errorf("failed to send otp email: %w", err)
+			status, lastError = domain.DeliveryFailed, err.Error()
+			if retry {
+				status = domain.DeliveryQueued
+			}
+		}
+		if id != 0 {
+			// the outcome is recorded even if ctx is done
+			if err := s.deliveries.RecordAttempt(context.WithoutCancel(ctx), id, status, lastError); err != nil {
+				logger.Error(ctx, "failed to record email delivery attempt", "deliveryID", id, "err", err)
+			}
+		}
+		if !retry {
+			return err
 		}
-	}
 
-	logger.Debug(ctx, "otp email sent", "email", email)
-	return nil
+		logger.Debug(ctx, "retrying email", "deliveryID", id, "attempt", attempt, "err", err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
 }
 
-func (s *mailService) SendRegistered(ctx context.Context, email, name string) error {
-	const (
-		subject     = "Добро пожаловать в Finance Tracker"
-		teplatePath = "templates/register.html"
-	)
-
-	mail := gomail.NewMessage()
-	mail.SetHeader("From", s.conf.User)
-	mail.SetHeader("To", email)
-	mail.SetHeader("Subject", subject)
+// attempt gives up on the transport after the timeout.
+func (s *mailService) attempt(ctx context.Context, email string, m *gomail.Message) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	return s.transport.Send(ctx, envelopeAddress(s.from), []string{email}, m)
+}
 
-	tmpl, err := template.ParseFiles(teplatePath)
+// Resend renders a logged email again from its payload and sends it to the
+// same address. Its attempts are added to the same delivery.
+func (s *mailService) Resend(ctx context.Context, id int64) (domain.Delivery, error) {
+	d, err := s.deliveries.Get(ctx, id)
 	if err != nil {
-		return fmt.Errorf("failed to parse email template: %w", err)
+		return domain.Delivery{}, err
 	}
-
-	var body bytes.Buffer
-	if err := tmpl.Execute(&body, map[string]string{"Name": name}); err != nil {
-		return fmt.Errorf("failed to execute email template: %w", err)
+	msg, err := emails.Decode(d.Template, d.Payload)
+	if err != nil {
+		return domain.Delivery{}, err
+	}
+	rendered, headers, err := s.render(d.Locale, msg)
+	if err != nil {
+		return domain.Delivery{}, err
 	}
 
-	mail.SetBody("text/html", body.String())
+	err = s.deliver(ctx, id, d.Email, rendered, headers)
+	metrics.ObserveEmail(msg.Template(), err)
+	if err != nil {
+		logger.Error(ctx, "failed to resend email", "deliveryID", id, "err", err)
+	}
+	// the failure is in the returned delivery
+	return s.deliveries.Get(ctx, id)
+}
 
-	d := gomail.NewDialer(s.conf.Host, s.conf.Port, s.conf.User, s.conf.Pass)
+// Search returns a page of the delivery log, newest first, with the same
+// cursors as the notifications.
+func (s *mailService) Search(ctx context.Context, dto domain.SearchDeliveriesDto) (domain.DeliveriesPage, error) {
+	pageSize := dto.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	pageSize = min(pageSize, MaxPageSize)
 
-	errChan := make(chan error, 1)
-	go func() {
-		errChan <- d.DialAndSend(mail)
-	}()
+	beforeID, err := decodeCursor(dto.Cursor)
+	if err != nil {
+		return domain.DeliveriesPage{}, err
+	}
 
-	ctx, cancel := context.WithTimeout(ctx, s.timeout)
-	defer cancel()
+	items, err := s.deliveries.Search(ctx, dto.Filter, beforeID, pageSize+1)
+	if err != nil {
+		return domain.DeliveriesPage{}, err
+	}
 
-	select {
-	case <-ctx.Done():
-		return fmt.Errorf("sending email canceled or timed out: %w", ctx.Err())
-	case err := <-errChan:
-		if err != nil {
-			return fmt.Errorf("failed to send registration email: %w", err)
-		}
+	var page domain.DeliveriesPage
+	if len(items) > pageSize {
+		items = items[:pageSize]
+		page.NextCursor = encodeCursor(items[pageSize-1].ID)
 	}
+	page.Deliveries = items
+	return page, nil
+}
 
-	logger.Debug(ctx, "registration email sent", "email", email)
-	return nil
+// envelopeAddress returns the bare address of "Finance Tracker <noreply@example.com>".
+func envelopeAddress(from string) string {
+	if addr, err := netmail.ParseAddress(from); err == nil {
+		return addr.Address
+	}
+	return from
 }
@@ -0,0 +1,241 @@
+package service_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"mime/quotedprintable"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"FinanceTracker/notification/internal/domain"
+	"FinanceTracker/notification/internal/emails"
+	"FinanceTracker/notification/internal/service"
+	smocks "FinanceTracker/notification/internal/service/mocks"
+	"FinanceTracker/notification/pkg/logger"
+	"FinanceTracker/notification/pkg/mail"
+	"FinanceTracker/notification/pkg/unsubscribe"
+)
+
+var (
+	retryPolicy = service.RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}
+	signer      = unsubscribe.New([]byte("secret"))
+	links       = &service.UnsubscribeLinks{
+		Signer:   signer,
+		OneClick: "https://api.example.com/notifications/unsubscribe",
+		Page:     "https://example.com/unsubscribe",
+	}
+)
+
+// flakyTransport fails with errs before delivering to the memory transport.
+type flakyTransport struct {
+	*mail.Memory
+	errs []error
+}
+
+func (t *flakyTransport) Send(ctx context.Context, from string, to []string, msg io.WriterTo) error {
+	if len(t.errs) > 0 {
+		err := t.errs[0]
+		t.errs = t.errs[1:]
+		return err
+	}
+	return t.Memory.Send(ctx, from, to, msg)
+}
+
+func TestMailService_SendOTP(t *testing.T) {
+	renderer, err := emails.New()
+	require.NoError(t, err)
+	ctx := logger.WithLogger(context.Background(), slog.New(slog.DiscardHandler))
+
+	testCases := []struct {
+		name         string
+		from         string
+		errs         []error
+		wantFrom     string
+		wantStatuses []string
+		wantErr      bool
+	}{
+		{
+			name:         "bare_address",
+			from:         "noreply@example.com",
+			wantFrom:     "noreply@example.com",
+			wantStatuses: []string{domain.DeliverySent},
+		},
+		{
+			name:         "address_with_name",
+			from:         "Finance Tracker <noreply@example.com>",
+			wantFrom:     "noreply@example.com",
+			wantStatuses: []string{domain.DeliverySent},
+		},
+		{
+			name:         "retries_temporary_failure",
+			from:         "noreply@example.com",
+			errs:         []error{&textproto.Error{Code: 451, Msg: "try again later"}},
+			wantFrom:     "noreply@example.com",
+			wantStatuses: []string{domain.DeliveryQueued, domain.DeliverySent},
+		},
+		{
+			name:         "gives_up_after_max_attempts",
+			from:         "noreply@example.com",
+			errs:         []error{io.EOF, io.EOF, io.EOF},
+			wantStatuses: []string{domain.DeliveryQueued, domain.DeliveryQueued, domain.DeliveryFailed},
+			wantErr:      true,
+		},
+		{
+			name:         "permanent_failure_is_not_retried",
+			from:         "noreply@example.com",
+			errs:         []error{&textproto.Error{Code: 550, Msg: "no such user"}},
+			wantStatuses: []string{domain.DeliveryFailed},
+			wantErr:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			transport := &flakyTransport{Memory: mail.NewMemory(), errs: tc.errs}
+			deliveries := smocks.NewMockDeliveryRepo(t)
+			// the code is never stored
+			deliveries.EXPECT().Create(mock.Anything, domain.Delivery{
+				Email:    "user@example.com",
+				Type:     domain.EmailSecurity,
+				Template: "otp",
+				Locale:   "en",
+				Subject:  "Your Finance Tracker sign-in code",
+			}).Return(17, nil)
+			var statuses []string
+			deliveries.EXPECT().RecordAttempt(mock.Anything, int64(17), mock.Anything, mock.Anything).
+				RunAndReturn(func(_ context.Context, _ int64, status, lastError string) error {
+					statuses = append(statuses, status)
+					assert.Equal(t, status == domain.DeliverySent, lastError == "")
+					return nil
+				})
+			s := service.NewMailService(tc.from, transport, renderer, deliveries, retryPolicy, links)
+
+			err := s.SendOTP(ctx, "user@example.com", "en", "482913", 5*time.Minute)
+
+			assert.Equal(t, tc.wantStatuses, statuses)
+			messages := transport.Messages()
+			if tc.wantErr {
+				require.Error(t, err)
+				assert.Empty(t, messages)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, messages, 1)
+			assert.Equal(t, tc.wantFrom, messages[0].From)
+			assert.Equal(t, []string{"user@example.com"}, messages[0].To)
+			data := string(messages[0].Data)
+			assert.Contains(t, data, "To: user@example.com\r\n")
+			assert.Contains(t, data, "Subject: Your Finance Tracker sign-in code\r\n")
+			assert.Contains(t, data, "482913")
+			// login codes can't be unsubscribed from
+			assert.NotContains(t, data, "List-Unsubscribe")
+			assert.NotContains(t, data, "unsubscribe?token=")
+		})
+	}
+}
+
+func TestMailService_SendDigest(t *testing.T) {
+	renderer, err := emails.New()
+	require.NoError(t, err)
+	ctx := logger.WithLogger(context.Background(), slog.New(slog.DiscardHandler))
+	digest := emails.Fixtures()["digest"].(emails.Digest).Digest
+	token := signer.Token(int64(digest.UserID), domain.EmailCategoryDigest)
+
+	transport := mail.NewMemory()
+	deliveries := smocks.NewMockDeliveryRepo(t)
+	deliveries.EXPECT().Create(mock.Anything, mock.Anything).Return(1, nil)
+	deliveries.EXPECT().RecordAttempt(mock.Anything, int64(1), domain.DeliverySent, "").Return(nil)
+	s := service.NewMailService("noreply@example.com", transport, renderer, deliveries, retryPolicy, links)
+
+	err = s.SendDigest(ctx, domain.Recipient{Email: "user@example.com", Locale: "en"}, digest)
+
+	require.NoError(t, err)
+	require.Len(t, transport.Messages(), 1)
+	data := string(transport.Messages()[0].Data)
+	assert.Contains(t, data, "List-Unsubscribe: <https://api.example.com/notifications/unsubscribe?token="+token+">\r\n")
+	assert.Contains(t, data, "List-Unsubscribe-Post: List-Unsubscribe=One-Click\r\n")
+	body, err := io.ReadAll(quotedprintable.NewReader(strings.NewReader(data)))
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `href="https://example.com/unsubscribe?token=`+token+`"`)
+}
+
+func TestMailService_SendReminder(t *testing.T) {
+	renderer, err := emails.New()
+	require.NoError(t, err)
+	ctx := logger.WithLogger(context.Background(), slog.New(slog.DiscardHandler))
+	reminder := emails.Fixtures()["reminder"].(emails.Reminder).Reminder
+	token := signer.Token(int64(reminder.Charge.UserID), domain.EmailCategoryReminders)
+
+	transport := mail.NewMemory()
+	deliveries := smocks.NewMockDeliveryRepo(t)
+	deliveries.EXPECT().Create(mock.Anything, mock.MatchedBy(func(d domain.Delivery) bool {
+		return d.Type == domain.EmailReminder
+	})).Return(1, nil)
+	deliveries.EXPECT().RecordAttempt(mock.Anything, int64(1), domain.DeliverySent, "").Return(nil)
+	s := service.NewMailService("noreply@example.com", transport, renderer, deliveries, retryPolicy, links)
+
+	err = s.SendReminder(ctx, domain.Recipient{Email: "user@example.com", Locale: "en"}, reminder)
+
+	require.NoError(t, err)
+	require.Len(t, transport.Messages(), 1)
+	data := string(transport.Messages()[0].Data)
+	assert.Contains(t, data, "List-Unsubscribe: <https://api.example.com/notifications/unsubscribe?token="+token+">\r\n")
+	assert.Contains(t, data, "List-Unsubscribe-Post: List-Unsubscribe=One-Click\r\n")
+}
+
+func TestMailService_Resend(t *testing.T) {
+	renderer, err := emails.New()
+	require.NoError(t, err)
+	ctx := logger.WithLogger(context.Background(), slog.New(slog.DiscardHandler))
+
+	registered := domain.Delivery{ID: 5, Email: "user@example.com", Template: "registered", Locale: "en", Payload: []byte(`{"Name":"Ivan"}`)}
+	otp := domain.Delivery{ID: 6, Email: "user@example.com", Template: "otp", Locale: "en"}
+
+	testCases := []struct {
+		name     string
+		delivery domain.Delivery
+		wantSent bool
+		wantErr  error
+	}{
+		{
+			name:     "resent",
+			delivery: registered,
+			wantSent: true,
+		},
+		{
+			name:     "login_code_is_not_kept",
+			delivery: otp,
+			wantErr:  domain.ErrNotResendable,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			transport := mail.NewMemory()
+			deliveries := smocks.NewMockDeliveryRepo(t)
+			deliveries.EXPECT().Get(mock.Anything, tc.delivery.ID).Return(tc.delivery, nil)
+			if tc.wantSent {
+				deliveries.EXPECT().RecordAttempt(mock.Anything, tc.delivery.ID, domain.DeliverySent, "").Return(nil)
+			}
+			s := service.NewMailService("noreply@example.com", transport, renderer, deliveries, retryPolicy, nil)
+
+			_, err := s.Resend(ctx, tc.delivery.ID)
+
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				assert.Empty(t, transport.Messages())
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, transport.Messages(), 1)
+			assert.Contains(t, string(transport.Messages()[0].Data), "Ivan")
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"FinanceTracker/notification/internal/domain"
+	"FinanceTracker/notification/internal/i18n"
+	"context"
+	"encoding/base64"
+	"strconv"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+type NotificationRepo interface {
+	Create(ctx context.Context, n domain.Notification) error
+	List(ctx context.Context, userID int, beforeID int64, limit int, unreadOnly bool) ([]domain.Notification, error)
+	MarkRead(ctx context.Context, userID int, notificationID int64) (domain.Notification, error)
+	MarkAllRead(ctx context.Context, userID int) (int64, error)
+	CountUnread(ctx context.Context, userID int) (int64, error)
+}
+
+type notificationService struct {
+	repo    NotificationRepo
+	catalog *i18n.Catalog
+}
+
+func NewNotificationService(repo NotificationRepo, catalog *i18n.Catalog) *notificationService {
+	return &notificationService{repo: repo, catalog: catalog}
+}
+
+// NotifyRegistered adds the welcome notification in the locale of the user.
+// dedupKey identifies the source event, so that a redelivered event adds
+// nothing.
+func (s *notificationService) NotifyRegistered(ctx context.Context, userID int, locale, dedupKey string) error {
+	l := i18n.Parse(locale)
+	return s.repo.Create(ctx, domain.Notification{
+		UserID:   userID,
+		Type:     domain.TypeWelcome,
+		Title:    s.catalog.T(l, "welcome.title"),
+		Body:     s.catalog.T(l, "welcome.inbox"),
+		Link:     "/subscriptions",
+		DedupKey: dedupKey,
+	})
+}
+
+// List returns a page of notifications, newest first. The cursor is opaque
+// to clients and holds the ID of the last notification of the previous page.
+func (s *notificationService) List(ctx context.Context, dto domain.ListNotificationsDto) (domain.NotificationsPage, error) {
+	pageSize := dto.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	pageSize = min(pageSize, MaxPageSize)
+
+	beforeID, err := decodeCursor(dto.Cursor)
+	if err != nil {
+		return domain.NotificationsPage{}, err
+	}
+
+	// one extra row tells whether there is a next page
+	items, err := s.repo.List(ctx, dto.UserID, beforeID, pageSize+1, dto.UnreadOnly)
+	if err != nil {
+		return domain.NotificationsPage{}, err
+	}
+
+	var page domain.NotificationsPage
+	if len(items) > pageSize {
+		items = items[:pageSize]
+		page.NextCursor = encodeCursor(items[pageSize-1].ID)
+	}
+	page.Notifications = items
+	return page, nil
+}
+
+func (s *notificationService) MarkRead(ctx context.Context, userID int, notificationID int64) (domain.Notification, error) {
+	return s.repo.MarkRead(ctx, userID, notificationID)
+}
+
+func (s *notificationService) MarkAllRead(ctx context.Context, userID int) (int64, error) {
+	return s.repo.MarkAllRead(ctx, userID)
+}
+
+func (s *notificationService) UnreadCount(ctx context.Context, userID int) (int64, error) {
+	return s.repo.CountUnread(ctx, userID)
+}
+
+func encodeCursor(id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(id, 10)))
+}
+
+func decodeCursor(cursor string) (int64, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, domain.ErrInvalidCursor
+	}
+	id, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, domain.ErrInvalidCursor
+	}
+	return id, nil
+}
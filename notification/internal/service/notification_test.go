@@ -0,0 +1,121 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"FinanceTracker/notification/internal/domain"
+	"FinanceTracker/notification/internal/service"
+	smocks "FinanceTracker/notification/internal/service/mocks"
+)
+
+func notifications(ids ...int64) []domain.Notification {
+	res := make([]domain.Notification, len(ids))
+	for i, id := range ids {
+		res[i] = domain.Notification{ID: id, UserID: 7}
+	}
+	return res
+}
+
+func TestNotificationService_List(t *testing.T) {
+	listErr := errors.New("list error")
+
+	testCases := []struct {
+		name         string
+		dto          domain.ListNotificationsDto
+		wantBeforeID int64
+		wantLimit    int
+		repoItems    []domain.Notification
+		repoErr      error
+		wantIDs      []int64
+		wantNext     bool
+		wantErr      error
+	}{
+		{
+			name:      "first_page_with_more",
+			dto:       domain.ListNotificationsDto{UserID: 7, PageSize: 2},
+			wantLimit: 3,
+			repoItems: notifications(9, 8, 7),
+			wantIDs:   []int64{9, 8},
+			wantNext:  true,
+		},
+		{
+			name:      "last_page",
+			dto:       domain.ListNotificationsDto{UserID: 7, PageSize: 2},
+			wantLimit: 3,
+			repoItems: notifications(9, 8),
+			wantIDs:   []int64{9, 8},
+		},
+		{
+			name:      "default_page_size",
+			dto:       domain.ListNotificationsDto{UserID: 7},
+			wantLimit: service.DefaultPageSize + 1,
+			repoItems: notifications(1),
+			wantIDs:   []int64{1},
+		},
+		{
+			name:      "page_size_is_capped",
+			dto:       domain.ListNotificationsDto{UserID: 7, PageSize: 1000},
+			wantLimit: service.MaxPageSize + 1,
+			repoItems: notifications(),
+			wantIDs:   []int64{},
+		},
+		{
+			name:    "invalid_cursor",
+			dto:     domain.ListNotificationsDto{UserID: 7, Cursor: "not a cursor"},
+			wantErr: domain.ErrInvalidCursor,
+		},
+		{
+			name:      "repo_error",
+			dto:       domain.ListNotificationsDto{UserID: 7, PageSize: 2},
+			wantLimit: 3,
+			repoErr:   listErr,
+			wantErr:   listErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := smocks.NewMockNotificationRepo(t)
+			if tc.wantLimit > 0 {
+				repo.EXPECT().List(mock.Anything, 7, tc.wantBeforeID, tc.wantLimit, false).Return(tc.repoItems, tc.repoErr)
+			}
+			svc := service.NewNotificationService(repo, nil)
+
+			page, err := svc.List(context.Background(), tc.dto)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			ids := make([]int64, len(page.Notifications))
+			for i, n := range page.Notifications {
+				ids[i] = n.ID
+			}
+			assert.Equal(t, tc.wantIDs, ids)
+			assert.Equal(t, tc.wantNext, page.NextCursor != "")
+		})
+	}
+}
+
+func TestNotificationService_ListWithCursor(t *testing.T) {
+	repo := smocks.NewMockNotificationRepo(t)
+	repo.EXPECT().List(mock.Anything, 7, int64(0), 2, true).Return(notifications(9, 8), nil)
+	repo.EXPECT().List(mock.Anything, 7, int64(9), 2, true).Return(notifications(8), nil)
+	svc := service.NewNotificationService(repo, nil)
+
+	first, err := svc.List(context.Background(), domain.ListNotificationsDto{UserID: 7, PageSize: 1, UnreadOnly: true})
+	require.NoError(t, err)
+	require.NotEmpty(t, first.NextCursor)
+
+	second, err := svc.List(context.Background(), domain.ListNotificationsDto{UserID: 7, PageSize: 1, UnreadOnly: true, Cursor: first.NextCursor})
+	require.NoError(t, err)
+	assert.Equal(t, notifications(8), second.Notifications)
+	assert.Empty(t, second.NextCursor)
+}
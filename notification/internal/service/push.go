@@ -0,0 +1,162 @@
+package service
+
+import (
+	"FinanceTracker/notification/internal/domain"
+	"FinanceTracker/notification/internal/i18n"
+	"FinanceTracker/notification/pkg/logger"
+	"FinanceTracker/notification/pkg/metrics"
+	"FinanceTracker/notification/pkg/webpush"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// maxEndpointLength bounds endpoints, which are opaque URLs from the browser.
+const maxEndpointLength = 2048
+
+type PushRepo interface {
+	Save(ctx context.Context, sub domain.PushSubscription) (domain.PushSubscription, error)
+	ListByUser(ctx context.Context, userID int) ([]domain.PushSubscription, error)
+	Delete(ctx context.Context, userID int, endpoint string) (bool, error)
+	DeleteByID(ctx context.Context, subscriptionID int64) error
+}
+
+// ChannelPreferences turns on a channel in the preferences of a user, who
+// would otherwise not get messages on a device they just subscribed.
+type ChannelPreferences interface {
+	EnableChannel(ctx context.Context, userID int, channel string) error
+}
+
+type PushSender interface {
+	PublicKey() string
+	Send(ctx context.Context, sub webpush.Subscription, payload []byte) error
+}
+
+type pushService struct {
+	repo         PushRepo
+	prefs        ChannelPreferences
+	sender       PushSender // nil when VAPID keys are not configured
+	allowedHosts []string
+	catalog      *i18n.Catalog
+}
+
+// NewPushService creates the Web Push channel. Endpoints must be https URLs
+// of allowedHosts or their subdomains, so that the service can't be made to
+// post to arbitrary addresses. Registering a browser turns push messages on
+// for its user. Messages are translated with catalog.
+func NewPushService(repo PushRepo, prefs ChannelPreferences, sender PushSender, allowedHosts []string, catalog *i18n.Catalog) *pushService {
+	return &pushService{
+		repo:         repo,
+		prefs:        prefs,
+		sender:       sender,
+		allowedHosts: allowedHosts,
+		catalog:      catalog,
+	}
+}
+
+// PublicKey is the VAPID key browsers subscribe with.
+func (s *pushService) PublicKey() (string, error) {
+	if s.sender == nil {
+		return "", domain.ErrPushDisabled
+	}
+	return s.sender.PublicKey(), nil
+}
+
+func (s *pushService) Register(ctx context.Context, sub domain.PushSubscription) (domain.PushSubscription, error) {
+	if s.sender == nil {
+		return domain.PushSubscription{}, domain.ErrPushDisabled
+	}
+	if err := s.validate(sub); err != nil {
+		return domain.PushSubscription{}, err
+	}
+	saved, err := s.repo.Save(ctx, sub)
+	if err != nil {
+		return domain.PushSubscription{}, err
+	}
+	// saving is idempotent, so a browser that failed here registers again
+	if err := s.prefs.EnableChannel(ctx, sub.UserID, domain.ChannelWebPush); err != nil {
+		return domain.PushSubscription{}, fmt.Errorf("failed to enable push messages: %w", err)
+	}
+	return saved, nil
+}
+
+func (s *pushService) Unregister(ctx context.Context, userID int, endpoint string) (bool, error) {
+	return s.repo.Delete(ctx, userID, endpoint)
+}
+
+func (s *pushService) validate(sub domain.PushSubscription) error {
+	if len(sub.Endpoint) > maxEndpointLength {
+		return fmt.Errorf("%w: endpoint is too long", domain.ErrInvalidPushSubscription)
+	}
+	endpoint, err := url.Parse(sub.Endpoint)
+	if err != nil || endpoint.Scheme != "https" || endpoint.User != nil {
+		return fmt.Errorf("%w: endpoint must be an https url", domain.ErrInvalidPushSubscription)
+	}
+	if !s.allowedHost(endpoint.Hostname()) {
+		return fmt.Errorf("%w: unknown push service %s", domain.ErrInvalidPushSubscription, endpoint.Hostname())
+	}
+	keys := webpush.Subscription{Endpoint: sub.Endpoint, P256dh: sub.P256dh, Auth: sub.Auth}
+	if err := keys.Validate(); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInvalidPushSubscription, err)
+	}
+	return nil
+}
+
+func (s *pushService) allowedHost(host string) bool {
+	for _, allowed := range s.allowedHosts {
+		if host == allowed || strings.HasSuffix(host, "."+allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *pushService) NotifyRegistered(ctx context.Context, userID int, locale, name string) error {
+	l := i18n.Parse(locale)
+	body := s.catalog.T(l, "welcome.push")
+	if name != "" {
+		body = s.catalog.T(l, "welcome.push.named", name)
+	}
+	return s.notify(ctx, userID, domain.TypeWelcome, domain.PushMessage{
+		Title: s.catalog.T(l, "welcome.title"),
+		Body:  body,
+		URL:   "/subscriptions",
+		Tag:   domain.TypeWelcome,
+	})
+}
+
+// notify sends a message to every browser of a user and forgets the
+// subscriptions their push services report as gone.
+func (s *pushService) notify(ctx context.Context, userID int, kind string, msg domain.PushMessage) error {
+	subs, err := s.repo.ListByUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to list push subscriptions: %w", err)
+	}
+	if len(subs) == 0 {
+		return nil
+	}
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, sub := range subs {
+		err := s.sender.Send(ctx, webpush.Subscription{Endpoint: sub.Endpoint, P256dh: sub.P256dh, Auth: sub.Auth}, payload)
+		metrics.ObservePush(kind, err)
+		if webpush.IsGone(err) {
+			logger.Info(ctx, "push subscription is gone, removing", "userID", userID, "subscriptionID", sub.ID)
+			if err := s.repo.DeleteByID(ctx, sub.ID); err != nil {
+				errs = append(errs, fmt.Errorf("failed to delete push subscription: %w", err))
+			}
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to send push message: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
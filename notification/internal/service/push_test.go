@@ -0,0 +1,182 @@
+package service_test
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"FinanceTracker/notification/internal/domain"
+	"FinanceTracker/notification/internal/i18n"
+	"FinanceTracker/notification/internal/service"
+	smocks "FinanceTracker/notification/internal/service/mocks"
+	"FinanceTracker/notification/pkg/logger"
+	"FinanceTracker/notification/pkg/webpush"
+	"FinanceTracker/notification/pkg/webpush/webpushtest"
+	"FinanceTracker/notification/templates"
+)
+
+func newPushClient(t *testing.T) (*webpush.Client, *webpushtest.Server) {
+	public, private, err := webpush.GenerateVAPIDKeys()
+	require.NoError(t, err)
+	client, err := webpush.New(public, private, "mailto:admin@example.com", time.Hour)
+	require.NoError(t, err)
+	server := webpushtest.NewServer(t, public)
+	client.HTTPClient = server.Client()
+	return client, server
+}
+
+func TestPushService_Register(t *testing.T) {
+	client, server := newPushClient(t)
+	valid := server.Subscribe(t)
+
+	enableErr := errors.New("profile is unavailable")
+
+	testCases := []struct {
+		name      string
+		sub       domain.PushSubscription
+		enableErr error
+		wantErr   error
+	}{
+		{
+			name: "registered",
+			sub:  domain.PushSubscription{UserID: 7, Endpoint: valid.Endpoint, P256dh: valid.P256dh, Auth: valid.Auth},
+		},
+		{
+			name:      "enable_channel_error",
+			sub:       domain.PushSubscription{UserID: 7, Endpoint: valid.Endpoint, P256dh: valid.P256dh, Auth: valid.Auth},
+			enableErr: enableErr,
+			wantErr:   enableErr,
+		},
+		{
+			name:    "unknown_push_service",
+			sub:     domain.PushSubscription{UserID: 7, Endpoint: "https://evil.example.com/push", P256dh: valid.P256dh, Auth: valid.Auth},
+			wantErr: domain.ErrInvalidPushSubscription,
+		},
+		{
+			name:    "not_https",
+			sub:     domain.PushSubscription{UserID: 7, Endpoint: "http://127.0.0.1/push", P256dh: valid.P256dh, Auth: valid.Auth},
+			wantErr: domain.ErrInvalidPushSubscription,
+		},
+		{
+			name:    "invalid_keys",
+			sub:     domain.PushSubscription{UserID: 7, Endpoint: valid.Endpoint, P256dh: base64.RawURLEncoding.EncodeToString([]byte("short")), Auth: valid.Auth},
+			wantErr: domain.ErrInvalidPushSubscription,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := smocks.NewMockPushRepo(t)
+			prefs := smocks.NewMockChannelPreferences(t)
+			if tc.wantErr == nil || tc.enableErr != nil {
+				repo.EXPECT().Save(mock.Anything, tc.sub).Return(tc.sub, nil)
+				prefs.EXPECT().EnableChannel(mock.Anything, 7, domain.ChannelWebPush).Return(tc.enableErr)
+			}
+			svc := service.NewPushService(repo, prefs, client, []string{"127.0.0.1"}, nil)
+
+			_, err := svc.Register(context.Background(), tc.sub)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPushService_NotifyRegistered(t *testing.T) {
+	catalog, err := i18n.Load(templates.I18n)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		locale      string
+		wantPayload string
+	}{
+		{
+			locale: "ru",
+			wantPayload: `{
+				"title": "Добро пожаловать в Finance Tracker",
+				"body": "Alice, теперь вы можете отслеживать свои финансы и управлять расходами.",
+				"url": "/subscriptions",
+				"tag": "welcome"
+			}`,
+		},
+		{
+			locale: "en",
+			wantPayload: `{
+				"title": "Welcome to Finance Tracker",
+				"body": "Alice, you can now track your finances and manage your spending.",
+				"url": "/subscriptions",
+				"tag": "welcome"
+			}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.locale, func(t *testing.T) {
+			client, server := newPushClient(t)
+			active, expired := server.Subscribe(t), server.Subscribe(t)
+			server.Expire(expired.Endpoint)
+
+			repo := smocks.NewMockPushRepo(t)
+			repo.EXPECT().ListByUser(mock.Anything, 7).Return([]domain.PushSubscription{
+				{ID: 1, UserID: 7, Endpoint: active.Endpoint, P256dh: active.P256dh, Auth: active.Auth},
+				{ID: 2, UserID: 7, Endpoint: expired.Endpoint, P256dh: expired.P256dh, Auth: expired.Auth},
+			}, nil)
+			repo.EXPECT().DeleteByID(mock.Anything, int64(2)).Return(nil)
+
+			svc := service.NewPushService(repo, nil, client, []string{"127.0.0.1"}, catalog)
+			ctx := logger.WithLogger(context.Background(), slog.New(slog.DiscardHandler))
+			require.NoError(t, svc.NotifyRegistered(ctx, 7, tc.locale, "Alice"))
+
+			received := server.Received()
+			require.Len(t, received, 1)
+			assert.Equal(t, active.Endpoint, received[0].Endpoint)
+			assert.JSONEq(t, tc.wantPayload, string(received[0].Payload))
+		})
+	}
+}
+
+// A user with the default channels who allows push messages gets them.
+func TestPushService_RegisterThenDeliver(t *testing.T) {
+	client, server := newPushClient(t)
+	browser := server.Subscribe(t)
+	sub := domain.PushSubscription{ID: 1, UserID: 7, Endpoint: browser.Endpoint, P256dh: browser.P256dh, Auth: browser.Auth}
+	userPrefs := domain.Preferences{
+		Channels: []string{domain.ChannelEmail, domain.ChannelInApp},
+		Location: time.UTC,
+		Mode:     domain.ModeImmediate,
+	}
+
+	repo := smocks.NewMockPushRepo(t)
+	repo.EXPECT().Save(mock.Anything, sub).Return(sub, nil)
+	repo.EXPECT().ListByUser(mock.Anything, 7).Return([]domain.PushSubscription{sub}, nil)
+	prefs := smocks.NewMockChannelPreferences(t)
+	prefs.EXPECT().EnableChannel(mock.Anything, 7, domain.ChannelWebPush).
+		RunAndReturn(func(_ context.Context, _ int, channel string) error {
+			userPrefs.Channels = append(userPrefs.Channels, channel)
+			return nil
+		})
+
+	catalog, err := i18n.Load(templates.I18n)
+	require.NoError(t, err)
+	svc := service.NewPushService(repo, prefs, client, []string{"127.0.0.1"}, catalog)
+	ctx := logger.WithLogger(context.Background(), slog.New(slog.DiscardHandler))
+	_, err = svc.Register(ctx, sub)
+	require.NoError(t, err)
+
+	channels, _, _ := userPrefs.Deliverable(time.Now())
+	require.Contains(t, channels, domain.ChannelWebPush)
+	require.NoError(t, svc.NotifyRegistered(ctx, 7, "en", "Alice"))
+	received := server.Received()
+	require.Len(t, received, 1)
+	assert.Equal(t, browser.Endpoint, received[0].Endpoint)
+}
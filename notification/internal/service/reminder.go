@@ -0,0 +1,113 @@
+package service
+
+import (
+	"FinanceTracker/notification/internal/domain"
+	"FinanceTracker/notification/pkg/logger"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// reminderHorizon covers the longest lead time users can choose, with a day
+// to spare for timezones.
+const reminderHorizon = 32 * 24 * time.Hour
+
+// ReminderCharges lists the upcoming charges and marks the ones users were
+// reminded of.
+type ReminderCharges interface {
+	Upcoming(ctx context.Context, from, to time.Time) ([]domain.UpcomingCharge, error)
+	// MarkReminded reports false if the reminder of the charge was already
+	// claimed, by this replica or another one.
+	MarkReminded(ctx context.Context, charge domain.UpcomingCharge) (bool, error)
+}
+
+type ReminderMailer interface {
+	SendReminder(ctx context.Context, to domain.Recipient, reminder domain.Reminder) error
+}
+
+type reminderService struct {
+	charges    ReminderCharges
+	prefs      DigestPreferences
+	recipients Recipients
+	mailer     ReminderMailer
+	now        func() time.Time
+}
+
+func NewReminderService(charges ReminderCharges, prefs DigestPreferences, recipients Recipients, mailer ReminderMailer) *reminderService {
+	return &reminderService{
+		charges:    charges,
+		prefs:      prefs,
+		recipients: recipients,
+		mailer:     mailer,
+		now:        time.Now,
+	}
+}
+
+// Run sends the due reminders at the start of every hour until ctx is done.
+// Reminders are claimed one by one, so every replica may run it.
+func (s *reminderService) Run(ctx context.Context) {
+	for {
+		next := s.now().Truncate(time.Hour).Add(time.Hour)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Until(next)):
+		}
+
+		if err := s.SendDue(ctx, next); err != nil {
+			logger.Error(ctx, "failed to send reminders", "err", err)
+		}
+	}
+}
+
+// SendDue emails the reminders due at now. A reminder is sent once, even if
+// sending it fails.
+func (s *reminderService) SendDue(ctx context.Context, now time.Time) error {
+	charges, err := s.charges.Upcoming(ctx, now, now.Add(reminderHorizon))
+	if err != nil {
+		return fmt.Errorf("failed to list upcoming charges: %w", err)
+	}
+
+	var errs []error
+	for userID, userCharges := range domain.GroupChargesByUser(charges) {
+		if err := s.remind(ctx, now, userID, userCharges); err != nil {
+			errs = append(errs, fmt.Errorf("user %d: %w", userID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *reminderService) remind(ctx context.Context, now time.Time, userID int, charges []domain.UpcomingCharge) error {
+	prefs, err := s.prefs.Get(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get preferences: %w", err)
+	}
+
+	var recipient *domain.Recipient
+	var errs []error
+	for _, charge := range charges {
+		reminder, ok := prefs.ReminderDue(charge, now)
+		if !ok {
+			continue
+		}
+		if recipient == nil {
+			r, err := s.recipients.Recipient(ctx, userID)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to get recipient: %w", err))
+				return errors.Join(errs...)
+			}
+			recipient = &r
+		}
+		claimed, err := s.charges.MarkReminded(ctx, charge)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to mark reminder: %w", err))
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		errs = append(errs, s.mailer.SendReminder(ctx, *recipient, reminder))
+	}
+	return errors.Join(errs...)
+}
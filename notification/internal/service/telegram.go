@@ -0,0 +1,90 @@
+package service
+
+import (
+	"FinanceTracker/notification/internal/domain"
+	"FinanceTracker/notification/internal/i18n"
+	"FinanceTracker/notification/pkg/logger"
+	"FinanceTracker/notification/pkg/metrics"
+	"FinanceTracker/notification/pkg/telegram"
+	"FinanceTracker/notification/templates"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+type TelegramClient interface {
+	SendMessage(ctx context.Context, chatID int64, text string) error
+}
+
+// TelegramLinks resolves the chat linked to a user.
+type TelegramLinks interface {
+	GetChatID(ctx context.Context, userID int) (int64, error)
+}
+
+type telegramService struct {
+	client    TelegramClient
+	links     TelegramLinks
+	templates map[i18n.Locale]*template.Template
+}
+
+// NewTelegramService parses the message templates of every locale, their
+// texts come from catalog.
+func NewTelegramService(client TelegramClient, links TelegramLinks, catalog *i18n.Catalog) *telegramService {
+	s := &telegramService{
+		client:    client,
+		links:     links,
+		templates: make(map[i18n.Locale]*template.Template),
+	}
+	for _, l := range i18n.Locales {
+		funcs := template.FuncMap{
+			"t": func(key string, args ...any) string {
+				return catalog.T(l, key, args...)
+			},
+		}
+		s.templates[l] = template.Must(template.New("").Funcs(funcs).ParseFS(templates.Telegram, "telegram/*.html"))
+	}
+	return s
+}
+
+func (s *telegramService) NotifyRegistered(ctx context.Context, userID int, locale, name string) error {
+	return s.notify(ctx, userID, locale, domain.TelegramRegistered, domain.TelegramRegisteredData{Name: name})
+}
+
+// notify sends a message to the chat of a user. Users without a chat are skipped.
+func (s *telegramService) notify(ctx context.Context, userID int, locale, kind string, data any) error {
+	chatID, err := s.links.GetChatID(ctx, userID)
+	if errors.Is(err, domain.ErrTelegramNotLinked) {
+		logger.Debug(ctx, "telegram is not linked, message skipped", "userID", userID, "type", kind)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get telegram chat: %w", err)
+	}
+	return s.Send(ctx, chatID, locale, kind, data)
+}
+
+// Send renders a message of the given type in the locale, falling back to
+// i18n.Default for unsupported ones, and sends it to a chat.
+func (s *telegramService) Send(ctx context.Context, chatID int64, locale, kind string, data any) error {
+	var text bytes.Buffer
+	if err := s.templates[i18n.Parse(locale)].ExecuteTemplate(&text, kind+".html", data); err != nil {
+		return fmt.Errorf("failed to execute telegram template: %w", err)
+	}
+
+	err := s.client.SendMessage(ctx, chatID, strings.TrimSpace(text.String()))
+	metrics.ObserveTelegram(kind, err)
+	if telegram.IsBlocked(err) {
+		// retrying won't help until the user unblocks the bot
+		logger.Info(ctx, "telegram chat is unavailable, message dropped", "chatID", chatID, "type", kind, "err", err)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to send telegram message: %w", err)
+	}
+
+	logger.Debug(ctx, "telegram message sent", "chatID", chatID, "type", kind)
+	return nil
+}
@@ -5,11 +5,15 @@ import "time"
 const (
 	TopicRegistered   = "user.registered"
 	TopicOTPGenerated = "user.otp.generated"
+	// TopicChargeScheduled is published by the scheduler when it plans the
+	// next charge of a subscription or the subscription is cancelled.
+	TopicChargeScheduled = "subscription.charge.scheduled"
 )
 
 type EventOTPGenerated struct {
 	Email     string    `json:"email"`
 	Code      string    `json:"code"`
+	Locale    string    `json:"locale,omitempty"` // ru or en
 	ExpiresAt time.Time `json:"expires_at"`
 	CreatedAt time.Time `json:"created_at"`
 }
@@ -20,4 +24,17 @@ type EventUserRegistered struct {
 	Provider  string `json:"provider"`
 	FullName  string `json:"full_name,omitempty"`
 	AvatarURL string `json:"avatar_url,omitempty"`
+	Locale    string `json:"locale,omitempty"` // ru or en
+}
+
+type EventChargeScheduled struct {
+	SubscriptionID int       `json:"subscription_id"`
+	UserID         int       `json:"user_id"`
+	Service        string    `json:"service"`
+	Amount         int64     `json:"amount"`   // in minor units
+	Currency       string    `json:"currency"` // ISO 4217 code
+	ChargeAt       time.Time `json:"charge_at"`
+	// Cancelled drops the charges of the subscription, the other fields
+	// except UserID are empty then
+	Cancelled bool `json:"cancelled,omitempty"`
 }
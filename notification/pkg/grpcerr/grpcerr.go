@@ -0,0 +1,55 @@
+package grpcerr
+
+import (
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Domain is reported in ErrorInfo so clients can tell our reasons from
+// the ones produced by grpc itself.
+const Domain = "financetracker"
+
+// ReasonInternal is used for errors that must not leak details to clients.
+const ReasonInternal = "INTERNAL"
+
+// New returns a status error with ErrorInfo carrying a machine-readable reason.
+// Metadata is given as key-value pairs.
+func New(code codes.Code, reason, msg string, metadata ...string) error {
+	return build(code, reason, msg, metadata).Err()
+}
+
+// InvalidArgument returns an InvalidArgument error describing a bad request field.
+func InvalidArgument(reason, field, description string) error {
+	st, err := build(codes.InvalidArgument, reason, description, nil).WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: field, Description: description},
+		},
+	})
+	if err != nil {
+		return status.Error(codes.InvalidArgument, description)
+	}
+	return st.Err()
+}
+
+// Internal hides the cause behind a generic message; log it before returning.
+func Internal(msg string) error {
+	return New(codes.Internal, ReasonInternal, msg)
+}
+
+func build(code codes.Code, reason, msg string, metadata []string) *status.Status {
+	info := &errdetails.ErrorInfo{Reason: reason, Domain: Domain}
+	if len(metadata) > 0 {
+		info.Metadata = make(map[string]string, len(metadata)/2)
+		for i := 0; i+1 < len(metadata); i += 2 {
+			info.Metadata[metadata[i]] = metadata[i+1]
+		}
+	}
+
+	st := status.New(code, msg)
+	withInfo, err := st.WithDetails(info)
+	if err != nil {
+		return st
+	}
+	return withInfo
+}
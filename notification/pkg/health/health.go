@@ -0,0 +1,156 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const checkTimeout = 2 * time.Second
+
+// Checker reports whether a dependency is usable.
+type Checker func(ctx context.Context) error
+
+type Health struct {
+	mu       sync.RWMutex
+	checks   map[string]Checker
+	services map[string][]string // service to the checks it depends on
+	shutdown atomic.Bool
+}
+
+func New() *Health {
+	return &Health{checks: make(map[string]Checker), services: make(map[string][]string)}
+}
+
+func (h *Health) Add(name string, check Checker) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.checks[name] = check
+}
+
+// Service names a part of the server that depends only on some of the
+// checks, so that its clients don't stop using it when an unrelated
+// dependency is down.
+func (h *Health) Service(name string, checks ...string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.services[name] = checks
+}
+
+// Shutdown marks the service as not ready, so that traffic is drained before it stops.
+func (h *Health) Shutdown() {
+	h.shutdown.Store(true)
+}
+
+// Check runs all checks concurrently and returns their errors by name.
+func (h *Health) Check(ctx context.Context) map[string]error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.run(ctx, h.checks)
+}
+
+func (h *Health) run(ctx context.Context, checks map[string]Checker) map[string]error {
+	var (
+		wg  sync.WaitGroup
+		mu  sync.Mutex
+		res = make(map[string]error, len(checks))
+	)
+	for name, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+			defer cancel()
+			err := check(ctx)
+			mu.Lock()
+			res[name] = err
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	return res
+}
+
+// Ready reports whether the service accepts traffic and all its dependencies are healthy.
+func (h *Health) Ready(ctx context.Context) bool {
+	if h.shutdown.Load() {
+		return false
+	}
+	for _, err := range h.Check(ctx) {
+		if err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// ServiceReady is Ready for the checks of a service; known is false for a
+// service that was never named.
+func (h *Health) ServiceReady(ctx context.Context, service string) (ready, known bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	names, ok := h.services[service]
+	if !ok {
+		return false, false
+	}
+	if h.shutdown.Load() {
+		return false, true
+	}
+	checks := make(map[string]Checker, len(names))
+	for _, name := range names {
+		if check, ok := h.checks[name]; ok {
+			checks[name] = check
+		}
+	}
+	for _, err := range h.run(ctx, checks) {
+		if err != nil {
+			return false, true
+		}
+	}
+	return true, true
+}
+
+type Response struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+func (h *Health) Routes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /healthz", h.handleLiveness)
+	mux.HandleFunc("GET /readyz", h.handleReadiness)
+}
+
+func (h *Health) handleLiveness(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, Response{Status: "ok"}, http.StatusOK)
+}
+
+func (h *Health) handleReadiness(w http.ResponseWriter, r *http.Request) {
+	if h.shutdown.Load() {
+		writeJSON(w, Response{Status: "shutting down"}, http.StatusServiceUnavailable)
+		return
+	}
+
+	resp := Response{Status: "ok", Checks: make(map[string]string)}
+	code := http.StatusOK
+	for name, err := range h.Check(r.Context()) {
+		if err != nil {
+			resp.Checks[name] = err.Error()
+			resp.Status = "unavailable"
+			code = http.StatusServiceUnavailable
+			continue
+		}
+		resp.Checks[name] = "ok"
+	}
+	writeJSON(w, resp, code)
+}
+
+func writeJSON(w http.ResponseWriter, payload any, code int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(payload)
+}
@@ -0,0 +1,132 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"FinanceTracker/notification/pkg/health"
+)
+
+func TestHealth_ServiceReady(t *testing.T) {
+	ok := func(context.Context) error { return nil }
+	down := func(context.Context) error { return errors.New("connection refused") }
+
+	testCases := []struct {
+		name      string
+		smtp      health.Checker
+		postgres  health.Checker
+		shutdown  bool
+		wantReady bool
+	}{
+		{
+			name:      "all_up",
+			smtp:      ok,
+			postgres:  ok,
+			wantReady: true,
+		},
+		{
+			name:      "unrelated_check_down",
+			smtp:      down,
+			postgres:  ok,
+			wantReady: true,
+		},
+		{
+			name:     "own_check_down",
+			smtp:     ok,
+			postgres: down,
+		},
+		{
+			name:     "shutting_down",
+			smtp:     ok,
+			postgres: ok,
+			shutdown: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := health.New()
+			h.Add("smtp", tc.smtp)
+			h.Add("postgres", tc.postgres)
+			h.Service("inbox", "postgres")
+			if tc.shutdown {
+				h.Shutdown()
+			}
+
+			ready, known := h.ServiceReady(context.Background(), "inbox")
+
+			assert.True(t, known)
+			assert.Equal(t, tc.wantReady, ready)
+		})
+	}
+
+	_, known := health.New().ServiceReady(context.Background(), "inbox")
+	assert.False(t, known)
+}
+
+func TestHealth_Register(t *testing.T) {
+	h := health.New()
+	h.Add("smtp", func(context.Context) error { return errors.New("connection refused") })
+	h.Add("postgres", func(context.Context) error { return nil })
+	h.Service("notification.NotificationService", "postgres")
+
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	h.Register(server)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+	client := grpc_health_v1.NewHealthClient(conn)
+
+	testCases := []struct {
+		name       string
+		service    string
+		wantStatus grpc_health_v1.HealthCheckResponse_ServingStatus
+		wantCode   codes.Code
+	}{
+		{
+			name:       "whole_server",
+			wantStatus: grpc_health_v1.HealthCheckResponse_NOT_SERVING,
+		},
+		{
+			name:       "named_service",
+			service:    "notification.NotificationService",
+			wantStatus: grpc_health_v1.HealthCheckResponse_SERVING,
+		},
+		{
+			name:     "unknown_service",
+			service:  "notification.Unknown",
+			wantCode: codes.NotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: tc.service})
+
+			if tc.wantCode != codes.OK {
+				assert.Equal(t, tc.wantCode, status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantStatus, resp.Status)
+		})
+	}
+}
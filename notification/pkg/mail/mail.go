@@ -0,0 +1,102 @@
+// Package mail delivers composed email messages. SMTP keeps a pool of
+// connections to the server, Dir drops .eml files in a directory and Memory
+// keeps the messages for tests.
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Transport delivers msg, written in the RFC 5322 format, from the envelope
+// sender to the recipients.
+type Transport interface {
+	Send(ctx context.Context, from string, to []string, msg io.WriterTo) error
+}
+
+// Message is a message accepted by Memory.
+type Message struct {
+	From string
+	To   []string
+	Data []byte
+}
+
+// Memory keeps the sent messages instead of delivering them.
+type Memory struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+func (m *Memory) Send(ctx context.Context, from string, to []string, msg io.WriterTo) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var data bytes.Buffer
+	if _, err := msg.WriteTo(&data); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = append(m.messages, Message{From: from, To: append([]string(nil), to...), Data: data.Bytes()})
+	return nil
+}
+
+// Messages returns the messages sent so far.
+func (m *Memory) Messages() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Message(nil), m.messages...)
+}
+
+// Dir writes every message to its own .eml file, which mail clients open as
+// is. The envelope is not kept, the message headers tell the recipients.
+type Dir struct {
+	path string
+}
+
+// NewDir creates the directory if it does not exist.
+func NewDir(path string) (*Dir, error) {
+	if err := os.MkdirAll(path, 0o755); err != nil {
+		return nil, err
+	}
+	return &Dir{path: path}, nil
+}
+
+func (d *Dir) Send(ctx context.Context, from string, to []string, msg io.WriterTo) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	suffix := make([]byte, 4)
+	rand.Read(suffix)
+	// names sort by the time the message was sent
+	name := time.Now().UTC().Format("20060102T150405.000000000") + "-" + hex.EncodeToString(suffix) + ".eml"
+
+	// written under a temporary name so that watchers never see half a message
+	f, err := os.CreateTemp(d.path, ".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := msg.WriteTo(f); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), filepath.Join(d.path, name))
+}
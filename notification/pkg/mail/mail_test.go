@@ -0,0 +1,128 @@
+package mail_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"FinanceTracker/notification/pkg/mail"
+	"FinanceTracker/notification/pkg/mail/mailtest"
+)
+
+const body = "Subject: Hi\r\n\r\nHello!\r\n"
+
+func TestSMTP_Send(t *testing.T) {
+	testCases := []struct {
+		name         string
+		between      func(s *mailtest.Server)
+		wantConns    int
+		idleTimeout  time.Duration
+		waitIdleTime time.Duration
+	}{
+		{
+			name:      "reuses_connection",
+			wantConns: 1,
+		},
+		{
+			name:      "redials_after_server_dropped_connection",
+			between:   (*mailtest.Server).DropConnections,
+			wantConns: 2,
+		},
+		{
+			name:         "redials_after_idle_timeout",
+			idleTimeout:  time.Millisecond,
+			waitIdleTime: 10 * time.Millisecond,
+			wantConns:    2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := mailtest.NewServer(t)
+			transport := mail.NewSMTP(server.Host, server.Port, "", "", 1, tc.idleTimeout)
+			defer transport.Close()
+			ctx := context.Background()
+
+			require.NoError(t, transport.Send(ctx, "from@example.com", []string{"one@example.com"}, strings.NewReader(body)))
+			if tc.between != nil {
+				tc.between(server)
+			}
+			time.Sleep(tc.waitIdleTime)
+			require.NoError(t, transport.Send(ctx, "from@example.com", []string{"two@example.com", "three@example.com"}, strings.NewReader(body)))
+
+			assert.Equal(t, tc.wantConns, server.Connections())
+			messages := server.Messages()
+			require.Len(t, messages, 2)
+			assert.Equal(t, []string{"one@example.com"}, messages[0].To)
+			assert.Equal(t, []string{"two@example.com", "three@example.com"}, messages[1].To)
+			assert.Equal(t, "from@example.com", messages[1].From)
+			assert.Equal(t, "Subject: Hi\n\nHello!\n", string(messages[1].Data))
+		})
+	}
+}
+
+func TestSMTP_SendCanceled(t *testing.T) {
+	server := mailtest.NewServer(t)
+	server.Hang()
+	transport := mail.NewSMTP(server.Host, server.Port, "", "", 1, 0)
+	defer transport.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	start := time.Now()
+
+	err := transport.Send(ctx, "from@example.com", []string{"one@example.com"}, strings.NewReader(body))
+
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Empty(t, server.Messages())
+	// the server got the whole message and might have queued it
+	assert.ErrorIs(t, err, mail.ErrMaybeSent)
+	assert.False(t, mail.Temporary(err))
+}
+
+func TestTemporary(t *testing.T) {
+	testCases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "mailbox_busy", err: &textproto.Error{Code: 450, Msg: "try again later"}, want: true},
+		{name: "rejected", err: &textproto.Error{Code: 550, Msg: "no such user"}},
+		{name: "connection_dropped", err: io.EOF, want: true},
+		{name: "timeout_before_data", err: context.DeadlineExceeded, want: true},
+		{name: "dropped_after_data", err: fmt.Errorf("%w: %w", mail.ErrMaybeSent, io.EOF)},
+		{name: "timeout_after_data", err: fmt.Errorf("%w: %w", mail.ErrMaybeSent, context.DeadlineExceeded)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, mail.Temporary(tc.err))
+		})
+	}
+}
+
+func TestDir_Send(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	transport, err := mail.NewDir(dir)
+	require.NoError(t, err)
+
+	require.NoError(t, transport.Send(context.Background(), "from@example.com", []string{"one@example.com"}, strings.NewReader(body)))
+
+	files, err := filepath.Glob(filepath.Join(dir, "*"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, ".eml", filepath.Ext(files[0]))
+	data, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	assert.Equal(t, body, string(data))
+}
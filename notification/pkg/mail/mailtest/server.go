@@ -0,0 +1,160 @@
+// Package mailtest provides a fake SMTP server for tests.
+package mailtest
+
+import (
+	"FinanceTracker/notification/pkg/mail"
+	"io"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Server accepts every message on a local port, without TLS or
+// authentication.
+type Server struct {
+	Host string
+	Port int
+
+	listener net.Listener
+
+	mu       sync.Mutex
+	messages []mail.Message
+	conns    map[net.Conn]bool
+	dialed   int
+	hang     bool
+}
+
+// NewServer starts a server that is closed when the test ends.
+func NewServer(t testing.TB) *Server {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := l.Addr().(*net.TCPAddr)
+	s := &Server{Host: addr.IP.String(), Port: addr.Port, listener: l, conns: make(map[net.Conn]bool)}
+	go s.serve()
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Messages returns the messages accepted so far.
+func (s *Server) Messages() []mail.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]mail.Message(nil), s.messages...)
+}
+
+// Connections returns the number of connections accepted so far.
+func (s *Server) Connections() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dialed
+}
+
+// Hang makes the server never answer the end of the message data.
+func (s *Server) Hang() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hang = true
+}
+
+// DropConnections closes the open connections, as servers do with idle ones.
+func (s *Server) DropConnections() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for c := range s.conns {
+		c.Close()
+	}
+}
+
+func (s *Server) Close() {
+	s.listener.Close()
+	s.DropConnections()
+}
+
+func (s *Server) serve() {
+	for {
+		c, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns[c] = true
+		s.dialed++
+		s.mu.Unlock()
+		go s.handle(c)
+	}
+}
+
+func (s *Server) handle(c net.Conn) {
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, c)
+		s.mu.Unlock()
+		c.Close()
+	}()
+
+	conn := textproto.NewConn(c)
+	reply := func(code int, text string) bool {
+		return conn.PrintfLine("%d %s", code, text) == nil
+	}
+	if !reply(220, "mailtest ESMTP") {
+		return
+	}
+
+	var msg mail.Message
+	for {
+		line, err := conn.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			reply(250, "mailtest")
+		case "MAIL":
+			msg = mail.Message{From: address(arg)}
+			reply(250, "OK")
+		case "RCPT":
+			msg.To = append(msg.To, address(arg))
+			reply(250, "OK")
+		case "DATA":
+			reply(354, "End data with <CR><LF>.<CR><LF>")
+			if msg.Data, err = conn.ReadDotBytes(); err != nil {
+				return
+			}
+			s.mu.Lock()
+			hang := s.hang
+			if !hang {
+				s.messages = append(s.messages, msg)
+			}
+			s.mu.Unlock()
+			if hang {
+				// until the client gives up
+				io.Copy(io.Discard, c)
+				return
+			}
+			reply(250, "OK: queued as "+strconv.Itoa(len(s.Messages())))
+		case "RSET":
+			msg = mail.Message{}
+			reply(250, "OK")
+		case "NOOP":
+			reply(250, "OK")
+		case "QUIT":
+			reply(221, "Bye")
+			return
+		default:
+			reply(502, "Command not implemented")
+		}
+	}
+}
+
+// address returns the address of FROM:<addr> and TO:<addr>.
+func address(arg string) string {
+	_, addr, _ := strings.Cut(arg, ":")
+	addr, _, _ = strings.Cut(addr, " ")
+	return strings.Trim(addr, "<>")
+}
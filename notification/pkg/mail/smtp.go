@@ -0,0 +1,278 @@
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultMaxConns    = 4
+	DefaultIdleTimeout = time.Minute
+)
+
+var ErrClosed = errors.New("mail transport closed")
+
+// ErrMaybeSent wraps failures after the whole message was handed to the
+// server: it may have accepted the message before the reply was lost, so
+// sending again could deliver it twice.
+var ErrMaybeSent = errors.New("message may have been sent")
+
+// SMTP sends messages over a pool of connections to one server. A
+// connection is reused for the next message until it has been idle for
+// longer than the idle timeout or the server drops it.
+type SMTP struct {
+	host        string
+	addr        string
+	implicitTLS bool // port 465 speaks TLS from the start, others upgrade with STARTTLS
+	auth        smtp.Auth
+	idleTimeout time.Duration
+	dialer      net.Dialer
+
+	slots chan struct{} // one per connection in use
+
+	mu     sync.Mutex
+	idle   []*conn
+	closed bool
+}
+
+// NewSMTP creates a transport with at most maxConns connections. Messages
+// are sent without authentication if user is empty.
+func NewSMTP(host string, port int, user, pass string, maxConns int, idleTimeout time.Duration) *SMTP {
+	if maxConns <= 0 {
+		maxConns = DefaultMaxConns
+	}
+	if idleTimeout <= 0 {
+		idleTimeout = DefaultIdleTimeout
+	}
+	s := &SMTP{
+		host:        host,
+		addr:        net.JoinHostPort(host, strconv.Itoa(port)),
+		implicitTLS: port == 465,
+		idleTimeout: idleTimeout,
+		slots:       make(chan struct{}, maxConns),
+	}
+	if user != "" {
+		// net/smtp refuses to send the password over plain text, except to localhost
+		s.auth = smtp.PlainAuth("", user, pass, host)
+	}
+	return s
+}
+
+func (s *SMTP) Send(ctx context.Context, from string, to []string, msg io.WriterTo) error {
+	var written bool
+	err := s.do(ctx, func(c *smtp.Client) error {
+		if err := c.Mail(from); err != nil {
+			return err
+		}
+		for _, addr := range to {
+			if err := c.Rcpt(addr); err != nil {
+				return err
+			}
+		}
+		w, err := c.Data()
+		if err != nil {
+			return err
+		}
+		if _, err := msg.WriteTo(w); err != nil {
+			w.Close()
+			return err
+		}
+		written = true
+		return w.Close()
+	})
+
+	// only a reply tells whether the server took the message
+	var reply *textproto.Error
+	if err != nil && written && !errors.As(err, &reply) {
+		return fmt.Errorf("%w: %w", ErrMaybeSent, err)
+	}
+	return err
+}
+
+// Ping checks that a connection to the server can be opened or reused.
+func (s *SMTP) Ping(ctx context.Context) error {
+	return s.do(ctx, (*smtp.Client).Noop)
+}
+
+// Close says goodbye on the idle connections. Connections in use are closed
+// when their message is sent.
+func (s *SMTP) Close() error {
+	s.mu.Lock()
+	idle := s.idle
+	s.idle, s.closed = nil, true
+	s.mu.Unlock()
+
+	for _, c := range idle {
+		c.quit()
+	}
+	return nil
+}
+
+// do runs f on a pooled connection, waiting for one to be free.
+func (s *SMTP) do(ctx context.Context, f func(*smtp.Client) error) error {
+	select {
+	case s.slots <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-s.slots }()
+
+	c, err := s.get(ctx)
+	if err != nil {
+		return err
+	}
+	err = c.do(ctx, f)
+
+	// a rejection leaves the connection usable, it is reset before the next message
+	var reply *textproto.Error
+	if c.broken || err != nil && !errors.As(err, &reply) {
+		c.client.Close()
+		return err
+	}
+	s.put(c)
+	return err
+}
+
+// get returns the most recently used idle connection that is still alive,
+// or dials a new one.
+func (s *SMTP) get(ctx context.Context) (*conn, error) {
+	for {
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			return nil, ErrClosed
+		}
+		n := len(s.idle)
+		if n == 0 {
+			s.mu.Unlock()
+			return s.dial(ctx)
+		}
+		c := s.idle[n-1]
+		s.idle = s.idle[:n-1]
+		s.mu.Unlock()
+
+		if time.Since(c.idleSince) > s.idleTimeout {
+			c.quit()
+			continue
+		}
+		// the server may have dropped the connection while it was idle
+		err := c.do(ctx, (*smtp.Client).Reset)
+		if err == nil && !c.broken {
+			return c, nil
+		}
+		c.client.Close()
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (s *SMTP) put(c *conn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		go c.quit()
+		return
+	}
+	c.idleSince = time.Now()
+	s.idle = append(s.idle, c)
+}
+
+func (s *SMTP) dial(ctx context.Context) (*conn, error) {
+	nc, err := s.dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return nil, err
+	}
+	if s.implicitTLS {
+		tc := tls.Client(nc, &tls.Config{ServerName: s.host})
+		if err := tc.HandshakeContext(ctx); err != nil {
+			nc.Close()
+			return nil, err
+		}
+		nc = tc
+	}
+
+	c := &conn{net: nc}
+	err = c.do(ctx, func(*smtp.Client) error {
+		client, err := smtp.NewClient(nc, s.host)
+		if err != nil {
+			return err
+		}
+		c.client = client
+		if ok, _ := client.Extension("STARTTLS"); ok && !s.implicitTLS {
+			if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+				return err
+			}
+		}
+		if ok, _ := client.Extension("AUTH"); ok && s.auth != nil {
+			return client.Auth(s.auth)
+		}
+		return nil
+	})
+	if err == nil && c.broken {
+		err = ctx.Err()
+	}
+	if err != nil {
+		nc.Close()
+		return nil, fmt.Errorf("failed to connect to %s: %w", s.addr, err)
+	}
+	return c, nil
+}
+
+type conn struct {
+	net       net.Conn // deadlines set here apply to the TLS connection on top
+	client    *smtp.Client
+	idleSince time.Time
+	broken    bool // the deadline was expired by a cancellation
+}
+
+// do runs f within the deadline of ctx. Canceling ctx expires the deadline
+// of the connection, so that f returns at once instead of waiting for the
+// server, and the connection must not be reused after that.
+func (c *conn) do(ctx context.Context, f func(*smtp.Client) error) error {
+	deadline, _ := ctx.Deadline()
+	c.net.SetDeadline(deadline)
+	stop := context.AfterFunc(ctx, func() {
+		c.net.SetDeadline(time.Unix(1, 0))
+	})
+	err := f(c.client)
+	if !stop() {
+		c.broken = true
+	}
+	if err != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// quit says goodbye to the server, not waiting for long.
+func (c *conn) quit() {
+	c.net.SetDeadline(time.Now().Add(time.Second))
+	c.client.Quit()
+	c.client.Close()
+}
+
+// Temporary reports whether sending may succeed if retried: the server
+// answered with a 4xx code or the connection failed before the message was
+// handed over.
+func Temporary(err error) bool {
+	if errors.Is(err, ErrMaybeSent) {
+		return false
+	}
+	var reply *textproto.Error
+	if errors.As(err, &reply) {
+		return reply.Code >= 400 && reply.Code < 500
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/segmentio/kafka-go"
+)
+
+var (
+	kafkaLag = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kafka_consumer_lag",
+		Help: "Number of messages behind the partition high watermark.",
+	}, []string{"topic", "partition"})
+
+	kafkaDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "kafka_handler_duration_seconds",
+		Help:    "Time spent handling a consumed message.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"topic"})
+
+	kafkaFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "kafka_handler_failures_total",
+		Help: "Number of consumed messages the handler failed to process.",
+	}, []string{"topic"})
+)
+
+// ObserveMessage records lag, handling time and outcome of a consumed message.
+func ObserveMessage(m kafka.Message, start time.Time, err error) {
+	kafkaLag.WithLabelValues(m.Topic, strconv.Itoa(m.Partition)).Set(float64(m.HighWaterMark - m.Offset - 1))
+	kafkaDuration.WithLabelValues(m.Topic).Observe(time.Since(start).Seconds())
+	if err != nil {
+		kafkaFailures.WithLabelValues(m.Topic).Inc()
+	}
+}
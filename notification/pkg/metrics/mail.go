@@ -0,0 +1,27 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	emailsSent = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "notification_emails_sent_total",
+		Help: "Number of emails delivered to the SMTP server, by type.",
+	}, []string{"type"})
+
+	emailsFailed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "notification_emails_failed_total",
+		Help: "Number of emails that failed to send, by type.",
+	}, []string{"type"})
+)
+
+// ObserveEmail records the outcome of sending an email of the given type.
+func ObserveEmail(kind string, err error) {
+	if err != nil {
+		emailsFailed.WithLabelValues(kind).Inc()
+		return
+	}
+	emailsSent.WithLabelValues(kind).Inc()
+}
@@ -0,0 +1,27 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	pushSent = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "notification_push_sent_total",
+		Help: "Number of Web Push messages accepted by push services, by type.",
+	}, []string{"type"})
+
+	pushFailed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "notification_push_failed_total",
+		Help: "Number of Web Push messages that failed to send, by type.",
+	}, []string{"type"})
+)
+
+// ObservePush records the outcome of sending a Web Push message of the given type.
+func ObservePush(kind string, err error) {
+	if err != nil {
+		pushFailed.WithLabelValues(kind).Inc()
+		return
+	}
+	pushSent.WithLabelValues(kind).Inc()
+}
@@ -0,0 +1,27 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	telegramSent = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "notification_telegram_sent_total",
+		Help: "Number of Telegram messages accepted by the Bot API, by type.",
+	}, []string{"type"})
+
+	telegramFailed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "notification_telegram_failed_total",
+		Help: "Number of Telegram messages that failed to send, by type.",
+	}, []string{"type"})
+)
+
+// ObserveTelegram records the outcome of sending a Telegram message of the given type.
+func ObserveTelegram(kind string, err error) {
+	if err != nil {
+		telegramFailed.WithLabelValues(kind).Inc()
+		return
+	}
+	telegramSent.WithLabelValues(kind).Inc()
+}
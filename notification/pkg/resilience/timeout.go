@@ -0,0 +1,28 @@
+package resilience
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// Timeout bounds every call with a deadline of d. A shorter deadline already
+// set on the context is kept.
+func Timeout(d time.Duration) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if d > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, d)
+			defer cancel()
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
@@ -0,0 +1,249 @@
+// Package telegram is a minimal Bot API client: sending messages and long
+// polling for updates.
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const DefaultAPIURL = "https://api.telegram.org"
+
+// DefaultMaxRetryAfter is the longest flood wait the client sleeps through.
+// Longer waits are returned to the caller as errors.
+const DefaultMaxRetryAfter = 30 * time.Second
+
+// DefaultRequestTimeout bounds a single Bot API call. Long polls get their
+// poll timeout on top of it.
+const DefaultRequestTimeout = 10 * time.Second
+
+type User struct {
+	ID           int64  `json:"id"`
+	Username     string `json:"username,omitempty"`
+	LanguageCode string `json:"language_code,omitempty"` // IETF tag of the user's app, if known
+}
+
+// ChatPrivate is the type of a one-to-one chat with a user.
+const ChatPrivate = "private"
+
+type Chat struct {
+	ID   int64  `json:"id"`
+	Type string `json:"type"`
+}
+
+type Message struct {
+	MessageID int64  `json:"message_id"`
+	From      *User  `json:"from,omitempty"`
+	Chat      Chat   `json:"chat"`
+	Text      string `json:"text,omitempty"`
+}
+
+type Update struct {
+	UpdateID int64    `json:"update_id"`
+	Message  *Message `json:"message,omitempty"`
+}
+
+// Error is an error answered by the Bot API.
+type Error struct {
+	Code        int
+	Description string
+	RetryAfter  time.Duration // set for 429 Too Many Requests
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("telegram: %d %s", e.Code, e.Description)
+}
+
+// IsBlocked reports whether the user blocked the bot or the chat is gone,
+// so retrying the message is pointless.
+func IsBlocked(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.Code == http.StatusForbidden
+}
+
+type response struct {
+	OK          bool            `json:"ok"`
+	Result      json.RawMessage `json:"result,omitempty"`
+	ErrorCode   int             `json:"error_code,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Parameters  *struct {
+		RetryAfter int `json:"retry_after,omitempty"`
+	} `json:"parameters,omitempty"`
+}
+
+type Client struct {
+	baseURL        string
+	token          string
+	http           *http.Client
+	throttle       *throttle
+	MaxRetryAfter  time.Duration
+	RequestTimeout time.Duration
+}
+
+// New creates a client that sends at most perSecond messages. The Bot API
+// allows about 30 per second across all chats.
+func New(baseURL, token string, perSecond int) *Client {
+	return &Client{
+		baseURL:        baseURL,
+		token:          token,
+		http:           &http.Client{},
+		throttle:       newThrottle(perSecond),
+		MaxRetryAfter:  DefaultMaxRetryAfter,
+		RequestTimeout: DefaultRequestTimeout,
+	}
+}
+
+// SendMessage sends text with HTML formatting to a chat.
+func (c *Client) SendMessage(ctx context.Context, chatID int64, text string) error {
+	params := map[string]any{
+		"chat_id":    chatID,
+		"text":       text,
+		"parse_mode": "HTML",
+		"link_preview_options": map[string]any{
+			"is_disabled": true,
+		},
+	}
+	for {
+		if err := c.throttle.Wait(ctx); err != nil {
+			return err
+		}
+		err := c.call(ctx, "sendMessage", params, nil, c.RequestTimeout)
+
+		var apiErr *Error
+		if !errors.As(err, &apiErr) || apiErr.Code != http.StatusTooManyRequests || apiErr.RetryAfter > c.MaxRetryAfter {
+			return err
+		}
+		timer := time.NewTimer(apiErr.RetryAfter)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// GetUpdates waits up to timeout for updates starting at offset. Passing the
+// next offset confirms the previous updates to the server.
+func (c *Client) GetUpdates(ctx context.Context, offset int64, timeout time.Duration) ([]Update, error) {
+	params := map[string]any{
+		"offset":          offset,
+		"timeout":         int(timeout.Seconds()),
+		"allowed_updates": []string{"message"},
+	}
+	var updates []Update
+	if err := c.call(ctx, "getUpdates", params, &updates, timeout+c.RequestTimeout); err != nil {
+		return nil, err
+	}
+	return updates, nil
+}
+
+func (c *Client) call(ctx context.Context, method string, params, result any, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	body, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/bot"+c.token+"/"+method, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		// the url holds the token, so don't let it into logs
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("telegram %s: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	var res response
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return fmt.Errorf("telegram %s: invalid response with status %s: %w", method, resp.Status, err)
+	}
+	if !res.OK {
+		apiErr := &Error{Code: res.ErrorCode, Description: res.Description}
+		if apiErr.Code == 0 {
+			apiErr.Code = resp.StatusCode
+		}
+		if res.Parameters != nil {
+			apiErr.RetryAfter = time.Duration(res.Parameters.RetryAfter) * time.Second
+		}
+		return apiErr
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(res.Result, result)
+}
+
+// throttle spaces out calls evenly.
+type throttle struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func newThrottle(perSecond int) *throttle {
+	t := &throttle{}
+	if perSecond > 0 {
+		t.interval = time.Second / time.Duration(perSecond)
+	}
+	return t
+}
+
+func (t *throttle) Wait(ctx context.Context) error {
+	t.mu.Lock()
+	now := time.Now()
+	at := t.next
+	if at.Before(now) {
+		at = now
+	}
+	t.next = at.Add(t.interval)
+	t.mu.Unlock()
+
+	d := at.Sub(now)
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// ParseStart returns the parameter of a "/start <payload>" command sent
+// from a deep link. ok is false for other texts.
+func ParseStart(text string) (payload string, ok bool) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return "", false
+	}
+	// groups send "/start@BotName"
+	cmd, _, _ := strings.Cut(fields[0], "@")
+	if cmd != "/start" {
+		return "", false
+	}
+	if len(fields) > 1 {
+		payload = fields[1]
+	}
+	return payload, true
+}
@@ -0,0 +1,124 @@
+package telegram_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"FinanceTracker/notification/pkg/telegram"
+	"FinanceTracker/notification/pkg/telegram/telegramtest"
+)
+
+func TestClient_SendMessage(t *testing.T) {
+	testCases := []struct {
+		name        string
+		setup       func(s *telegramtest.Server)
+		wantSent    int
+		wantCode    int
+		wantBlocked bool
+	}{
+		{
+			name:     "sent",
+			wantSent: 1,
+		},
+		{
+			name:     "retries_after_flood_wait",
+			setup:    func(s *telegramtest.Server) { s.RateLimit(2, 0) },
+			wantSent: 1,
+		},
+		{
+			name:     "gives_up_on_long_flood_wait",
+			setup:    func(s *telegramtest.Server) { s.RateLimit(1, 60) },
+			wantCode: http.StatusTooManyRequests,
+		},
+		{
+			name:        "blocked",
+			setup:       func(s *telegramtest.Server) { s.Block(42) },
+			wantCode:    http.StatusForbidden,
+			wantBlocked: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := telegramtest.NewServer(t)
+			if tc.setup != nil {
+				tc.setup(server)
+			}
+			client := telegram.New(server.URL, telegramtest.Token, 0)
+
+			err := client.SendMessage(context.Background(), 42, "<b>hi</b>")
+
+			assert.Len(t, server.Sent(), tc.wantSent)
+			if tc.wantCode == 0 {
+				require.NoError(t, err)
+				assert.Equal(t, telegramtest.SentMessage{ChatID: 42, Text: "<b>hi</b>", ParseMode: "HTML"}, server.Sent()[0])
+				return
+			}
+			var apiErr *telegram.Error
+			require.ErrorAs(t, err, &apiErr)
+			assert.Equal(t, tc.wantCode, apiErr.Code)
+			assert.Equal(t, tc.wantBlocked, telegram.IsBlocked(err))
+		})
+	}
+}
+
+func TestClient_SendMessage_Hung(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(release) })
+	client := telegram.New(server.URL, telegramtest.Token, 0)
+	client.RequestTimeout = 50 * time.Millisecond
+
+	err := client.SendMessage(context.Background(), 42, "hi")
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestClient_GetUpdates(t *testing.T) {
+	server := telegramtest.NewServer(t)
+	client := telegram.New(server.URL, telegramtest.Token, 0)
+	ctx := context.Background()
+
+	server.SendText(42, "alice", "/start abc")
+	updates, err := client.GetUpdates(ctx, 0, time.Second)
+	require.NoError(t, err)
+	require.Len(t, updates, 1)
+	assert.Equal(t, "/start abc", updates[0].Message.Text)
+	assert.Equal(t, int64(42), updates[0].Message.Chat.ID)
+
+	// the next offset confirms the update
+	updates, err = client.GetUpdates(ctx, updates[0].UpdateID+1, 0)
+	require.NoError(t, err)
+	assert.Empty(t, updates)
+}
+
+func TestParseStart(t *testing.T) {
+	testCases := []struct {
+		text        string
+		wantPayload string
+		wantOK      bool
+	}{
+		{text: "/start abc", wantPayload: "abc", wantOK: true},
+		{text: "/start", wantOK: true},
+		{text: "/start@FinanceTrackerBot abc", wantPayload: "abc", wantOK: true},
+		{text: "/started", wantOK: false},
+		{text: "hello", wantOK: false},
+		{text: "", wantOK: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.text, func(t *testing.T) {
+			payload, ok := telegram.ParseStart(tc.text)
+			assert.Equal(t, tc.wantOK, ok)
+			assert.Equal(t, tc.wantPayload, payload)
+		})
+	}
+}
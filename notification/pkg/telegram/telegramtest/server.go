@@ -0,0 +1,187 @@
+// Package telegramtest provides a fake Bot API server for tests.
+package telegramtest
+
+import (
+	"FinanceTracker/notification/pkg/telegram"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const Token = "123456:test-token"
+
+// SentMessage is a sendMessage call accepted by the server.
+type SentMessage struct {
+	ChatID    int64  `json:"chat_id"`
+	Text      string `json:"text"`
+	ParseMode string `json:"parse_mode"`
+}
+
+// Server answers sendMessage and getUpdates for Token.
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	sent        []SentMessage
+	updates     []telegram.Update
+	nextUpdate  int64
+	newUpdate   chan struct{}
+	rateLimited int
+	retryAfter  int
+	blocked     map[int64]bool
+}
+
+// NewServer starts a server that is closed when the test ends.
+func NewServer(t testing.TB) *Server {
+	s := &Server{
+		nextUpdate: 1,
+		newUpdate:  make(chan struct{}),
+		blocked:    make(map[int64]bool),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Sent returns the messages delivered so far.
+func (s *Server) Sent() []SentMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]SentMessage(nil), s.sent...)
+}
+
+// SendText queues a text message from a user to the bot.
+func (s *Server) SendText(chatID int64, username, text string) {
+	s.SendTextIn(chatID, username, "", text)
+}
+
+// SendTextIn is SendText from a user whose app is in languageCode.
+func (s *Server) SendTextIn(chatID int64, username, languageCode, text string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.updates = append(s.updates, telegram.Update{
+		UpdateID: s.nextUpdate,
+		Message: &telegram.Message{
+			MessageID: s.nextUpdate,
+			From:      &telegram.User{ID: chatID, Username: username, LanguageCode: languageCode},
+			Chat:      telegram.Chat{ID: chatID, Type: telegram.ChatPrivate},
+			Text:      text,
+		},
+	})
+	s.nextUpdate++
+	close(s.newUpdate)
+	s.newUpdate = make(chan struct{})
+}
+
+// RateLimit answers the next n sendMessage calls with 429 and retry_after.
+func (s *Server) RateLimit(n, retryAfter int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rateLimited = n
+	s.retryAfter = retryAfter
+}
+
+// Block makes the chat answer 403 as if the user blocked the bot.
+func (s *Server) Block(chatID int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blocked[chatID] = true
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	method, ok := strings.CutPrefix(r.URL.Path, "/bot"+Token+"/")
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "Unauthorized", 0)
+		return
+	}
+	switch method {
+	case "sendMessage":
+		s.sendMessage(w, r)
+	case "getUpdates":
+		s.getUpdates(w, r)
+	default:
+		writeError(w, http.StatusNotFound, "Not Found: method not found", 0)
+	}
+}
+
+func (s *Server) sendMessage(w http.ResponseWriter, r *http.Request) {
+	var msg SentMessage
+	if err := json.NewDecoder(r.Body).Decode(&msg); err != nil || msg.ChatID == 0 || msg.Text == "" {
+		writeError(w, http.StatusBadRequest, "Bad Request: chat_id and text are required", 0)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.rateLimited > 0 {
+		s.rateLimited--
+		writeError(w, http.StatusTooManyRequests, "Too Many Requests: retry later", s.retryAfter)
+		return
+	}
+	if s.blocked[msg.ChatID] {
+		writeError(w, http.StatusForbidden, "Forbidden: bot was blocked by the user", 0)
+		return
+	}
+	s.sent = append(s.sent, msg)
+	writeResult(w, telegram.Message{MessageID: int64(len(s.sent)), Chat: telegram.Chat{ID: msg.ChatID, Type: telegram.ChatPrivate}, Text: msg.Text})
+}
+
+func (s *Server) getUpdates(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Offset  int64 `json:"offset"`
+		Timeout int   `json:"timeout"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Bad Request: invalid body", 0)
+		return
+	}
+
+	timeout := time.NewTimer(time.Duration(req.Timeout) * time.Second)
+	defer timeout.Stop()
+	for {
+		s.mu.Lock()
+		// like the real API, asking for an offset confirms what came before it
+		pending := s.updates[:0]
+		for _, u := range s.updates {
+			if u.UpdateID >= req.Offset {
+				pending = append(pending, u)
+			}
+		}
+		s.updates = pending
+		res := append([]telegram.Update{}, pending...)
+		wait := s.newUpdate
+		s.mu.Unlock()
+
+		if len(res) > 0 {
+			writeResult(w, res)
+			return
+		}
+		select {
+		case <-wait:
+		case <-timeout.C:
+			writeResult(w, res)
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func writeResult(w http.ResponseWriter, result any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": result})
+}
+
+func writeError(w http.ResponseWriter, code int, description string, retryAfter int) {
+	body := map[string]any{"ok": false, "error_code": code, "description": description}
+	if retryAfter > 0 {
+		body["parameters"] = map[string]int{"retry_after": retryAfter}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(body)
+}
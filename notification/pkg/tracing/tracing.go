@@ -0,0 +1,72 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+type Provider struct {
+	tp *sdktrace.TracerProvider
+}
+
+// MustNew configures the global tracer provider and propagator.
+// With ExporterNone spans are still propagated but never exported.
+func MustNew(ctx context.Context, service, exporter, endpoint string) *Provider {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", service))),
+	}
+
+	exp, err := newExporter(ctx, exporter, endpoint)
+	if err != nil {
+		log.Fatalf("failed to create trace exporter: %s", err)
+	}
+	if exp != nil {
+		opts = append(opts, sdktrace.WithBatcher(exp))
+	}
+
+	tp := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(tp)
+
+	return &Provider{tp: tp}
+}
+
+func newExporter(ctx context.Context, exporter, endpoint string) (sdktrace.SpanExporter, error) {
+	switch exporter {
+	case ExporterNone, "":
+		return nil, nil
+	case ExporterStdout:
+		return stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case ExporterOTLP:
+		return otlptracegrpc.New(ctx,
+			otlptracegrpc.WithEndpoint(endpoint),
+			otlptracegrpc.WithInsecure(),
+		)
+	default:
+		return nil, fmt.Errorf("unknown exporter %q", exporter)
+	}
+}
+
+// Shutdown flushes pending spans and stops the exporter.
+func (p *Provider) Shutdown(ctx context.Context) error {
+	return p.tp.Shutdown(ctx)
+}
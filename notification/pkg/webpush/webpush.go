@@ -0,0 +1,320 @@
+// Package webpush sends Web Push messages: payloads are encrypted with
+// aes128gcm (RFC 8291) and requests are signed with VAPID (RFC 8292).
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// recordSize is the record size announced in the header. The payload
+	// always fits in a single record.
+	recordSize = 4096
+	// MaxPayload is the largest payload push services must accept.
+	MaxPayload = recordSize - 16 - 1 - 86
+
+	vapidTTL = 12 * time.Hour
+)
+
+var (
+	ErrInvalidSubscription = errors.New("invalid push subscription")
+	ErrPayloadTooLarge     = errors.New("push payload too large")
+)
+
+// Subscription is PushSubscription.toJSON() of the browser.
+type Subscription struct {
+	Endpoint string
+	P256dh   string // base64url public key of the user agent
+	Auth     string // base64url auth secret
+}
+
+// Error is an error answered by a push service.
+type Error struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("push service answered %d: %s", e.StatusCode, e.Body)
+}
+
+// IsGone reports whether the subscription expired or was revoked, so it
+// must not be used again.
+func IsGone(err error) bool {
+	var pushErr *Error
+	return errors.As(err, &pushErr) && (pushErr.StatusCode == http.StatusNotFound || pushErr.StatusCode == http.StatusGone)
+}
+
+type Client struct {
+	HTTPClient *http.Client
+	privateKey *ecdsa.PrivateKey
+	publicKey  string
+	subject    string
+	ttl        time.Duration
+}
+
+// New creates a client from a VAPID key pair in base64url: the private key
+// is the raw 32-byte scalar and the public key is the uncompressed point.
+// subject is a mailto: or https: contact for push service operators.
+func New(publicKey, privateKey, subject string, ttl time.Duration) (*Client, error) {
+	d, err := decodeBase64(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vapid private key: %w", err)
+	}
+	key, err := ecdh.P256().NewPrivateKey(d)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vapid private key: %w", err)
+	}
+	pub := key.PublicKey().Bytes()
+	if base64.RawURLEncoding.EncodeToString(pub) != publicKey {
+		return nil, errors.New("vapid public key does not match the private key")
+	}
+
+	return &Client{
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+		privateKey: &ecdsa.PrivateKey{
+			PublicKey: ecdsa.PublicKey{
+				Curve: elliptic.P256(),
+				X:     new(big.Int).SetBytes(pub[1:33]),
+				Y:     new(big.Int).SetBytes(pub[33:]),
+			},
+			D: new(big.Int).SetBytes(d),
+		},
+		publicKey: publicKey,
+		subject:   subject,
+		ttl:       ttl,
+	}, nil
+}
+
+// GenerateVAPIDKeys returns a new key pair for New.
+func GenerateVAPIDKeys() (publicKey, privateKey string, err error) {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()),
+		base64.RawURLEncoding.EncodeToString(key.Bytes()), nil
+}
+
+// PublicKey is the applicationServerKey for PushManager.subscribe.
+func (c *Client) PublicKey() string {
+	return c.publicKey
+}
+
+// Send encrypts payload for the subscription and posts it to its push service.
+func (c *Client) Send(ctx context.Context, sub Subscription, payload []byte) error {
+	body, err := Encrypt(sub, payload)
+	if err != nil {
+		return err
+	}
+	endpoint, err := url.Parse(sub.Endpoint)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSubscription, err)
+	}
+	token, err := c.vapidToken(endpoint.Scheme + "://" + endpoint.Host)
+	if err != nil {
+		return fmt.Errorf("failed to sign vapid token: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("TTL", strconv.Itoa(int(c.ttl.Seconds())))
+	req.Header.Set("Authorization", "vapid t="+token+", k="+c.publicKey)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return &Error{StatusCode: resp.StatusCode, Body: string(msg)}
+}
+
+// vapidToken signs a JWT for the origin of a push service.
+func (c *Client) vapidToken(audience string) (string, error) {
+	claims := jwt.MapClaims{
+		"aud": audience,
+		"exp": time.Now().Add(vapidTTL).Unix(),
+		"sub": c.subject,
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodES256, claims).SignedString(c.privateKey)
+}
+
+// Encrypt builds an aes128gcm body of a single record for the subscription.
+func Encrypt(sub Subscription, payload []byte) ([]byte, error) {
+	if len(payload) > MaxPayload {
+		return nil, ErrPayloadTooLarge
+	}
+	uaPublic, authSecret, err := sub.keys()
+	if err != nil {
+		return nil, err
+	}
+
+	asPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	asPublic := asPrivate.PublicKey().Bytes()
+	ecdhSecret, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, err
+	}
+	cek, nonce, err := deriveKeys(ecdhSecret, authSecret, uaPublic.Bytes(), asPublic, salt)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(cek)
+	if err != nil {
+		return nil, err
+	}
+
+	// the 0x02 delimiter marks the last record
+	plaintext := append(append(make([]byte, 0, len(payload)+1), payload...), 0x02)
+
+	header := make([]byte, 0, 16+4+1+len(asPublic))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, recordSize)
+	header = append(header, byte(len(asPublic)))
+	header = append(header, asPublic...)
+	return gcm.Seal(header, nonce, plaintext, nil), nil
+}
+
+// Decrypt reverses Encrypt with the user agent's keys. Push services don't
+// need it: it is for tests and for stand-in user agents.
+func Decrypt(uaPrivate *ecdh.PrivateKey, authSecret, body []byte) ([]byte, error) {
+	if len(body) < 21 {
+		return nil, errors.New("body too short")
+	}
+	salt := body[:16]
+	idLen := int(body[20])
+	if len(body) < 21+idLen {
+		return nil, errors.New("body too short")
+	}
+	asPublic, err := ecdh.P256().NewPublicKey(body[21 : 21+idLen])
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender key: %w", err)
+	}
+
+	ecdhSecret, err := uaPrivate.ECDH(asPublic)
+	if err != nil {
+		return nil, err
+	}
+	cek, nonce, err := deriveKeys(ecdhSecret, authSecret, uaPrivate.PublicKey().Bytes(), asPublic.Bytes(), salt)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(cek)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := gcm.Open(nil, nonce, body[21+idLen:], nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// strip the padding and the delimiter
+	end := bytes.LastIndexByte(plaintext, 0x02)
+	if end < 0 || len(bytes.Trim(plaintext[end+1:], "\x00")) != 0 {
+		return nil, errors.New("invalid padding")
+	}
+	return plaintext[:end], nil
+}
+
+// deriveKeys implements the key derivation of RFC 8291 section 3.4.
+func deriveKeys(ecdhSecret, authSecret, uaPublic, asPublic, salt []byte) (cek, nonce []byte, err error) {
+	prkKey, err := hkdf.Extract(sha256.New, ecdhSecret, authSecret)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyInfo := "WebPush: info\x00" + string(uaPublic) + string(asPublic)
+	ikm, err := hkdf.Expand(sha256.New, prkKey, keyInfo, 32)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	prk, err := hkdf.Extract(sha256.New, ikm, salt)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cek, err = hkdf.Expand(sha256.New, prk, "Content-Encoding: aes128gcm\x00", 16); err != nil {
+		return nil, nil, err
+	}
+	if nonce, err = hkdf.Expand(sha256.New, prk, "Content-Encoding: nonce\x00", 12); err != nil {
+		return nil, nil, err
+	}
+	return cek, nonce, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Validate checks that the keys of the subscription are usable.
+func (s Subscription) Validate() error {
+	_, _, err := s.keys()
+	return err
+}
+
+func (s Subscription) keys() (*ecdh.PublicKey, []byte, error) {
+	p256dh, err := decodeBase64(s.P256dh)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: p256dh: %w", ErrInvalidSubscription, err)
+	}
+	uaPublic, err := ecdh.P256().NewPublicKey(p256dh)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: p256dh: %w", ErrInvalidSubscription, err)
+	}
+	auth, err := decodeBase64(s.Auth)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: auth: %w", ErrInvalidSubscription, err)
+	}
+	if len(auth) != 16 {
+		return nil, nil, fmt.Errorf("%w: auth must be 16 bytes", ErrInvalidSubscription)
+	}
+	return uaPublic, auth, nil
+}
+
+// decodeBase64 accepts base64url with or without padding, as browsers differ.
+func decodeBase64(s string) ([]byte, error) {
+	if b, err := base64.RawURLEncoding.DecodeString(s); err == nil {
+		return b, nil
+	}
+	return base64.URLEncoding.DecodeString(s)
+}
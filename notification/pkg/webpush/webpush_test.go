@@ -0,0 +1,87 @@
+package webpush_test
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"FinanceTracker/notification/pkg/webpush"
+	"FinanceTracker/notification/pkg/webpush/webpushtest"
+)
+
+func mustDecode(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+// TestDecrypt_RFC8291 checks the key derivation against the example of RFC 8291 appendix A.
+func TestDecrypt_RFC8291(t *testing.T) {
+	uaPrivate, err := ecdh.P256().NewPrivateKey(mustDecode(t, "q1dXpw3UpT5VOmu_cf_v6ih07Aems3njxI-JWgLcM94"))
+	require.NoError(t, err)
+	authSecret := mustDecode(t, "BTBZMqHH6r4Tts7J_aSIgg")
+	body := mustDecode(t, "DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN")
+
+	plaintext, err := webpush.Decrypt(uaPrivate, authSecret, body)
+	require.NoError(t, err)
+	assert.Equal(t, "When I grow up, I want to be a watermelon", string(plaintext))
+}
+
+func TestEncrypt_RoundTrip(t *testing.T) {
+	uaPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	authSecret := []byte("0123456789abcdef")
+	sub := webpush.Subscription{
+		Endpoint: "https://push.example.com/1",
+		P256dh:   base64.RawURLEncoding.EncodeToString(uaPrivate.PublicKey().Bytes()),
+		Auth:     base64.RawURLEncoding.EncodeToString(authSecret),
+	}
+
+	body, err := webpush.Encrypt(sub, []byte(`{"title":"hi"}`))
+	require.NoError(t, err)
+	plaintext, err := webpush.Decrypt(uaPrivate, authSecret, body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"title":"hi"}`, string(plaintext))
+
+	_, err = webpush.Encrypt(sub, make([]byte, webpush.MaxPayload+1))
+	assert.ErrorIs(t, err, webpush.ErrPayloadTooLarge)
+}
+
+func TestClient_Send(t *testing.T) {
+	public, private, err := webpush.GenerateVAPIDKeys()
+	require.NoError(t, err)
+	client, err := webpush.New(public, private, "mailto:admin@example.com", time.Hour)
+	require.NoError(t, err)
+
+	server := webpushtest.NewServer(t, public)
+	client.HTTPClient = server.Client()
+	sub := server.Subscribe(t)
+	ctx := context.Background()
+
+	require.NoError(t, client.Send(ctx, sub, []byte(`{"title":"hi"}`)))
+	received := server.Received()
+	require.Len(t, received, 1)
+	assert.Equal(t, `{"title":"hi"}`, string(received[0].Payload))
+	assert.Equal(t, "3600", received[0].TTL)
+
+	server.Expire(sub.Endpoint)
+	err = client.Send(ctx, sub, []byte(`{"title":"hi"}`))
+	assert.True(t, webpush.IsGone(err), "got %v", err)
+
+	// another VAPID key is rejected
+	otherPublic, otherPrivate, err := webpush.GenerateVAPIDKeys()
+	require.NoError(t, err)
+	other, err := webpush.New(otherPublic, otherPrivate, "mailto:admin@example.com", time.Hour)
+	require.NoError(t, err)
+	other.HTTPClient = server.Client()
+	err = other.Send(ctx, server.Subscribe(t), []byte(`{}`))
+	assert.False(t, webpush.IsGone(err))
+	assert.Error(t, err)
+}
@@ -0,0 +1,174 @@
+// Package webpushtest provides a stand-in push service for tests. It checks
+// VAPID signatures and decrypts what it receives.
+package webpushtest
+
+import (
+	"FinanceTracker/notification/pkg/webpush"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Message is a push accepted by the server.
+type Message struct {
+	Endpoint string
+	Payload  []byte
+	TTL      string
+}
+
+type subscription struct {
+	key  *ecdh.PrivateKey
+	auth []byte
+	gone bool
+}
+
+// Server is a TLS push service that accepts messages signed with one VAPID key.
+type Server struct {
+	*httptest.Server
+
+	vapidPublicKey string
+	vapidKey       *ecdsa.PublicKey
+
+	mu       sync.Mutex
+	subs     map[string]*subscription
+	received []Message
+}
+
+// NewServer starts a server that is closed when the test ends.
+func NewServer(t testing.TB, vapidPublicKey string) *Server {
+	t.Helper()
+	raw, err := base64.RawURLEncoding.DecodeString(vapidPublicKey)
+	if err != nil || len(raw) != 65 {
+		t.Fatalf("invalid vapid public key %q", vapidPublicKey)
+	}
+	s := &Server{
+		vapidPublicKey: vapidPublicKey,
+		vapidKey: &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(raw[1:33]),
+			Y:     new(big.Int).SetBytes(raw[33:]),
+		},
+		subs: make(map[string]*subscription),
+	}
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Subscribe creates a subscription as a browser would.
+func (s *Server) Subscribe(t testing.TB) webpush.Subscription {
+	t.Helper()
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth := make([]byte, 16)
+	rand.Read(auth)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	endpoint := fmt.Sprintf("%s/push/%d", s.URL, len(s.subs)+1)
+	s.subs[endpoint] = &subscription{key: key, auth: auth}
+	return webpush.Subscription{
+		Endpoint: endpoint,
+		P256dh:   base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()),
+		Auth:     base64.RawURLEncoding.EncodeToString(auth),
+	}
+}
+
+// Expire makes the subscription answer 410 Gone.
+func (s *Server) Expire(endpoint string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sub, ok := s.subs[endpoint]; ok {
+		sub.gone = true
+	}
+}
+
+// Received returns the decrypted messages so far.
+func (s *Server) Received() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message(nil), s.received...)
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || !strings.HasPrefix(r.URL.Path, "/push/") {
+		http.NotFound(w, r)
+		return
+	}
+	if err := s.checkVAPID(r); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if r.Header.Get("Content-Encoding") != "aes128gcm" || r.Header.Get("TTL") == "" {
+		http.Error(w, "aes128gcm body and TTL are required", http.StatusBadRequest)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	endpoint := s.URL + r.URL.Path
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sub, ok := s.subs[endpoint]
+	switch {
+	case !ok:
+		http.NotFound(w, r)
+		return
+	case sub.gone:
+		http.Error(w, "push subscription has unsubscribed or expired", http.StatusGone)
+		return
+	}
+
+	payload, err := webpush.Decrypt(sub.key, sub.auth, body)
+	if err != nil {
+		http.Error(w, "failed to decrypt: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.received = append(s.received, Message{Endpoint: endpoint, Payload: payload, TTL: r.Header.Get("TTL")})
+	w.WriteHeader(http.StatusCreated)
+}
+
+// checkVAPID verifies "Authorization: vapid t=<jwt>, k=<key>" of RFC 8292.
+func (s *Server) checkVAPID(r *http.Request) error {
+	params, ok := strings.CutPrefix(r.Header.Get("Authorization"), "vapid ")
+	if !ok {
+		return fmt.Errorf("missing vapid authorization")
+	}
+	var token, key string
+	for _, p := range strings.Split(params, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(p), "=")
+		switch name {
+		case "t":
+			token = value
+		case "k":
+			key = value
+		}
+	}
+	if key != s.vapidPublicKey {
+		return fmt.Errorf("unknown vapid key")
+	}
+
+	_, err := jwt.Parse(token, func(*jwt.Token) (any, error) { return s.vapidKey, nil },
+		jwt.WithValidMethods([]string{jwt.SigningMethodES256.Alg()}),
+		jwt.WithAudience(s.URL),
+		jwt.WithExpirationRequired(),
+	)
+	return err
+}
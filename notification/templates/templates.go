@@ -0,0 +1,23 @@
+// Package templates embeds the message templates of the notification service.
+package templates
+
+import "embed"
+
+// Telegram holds messages in the HTML subset of the Bot API, one file per
+// message type. Their texts come from the message catalog.
+//
+//go:embed telegram/*.html
+var Telegram embed.FS
+
+// Mail holds the emails. Every message type has an HTML and a plain text
+// template defining "content", rendered inside the "layout" of the same
+// format; partials are shared by all messages of a format.
+//
+//go:embed mail/*.html mail/*.txt
+var Mail embed.FS
+
+// I18n holds the message catalog of every locale, one JSON file per locale.
+// A value is either a string or an object of CLDR plural forms.
+//
+//go:embed i18n/*.json
+var I18n embed.FS
@@ -4,14 +4,19 @@ import (
 	"FinanceTracker/profile/internal/app"
 	"FinanceTracker/profile/internal/config"
 	"FinanceTracker/profile/internal/controller"
+	"FinanceTracker/profile/internal/producer"
 	"FinanceTracker/profile/internal/repo"
 	"FinanceTracker/profile/internal/service"
+	profilePb "FinanceTracker/profile/pkg/api/profile"
+	"FinanceTracker/profile/pkg/health"
 	log "FinanceTracker/profile/pkg/logger"
 	"FinanceTracker/profile/pkg/postgres"
+	"FinanceTracker/profile/pkg/tracing"
 	"FinanceTracker/profile/pkg/transaction"
 	"context"
 	"os/signal"
 	"syscall"
+	_ "time/tzdata" // preferences validate IANA timezones
 
 	"github.com/joho/godotenv"
 )
@@ -25,6 +30,8 @@ func main() {
 
 	ctx = log.WithLogger(ctx, logger)
 
+	tracer := tracing.MustNew(ctx, "profile", conf.Tracing.Exporter, conf.Tracing.Endpoint)
+
 	postgres := postgres.MustNew(conf.PostgresURL)
 	defer postgres.Close()
 	logger.Info("postgres connected")
@@ -33,18 +40,32 @@ func main() {
 	userRepo := repo.NewUserRepo(postgres)
 	avatarRepo := repo.MustAvatarRepo(ctx, conf.S3)
 
-	profileService := service.NewProfileService(userRepo, avatarRepo, txManager)
-	profileController := controller.NewProfileController(profileService)
+	producer := producer.New(conf.KafkaBrokers, conf.KafkaBatchTimeout)
+	profileService := service.NewProfileService(userRepo, avatarRepo, producer, txManager)
+	preferencesRepo := repo.NewPreferencesRepo(postgres)
+	preferencesService := service.NewPreferencesService(preferencesRepo, txManager)
+	telegramService := service.NewTelegramService(repo.NewTelegramRepo(postgres), preferencesRepo, txManager)
+	profileController := controller.NewProfileController(profileService, preferencesService, telegramService, conf.S3.PublicURL)
+
+	checks := health.New()
+	checks.Add("postgres", postgres.PingContext)
+	checks.Add("kafka", health.Kafka(conf.KafkaBrokers))
+	checks.Add("s3", avatarRepo.Ping)
+	// profiles and preferences only need the database, the gateway keeps
+	// routing to profile while avatar storage or kafka is down
+	checks.Service(profilePb.ProfileService_ServiceDesc.ServiceName, "postgres")
 
-	app := app.New(logger, profileController)
+	app := app.New(logger, checks, profileController)
 
 	consumer := controller.NewEventsController(conf.KafkaBrokers, conf.KafkaGroupID, profileService)
 
-	app.Start(conf.Host, conf.Port)
+	app.Start(conf.Host, conf.Port, conf.HttpPort)
 	go consumer.Consume(ctx)
 	<-ctx.Done()
 	consumer.Close()
 	app.Stop()
+	producer.Close()
+	tracer.Shutdown(context.Background())
 }
 
 func init() {
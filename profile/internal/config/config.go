@@ -4,19 +4,29 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type Config struct {
-	Port int
-	Host string
-	Env  string
+	Port     int
+	HttpPort int // metrics and health endpoints
+	Host     string
+	Env      string
 
-	KafkaBrokers []string
-	KafkaGroupID string
+	KafkaBrokers      []string
+	KafkaGroupID      string
+	KafkaBatchTimeout time.Duration
 
 	S3 S3
 
 	PostgresURL string
+
+	Tracing Tracing
+}
+
+type Tracing struct {
+	Exporter string // none, stdout or otlp
+	Endpoint string // otlp collector address
 }
 
 type S3 struct {
@@ -25,11 +35,13 @@ type S3 struct {
 	Endpoint  string
 	Region    string
 	Bucket    string
+	PublicURL string // where clients download objects of the bucket from
 }
 
 func New() Config {
 	return Config{
 		Port:        envInt("PORT", 50052),
+		HttpPort:    envInt("HTTP_PORT", 9052),
 		Host:        env("HOST", "localhost"),
 		Env:         env("ENV", "development"),
 		PostgresURL: env("POSTGRES_URL"),
@@ -39,9 +51,15 @@ func New() Config {
 			Endpoint:  env("S3_ENDPOINT", "http://localhost:9000"),
 			Region:    env("S3_REGION", "local"),
 			Bucket:    env("S3_BUCKET", "finance-tracker"),
+			PublicURL: env("S3_PUBLIC_URL", "http://localhost:9000/finance-tracker"),
+		},
+		KafkaBrokers:      envArray("KAFKA_BROKERS", "localhost:9092"),
+		KafkaGroupID:      env("KAFKA_GROUP_ID", "profile-service"),
+		KafkaBatchTimeout: envDuration("KAFKA_BATCH_TIMEOUT", 10*time.Millisecond),
+		Tracing: Tracing{
+			Exporter: env("TRACING_EXPORTER", "none"),
+			Endpoint: env("TRACING_ENDPOINT", "localhost:4317"),
 		},
-		KafkaBrokers: envArray("KAFKA_BROKERS", "localhost:9092"),
-		KafkaGroupID: env("KAFKA_GROUP_ID", "profile-service"),
 	}
 }
 
@@ -68,6 +86,19 @@ func envInt(key string, fallback ...int) int {
 	return fallback[0]
 }
 
+func envDuration(key string, fallback ...time.Duration) time.Duration {
+	if value, ok := os.LookupEnv(key); ok {
+		d, err := time.ParseDuration(value)
+		if err == nil {
+			return d
+		}
+	}
+	if len(fallback) == 0 {
+		return 0
+	}
+	return fallback[0]
+}
+
 func envArray(key string, fallback ...string) []string {
 	if value, ok := os.LookupEnv(key); ok {
 		return strings.Split(value, ",")
@@ -3,14 +3,29 @@ package controller
 import (
 	"FinanceTracker/profile/internal/domain"
 	pb "FinanceTracker/profile/pkg/api/profile"
+	"FinanceTracker/profile/pkg/grpcerr"
 	"FinanceTracker/profile/pkg/logger"
 	"context"
 	"errors"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// ReasonProfileNotFound is reported in ErrorInfo when the user has no profile.
+	ReasonProfileNotFound = "PROFILE_NOT_FOUND"
+	// ReasonInvalidPreferences is reported with the invalid field of notification preferences.
+	ReasonInvalidPreferences = "INVALID_PREFERENCES"
+	// ReasonInvalidLinkCode is reported when a Telegram link code is unknown, used or expired.
+	ReasonInvalidLinkCode = "INVALID_LINK_CODE"
+	// ReasonInvalidLocale is reported when the language of notifications is not supported.
+	ReasonInvalidLocale = "INVALID_LOCALE"
 )
 
 type ProfileService interface {
@@ -18,15 +33,35 @@ type ProfileService interface {
 	UpdateProfile(ctx context.Context, userID int, dto domain.UpdateProfileDto) (domain.Profile, error)
 }
 
+type PreferencesService interface {
+	GetPreferences(ctx context.Context, userID int) (domain.NotificationPreferences, error)
+	UpdatePreferences(ctx context.Context, prefs domain.NotificationPreferences, keepUnsubscribed bool) (domain.NotificationPreferences, error)
+	Unsubscribe(ctx context.Context, userID int, category string) (domain.NotificationPreferences, error)
+	EnableChannel(ctx context.Context, userID int, channel string) (domain.NotificationPreferences, error)
+}
+
+type TelegramService interface {
+	CreateLinkCode(ctx context.Context, userID int) (domain.TelegramLinkCode, error)
+	Link(ctx context.Context, code string, chatID int64, username string) (domain.TelegramLink, error)
+	GetLink(ctx context.Context, userID int) (domain.TelegramLink, error)
+	Unlink(ctx context.Context, userID int) error
+}
+
 type profileController struct {
 	pb.UnimplementedProfileServiceServer
 	validate *validator.Validate
 	svc      ProfileService
+	prefs    PreferencesService
+	telegram TelegramService
+	mediaURL string // public url of the avatar bucket
 }
 
-func NewProfileController(svc ProfileService) *profileController {
+func NewProfileController(svc ProfileService, prefs PreferencesService, telegram TelegramService, mediaURL string) *profileController {
 	return &profileController{
 		svc:      svc,
+		prefs:    prefs,
+		telegram: telegram,
+		mediaURL: strings.TrimSuffix(mediaURL, "/"),
 		validate: validator.New(),
 	}
 }
@@ -38,39 +73,225 @@ func (c *profileController) Register(server *grpc.Server) {
 func (c *profileController) GetProfile(ctx context.Context, req *pb.GetProfileRequest) (*pb.Profile, error) {
 	profile, err := c.svc.GetProfileInfo(ctx, int(req.UserId))
 	if errors.Is(err, domain.ErrProfileNotFound) {
-		return nil, status.Errorf(codes.NotFound, "profile not found for user ID %d", req.UserId)
+		return nil, grpcerr.New(codes.NotFound, ReasonProfileNotFound, "profile not found", "user_id", strconv.FormatInt(req.UserId, 10))
 	}
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get profile for user ID %d: %v", req.UserId, err)
+		logger.Error(ctx, "failed to get profile", "userID", req.UserId, "err", err)
+		return nil, grpcerr.Internal("failed to get profile")
 	}
 
-	return &pb.Profile{
-		UserId:   int64(profile.UserID),
-		Email:    profile.Email,
-		Provider: profile.Provider,
-		AvatarId: profile.AvatarID,
-		FullName: profile.FullName,
-	}, nil
+	return c.profileToProto(profile), nil
 }
 
 func (c *profileController) UpdateProfile(ctx context.Context, req *pb.UpdateProfileRequest) (*pb.Profile, error) {
+	if req.Locale != nil && *req.Locale != domain.LocaleRu && *req.Locale != domain.LocaleEn {
+		return nil, grpcerr.InvalidArgument(ReasonInvalidLocale, "locale", "must be ru or en")
+	}
+
 	profile, err := c.svc.UpdateProfile(ctx, int(req.UserId), domain.UpdateProfileDto{
 		FullName:    req.FullName,
 		AvatarBytes: req.AvatarBytes,
+		Locale:      req.Locale,
 	})
 	if errors.Is(err, domain.ErrProfileNotFound) {
-		return nil, status.Errorf(codes.NotFound, "profile not found for user ID %d", req.UserId)
+		return nil, grpcerr.New(codes.NotFound, ReasonProfileNotFound, "profile not found", "user_id", strconv.FormatInt(req.UserId, 10))
 	}
 	if err != nil {
 		logger.Error(ctx, "failed to update profile", "userID", req.UserId, "err", err)
-		return nil, status.Errorf(codes.Internal, "failed to update profile for user ID")
+		return nil, grpcerr.Internal("failed to update profile")
 	}
 
-	return &pb.Profile{
+	return c.profileToProto(profile), nil
+}
+
+func (c *profileController) profileToProto(profile domain.Profile) *pb.Profile {
+	res := &pb.Profile{
 		UserId:   int64(profile.UserID),
 		Email:    profile.Email,
 		Provider: profile.Provider,
 		AvatarId: profile.AvatarID,
 		FullName: profile.FullName,
+		Locale:   profile.Locale,
+	}
+	for _, v := range domain.AvatarVariants(profile.AvatarID) {
+		res.AvatarVariants = append(res.AvatarVariants, &pb.AvatarVariant{
+			Url:    c.mediaURL + "/" + v.Key,
+			Size:   int32(v.Size),
+			Format: v.Format,
+		})
+	}
+	return res
+}
+
+func (c *profileController) GetNotificationPreferences(ctx context.Context, req *pb.GetNotificationPreferencesRequest) (*pb.NotificationPreferences, error) {
+	prefs, err := c.prefs.GetPreferences(ctx, int(req.UserId))
+	if err != nil {
+		logger.Error(ctx, "failed to get notification preferences", "userID", req.UserId, "err", err)
+		return nil, grpcerr.Internal("failed to get notification preferences")
+	}
+	return preferencesToProto(prefs), nil
+}
+
+func (c *profileController) UpdateNotificationPreferences(ctx context.Context, req *pb.NotificationPreferences) (*pb.NotificationPreferences, error) {
+	prefs := domain.NotificationPreferences{
+		UserID:            int(req.UserId),
+		Channels:          req.Channels,
+		ReminderLeadDays:  int(req.ReminderLeadDays),
+		Timezone:          req.Timezone,
+		Mode:              req.Mode,
+		DigestFrequency:   req.DigestFrequency,
+		DigestDay:         time.Monday,
+		EmailUnsubscribed: req.EmailUnsubscribed,
+	}
+	if prefs.DigestFrequency == "" {
+		prefs.DigestFrequency = domain.DigestWeekly
+	}
+	if req.DigestDay != "" {
+		day, err := domain.ParseWeekday(req.DigestDay)
+		if err != nil {
+			return nil, grpcerr.InvalidArgument(ReasonInvalidPreferences, "digest_day", "unknown weekday")
+		}
+		prefs.DigestDay = day
+	}
+	if req.QuietHoursStart != "" || req.QuietHoursEnd != "" {
+		start, err := domain.ParseClock(req.QuietHoursStart)
+		if err != nil {
+			return nil, grpcerr.InvalidArgument(ReasonInvalidPreferences, "quiet_hours_start", "must be HH:MM")
+		}
+		end, err := domain.ParseClock(req.QuietHoursEnd)
+		if err != nil {
+			return nil, grpcerr.InvalidArgument(ReasonInvalidPreferences, "quiet_hours_end", "must be HH:MM")
+		}
+		prefs.QuietHours = &domain.QuietHours{Start: start, End: end}
+	}
+
+	prefs, err := c.prefs.UpdatePreferences(ctx, prefs, req.KeepEmailUnsubscribed)
+	var prefsErr *domain.PreferencesError
+	if errors.As(err, &prefsErr) {
+		return nil, grpcerr.InvalidArgument(ReasonInvalidPreferences, prefsErr.Field, prefsErr.Message)
+	}
+	if errors.Is(err, domain.ErrProfileNotFound) {
+		return nil, grpcerr.New(codes.NotFound, ReasonProfileNotFound, "profile not found", "user_id", strconv.FormatInt(req.UserId, 10))
+	}
+	if err != nil {
+		logger.Error(ctx, "failed to update notification preferences", "userID", req.UserId, "err", err)
+		return nil, grpcerr.Internal("failed to update notification preferences")
+	}
+	return preferencesToProto(prefs), nil
+}
+
+func (c *profileController) UnsubscribeEmail(ctx context.Context, req *pb.UnsubscribeEmailRequest) (*pb.NotificationPreferences, error) {
+	prefs, err := c.prefs.Unsubscribe(ctx, int(req.UserId), req.Category)
+	var prefsErr *domain.PreferencesError
+	if errors.As(err, &prefsErr) {
+		return nil, grpcerr.InvalidArgument(ReasonInvalidPreferences, prefsErr.Field, prefsErr.Message)
+	}
+	if errors.Is(err, domain.ErrProfileNotFound) {
+		return nil, grpcerr.New(codes.NotFound, ReasonProfileNotFound, "profile not found", "user_id", strconv.FormatInt(req.UserId, 10))
+	}
+	if err != nil {
+		logger.Error(ctx, "failed to unsubscribe from emails", "userID", req.UserId, "category", req.Category, "err", err)
+		return nil, grpcerr.Internal("failed to unsubscribe from emails")
+	}
+	return preferencesToProto(prefs), nil
+}
+
+func (c *profileController) EnableChannel(ctx context.Context, req *pb.EnableChannelRequest) (*pb.NotificationPreferences, error) {
+	prefs, err := c.prefs.EnableChannel(ctx, int(req.UserId), req.Channel)
+	var prefsErr *domain.PreferencesError
+	if errors.As(err, &prefsErr) {
+		return nil, grpcerr.InvalidArgument(ReasonInvalidPreferences, prefsErr.Field, prefsErr.Message)
+	}
+	if errors.Is(err, domain.ErrProfileNotFound) {
+		return nil, grpcerr.New(codes.NotFound, ReasonProfileNotFound, "profile not found", "user_id", strconv.FormatInt(req.UserId, 10))
+	}
+	if err != nil {
+		logger.Error(ctx, "failed to enable notification channel", "userID", req.UserId, "channel", req.Channel, "err", err)
+		return nil, grpcerr.Internal("failed to enable notification channel")
+	}
+	return preferencesToProto(prefs), nil
+}
+
+func preferencesToProto(prefs domain.NotificationPreferences) *pb.NotificationPreferences {
+	res := &pb.NotificationPreferences{
+		UserId:            int64(prefs.UserID),
+		Channels:          prefs.Channels,
+		ReminderLeadDays:  int32(prefs.ReminderLeadDays),
+		Timezone:          prefs.Timezone,
+		Mode:              prefs.Mode,
+		DigestFrequency:   prefs.DigestFrequency,
+		DigestDay:         domain.FormatWeekday(prefs.DigestDay),
+		EmailUnsubscribed: prefs.EmailUnsubscribed,
+	}
+	if prefs.QuietHours != nil {
+		res.QuietHoursStart = domain.FormatClock(prefs.QuietHours.Start)
+		res.QuietHoursEnd = domain.FormatClock(prefs.QuietHours.End)
+	}
+	return res
+}
+
+func (c *profileController) CreateTelegramLinkCode(ctx context.Context, req *pb.CreateTelegramLinkCodeRequest) (*pb.TelegramLinkCode, error) {
+	code, err := c.telegram.CreateLinkCode(ctx, int(req.UserId))
+	if errors.Is(err, domain.ErrProfileNotFound) {
+		return nil, grpcerr.New(codes.NotFound, ReasonProfileNotFound, "profile not found", "user_id", strconv.FormatInt(req.UserId, 10))
+	}
+	if err != nil {
+		logger.Error(ctx, "failed to create telegram link code", "userID", req.UserId, "err", err)
+		return nil, grpcerr.Internal("failed to create telegram link code")
+	}
+	return &pb.TelegramLinkCode{
+		Code:      code.Code,
+		ExpiresAt: timestamppb.New(code.ExpiresAt),
 	}, nil
 }
+
+func (c *profileController) LinkTelegram(ctx context.Context, req *pb.LinkTelegramRequest) (*pb.TelegramLink, error) {
+	if req.Code == "" {
+		return nil, grpcerr.InvalidArgument(ReasonInvalidLinkCode, "code", "required")
+	}
+	link, err := c.telegram.Link(ctx, req.Code, req.ChatId, req.Username)
+	if errors.Is(err, domain.ErrInvalidLinkCode) || errors.Is(err, domain.ErrProfileNotFound) {
+		return nil, grpcerr.InvalidArgument(ReasonInvalidLinkCode, "code", "invalid or expired")
+	}
+	if err != nil {
+		logger.Error(ctx, "failed to link telegram", "chatID", req.ChatId, "err", err)
+		return nil, grpcerr.Internal("failed to link telegram")
+	}
+	return telegramLinkToProto(link), nil
+}
+
+// GetTelegramLink answers with linked=false rather than an error for users
+// without a chat.
+func (c *profileController) GetTelegramLink(ctx context.Context, req *pb.GetTelegramLinkRequest) (*pb.TelegramLink, error) {
+	link, err := c.telegram.GetLink(ctx, int(req.UserId))
+	if errors.Is(err, domain.ErrTelegramNotLinked) {
+		return &pb.TelegramLink{UserId: req.UserId}, nil
+	}
+	if err != nil {
+		logger.Error(ctx, "failed to get telegram link", "userID", req.UserId, "err", err)
+		return nil, grpcerr.Internal("failed to get telegram link")
+	}
+	return telegramLinkToProto(link), nil
+}
+
+func (c *profileController) UnlinkTelegram(ctx context.Context, req *pb.UnlinkTelegramRequest) (*pb.UnlinkTelegramResponse, error) {
+	err := c.telegram.Unlink(ctx, int(req.UserId))
+	if errors.Is(err, domain.ErrTelegramNotLinked) {
+		return &pb.UnlinkTelegramResponse{}, nil
+	}
+	if err != nil {
+		logger.Error(ctx, "failed to unlink telegram", "userID", req.UserId, "err", err)
+		return nil, grpcerr.Internal("failed to unlink telegram")
+	}
+	return &pb.UnlinkTelegramResponse{Unlinked: true}, nil
+}
+
+func telegramLinkToProto(link domain.TelegramLink) *pb.TelegramLink {
+	return &pb.TelegramLink{
+		UserId:   int64(link.UserID),
+		Linked:   true,
+		ChatId:   link.ChatID,
+		Username: link.Username,
+		LinkedAt: timestamppb.New(link.LinkedAt),
+	}
+}
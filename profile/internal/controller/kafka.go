@@ -3,13 +3,17 @@ package controller
 import (
 	"FinanceTracker/profile/pkg/events"
 	"FinanceTracker/profile/pkg/logger"
+	"FinanceTracker/profile/pkg/metrics"
+	"FinanceTracker/profile/pkg/tracing"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/codes"
 )
 
 type EventsService interface {
@@ -44,8 +48,7 @@ func (c *eventsController) Consume(ctx context.Context) {
 			}
 		}
 
-		if err := c.handleUserRegistered(ctx, m); err != nil {
-			logger.Error(ctx, "failed to handle message", "err", err)
+		if err := c.process(ctx, m); err != nil {
 			continue
 		}
 
@@ -55,6 +58,22 @@ func (c *eventsController) Consume(ctx context.Context) {
 	}
 }
 
+func (c *eventsController) process(ctx context.Context, m kafka.Message) (err error) {
+	ctx, span := tracing.StartConsumerSpan(ctx, m)
+	defer span.End()
+	if id := requestID(m); id != "" {
+		ctx = logger.WithRequestID(ctx, id)
+	}
+	defer func(start time.Time) { metrics.ObserveMessage(m, start, err) }(time.Now())
+
+	if err := c.handleUserRegistered(ctx, m); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		logger.Error(ctx, "failed to handle message", "err", err)
+		return err
+	}
+	return nil
+}
+
 func (c *eventsController) handleUserRegistered(ctx context.Context, m kafka.Message) error {
 	var event events.EventUserRegistered
 	if err := decodeMessage(m, &event); err != nil {
@@ -71,3 +90,12 @@ func (c *eventsController) Close() error {
 func decodeMessage(m kafka.Message, event any) error {
 	return json.Unmarshal(m.Value, event)
 }
+
+func requestID(m kafka.Message) string {
+	for _, h := range m.Headers {
+		if h.Key == logger.RequestIDKey {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
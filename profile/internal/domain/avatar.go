@@ -1,8 +1,69 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"path"
+)
+
+// Avatar formats.
+const (
+	AvatarJPEG = "jpeg"
+	AvatarWebP = "webp"
+)
+
+var (
+	// AvatarSizes are the sides of the square copies of an avatar in pixels,
+	// largest first.
+	AvatarSizes   = []int{512, 256, 128, 64}
+	AvatarFormats = []string{AvatarJPEG, AvatarWebP}
+)
+
+var avatarExtensions = map[string]string{
+	AvatarJPEG: "jpg",
+	AvatarWebP: "webp",
+}
 
 type Avatar interface {
 	AvatarID() string
 	Upload(ctx context.Context) error
 }
+
+// AvatarVariant is a copy of an avatar in one size and format.
+type AvatarVariant struct {
+	Key    string // object key in the bucket
+	Size   int
+	Format string
+}
+
+// AvatarKey returns the object key of a copy of the user's avatar. The
+// largest JPEG is the avatar ID, so clients that only know the ID still get
+// a picture.
+func AvatarKey(userID, size int, format string) string {
+	return variantKey(fmt.Sprintf("avatars/%d", userID), size, format)
+}
+
+func variantKey(dir string, size int, format string) string {
+	return fmt.Sprintf("%s/%d.%s", dir, size, avatarExtensions[format])
+}
+
+// AvatarVariants returns every copy of the avatar. Avatars uploaded before
+// the copies were made are a single image at the ID and have none.
+func AvatarVariants(avatarID string) []AvatarVariant {
+	dir := path.Dir(avatarID)
+	if avatarID != variantKey(dir, AvatarSizes[0], AvatarJPEG) {
+		return nil
+	}
+
+	variants := make([]AvatarVariant, 0, len(AvatarSizes)*len(AvatarFormats))
+	for _, format := range AvatarFormats {
+		for _, size := range AvatarSizes {
+			variants = append(variants, AvatarVariant{
+				Key:    variantKey(dir, size, format),
+				Size:   size,
+				Format: format,
+			})
+		}
+	}
+	return variants
+}
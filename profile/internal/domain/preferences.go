@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Notification channels.
+const (
+	ChannelEmail    = "email"
+	ChannelInApp    = "in_app"
+	ChannelTelegram = "telegram"
+	ChannelWebPush  = "web_push"
+)
+
+// Notification modes.
+const (
+	ModeImmediate = "immediate"
+	ModeDigest    = "digest"
+)
+
+// Digest frequencies.
+const (
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+)
+
+// Email categories users can unsubscribe from with a link in the email.
+// Login codes and other transactional emails have none.
+const (
+	EmailCategoryReminders = "reminders"
+	EmailCategoryDigest    = "digest"
+)
+
+const MaxReminderLeadDays = 30
+
+var (
+	channels        = []string{ChannelEmail, ChannelInApp, ChannelTelegram, ChannelWebPush}
+	emailCategories = []string{EmailCategoryReminders, EmailCategoryDigest}
+)
+
+// NotificationPreferences decide how non-security notifications reach a user.
+type NotificationPreferences struct {
+	UserID            int
+	Channels          []string
+	ReminderLeadDays  int
+	QuietHours        *QuietHours // nil when quiet hours are off
+	Timezone          string      // IANA name
+	Mode              string
+	DigestFrequency   string
+	DigestDay         time.Weekday // of weekly digests
+	EmailUnsubscribed []string     // email categories turned off by unsubscribe links
+}
+
+// QuietHours is a daily interval in minutes since midnight. Start may be
+// after End, e.g. 22:00-08:00.
+type QuietHours struct {
+	Start int
+	End   int
+}
+
+// DefaultNotificationPreferences apply until the user saves their own.
+func DefaultNotificationPreferences(userID int) NotificationPreferences {
+	return NotificationPreferences{
+		UserID:           userID,
+		Channels:         []string{ChannelEmail, ChannelInApp},
+		ReminderLeadDays: 1,
+		Timezone:         "UTC",
+		Mode:             ModeImmediate,
+		DigestFrequency:  DigestWeekly,
+		DigestDay:        time.Monday,
+	}
+}
+
+// ErrInvalidPreferences is wrapped by *PreferencesError.
+var ErrInvalidPreferences = errors.New("invalid notification preferences")
+
+type PreferencesError struct {
+	Field   string
+	Message string
+}
+
+func (e *PreferencesError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+func (e *PreferencesError) Unwrap() error {
+	return ErrInvalidPreferences
+}
+
+func (p NotificationPreferences) Validate() error {
+	for _, c := range p.Channels {
+		if err := ValidateChannel(c); err != nil {
+			return &PreferencesError{Field: "channels", Message: err.Error()}
+		}
+	}
+	if p.ReminderLeadDays < 0 || p.ReminderLeadDays > MaxReminderLeadDays {
+		return &PreferencesError{Field: "reminder_lead_days", Message: fmt.Sprintf("must be between 0 and %d", MaxReminderLeadDays)}
+	}
+	if _, err := time.LoadLocation(p.Timezone); err != nil || p.Timezone == "" {
+		return &PreferencesError{Field: "timezone", Message: "unknown timezone"}
+	}
+	if p.Mode != ModeImmediate && p.Mode != ModeDigest {
+		return &PreferencesError{Field: "mode", Message: "must be immediate or digest"}
+	}
+	if p.DigestFrequency != DigestDaily && p.DigestFrequency != DigestWeekly {
+		return &PreferencesError{Field: "digest_frequency", Message: "must be daily or weekly"}
+	}
+	if p.DigestDay < time.Sunday || p.DigestDay > time.Saturday {
+		return &PreferencesError{Field: "digest_day", Message: "unknown weekday"}
+	}
+	for _, c := range p.EmailUnsubscribed {
+		if err := ValidateEmailCategory(c); err != nil {
+			return &PreferencesError{Field: "email_unsubscribed", Message: err.Error()}
+		}
+	}
+	return nil
+}
+
+func ValidateChannel(channel string) error {
+	if !slices.Contains(channels, channel) {
+		return fmt.Errorf("unknown channel %q", channel)
+	}
+	return nil
+}
+
+func ValidateEmailCategory(category string) error {
+	if !slices.Contains(emailCategories, category) {
+		return fmt.Errorf("unknown email category %q", category)
+	}
+	return nil
+}
+
+// ParseClock parses "HH:MM" into minutes since midnight.
+func ParseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func FormatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// ParseWeekday parses a lowercase English weekday name, e.g. "monday".
+func ParseWeekday(s string) (time.Weekday, error) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if s == FormatWeekday(d) {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown weekday %q", s)
+}
+
+func FormatWeekday(d time.Weekday) string {
+	return strings.ToLower(d.String())
+}
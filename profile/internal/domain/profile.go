@@ -2,12 +2,19 @@ package domain
 
 import "errors"
 
+// Notification languages.
+const (
+	LocaleRu = "ru"
+	LocaleEn = "en"
+)
+
 type Profile struct {
 	UserID   int
 	Email    string
 	Provider string
 	AvatarID string
 	FullName string
+	Locale   string
 }
 
 var (
@@ -17,4 +24,5 @@ var (
 type UpdateProfileDto struct {
 	FullName    *string
 	AvatarBytes []byte
+	Locale      *string
 }
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// TelegramLinkCodeTTL is how long a code for /start stays valid.
+const TelegramLinkCodeTTL = 10 * time.Minute
+
+var (
+	ErrInvalidLinkCode   = errors.New("invalid or expired link code")
+	ErrTelegramNotLinked = errors.New("telegram is not linked")
+)
+
+// TelegramLinkCode is a one-time code the user sends to the bot to link a chat.
+type TelegramLinkCode struct {
+	Code      string
+	UserID    int
+	ExpiresAt time.Time
+}
+
+// TelegramLink is the chat the bot writes to on behalf of a user.
+type TelegramLink struct {
+	UserID   int
+	ChatID   int64
+	Username string
+	LinkedAt time.Time
+}
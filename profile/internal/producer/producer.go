@@ -0,0 +1,63 @@
+package producer
+
+import (
+	"FinanceTracker/profile/pkg/events"
+	"FinanceTracker/profile/pkg/logger"
+	"FinanceTracker/profile/pkg/tracing"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	kafka "github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/codes"
+)
+
+type producer struct {
+	profileUpdatedWriter *kafka.Writer
+}
+
+func New(brokers []string, batchTimeout time.Duration) *producer {
+	return &producer{
+		profileUpdatedWriter: &kafka.Writer{
+			Addr:                   kafka.TCP(brokers...),
+			Topic:                  events.TopicProfileUpdated,
+			Balancer:               &kafka.Hash{},
+			AllowAutoTopicCreation: true,
+			BatchTimeout:           batchTimeout,
+		},
+	}
+}
+
+func (p *producer) PublishProfileUpdated(ctx context.Context, event events.EventProfileUpdated) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	// keyed by user, so that updates of one profile stay ordered
+	return publish(ctx, p.profileUpdatedWriter, []byte(strconv.Itoa(event.UserID)), data)
+}
+
+func publish(ctx context.Context, w *kafka.Writer, key, data []byte) error {
+	m := kafka.Message{Key: key, Value: data}
+	if id := logger.RequestID(ctx); id != "" {
+		m.Headers = append(m.Headers, kafka.Header{Key: logger.RequestIDKey, Value: []byte(id)})
+	}
+	ctx, span := tracing.StartProducerSpan(ctx, w.Topic, &m)
+	defer span.End()
+
+	if err := w.WriteMessages(ctx, m); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	return nil
+}
+
+func (p *producer) Close() error {
+	if err := p.profileUpdatedWriter.Close(); err != nil {
+		return fmt.Errorf("failed to close profile updated writer: %w", err)
+	}
+	return nil
+}
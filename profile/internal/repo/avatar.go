@@ -3,11 +3,13 @@ package repo
 import (
 	"FinanceTracker/profile/internal/config"
 	"FinanceTracker/profile/internal/domain"
+	"FinanceTracker/profile/pkg/imaging"
 	"FinanceTracker/profile/pkg/logger"
 	"bytes"
 	"context"
 	"fmt"
 	"image"
+	"image/color"
 	"image/jpeg"
 	"io"
 	"os"
@@ -17,6 +19,7 @@ import (
 
 	_ "golang.org/x/image/webp"
 
+	"github.com/HugoSmits86/nativewebp"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsConf "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
@@ -54,9 +57,22 @@ func MustAvatarRepo(ctx context.Context, conf config.S3) *avatarRepo {
 	}
 }
 
+// Ping checks that the bucket exists and is accessible.
+func (r *avatarRepo) Ping(ctx context.Context) error {
+	_, err := r.client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(r.bucket)})
+	return err
+}
+
+// avatarObject is an encoded copy of an avatar.
+type avatarObject struct {
+	key         string
+	contentType string
+	data        []byte
+}
+
 type avatarUploader struct {
 	uploader *manager.Uploader
-	buf      bytes.Buffer
+	objects  []avatarObject
 	avatarID string
 	bucket   string
 }
@@ -65,35 +81,81 @@ func (u *avatarUploader) AvatarID() string {
 	return u.avatarID
 }
 
+// Upload puts every copy in the bucket. The avatar ID is put last, so that it
+// never points to a picture whose other copies are missing.
 func (u *avatarUploader) Upload(ctx context.Context) error {
-	_, err := u.uploader.Upload(ctx, &s3.PutObjectInput{
-		Bucket:      aws.String(u.bucket),
-		Key:         aws.String(u.avatarID),
-		Body:        bytes.NewReader(u.buf.Bytes()),
-		ContentType: aws.String("image/jpeg"),
-	})
-	return err
+	for i := len(u.objects) - 1; i >= 0; i-- {
+		o := u.objects[i]
+		_, err := u.uploader.Upload(ctx, &s3.PutObjectInput{
+			Bucket:      aws.String(u.bucket),
+			Key:         aws.String(o.key),
+			Body:        bytes.NewReader(o.data),
+			ContentType: aws.String(o.contentType),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to upload %s: %w", o.key, err)
+		}
+	}
+	return nil
 }
 
+// Create turns the image upright, crops it to a square and encodes it in
+// every size and format. Re-encoding drops EXIF and other metadata of the
+// upload, such as the location where a photo was taken.
 func (r *avatarRepo) Create(userID int, data io.Reader) (domain.Avatar, error) {
-	img, _, err := image.Decode(data)
+	raw, err := io.ReadAll(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+	img, _, err := image.Decode(bytes.NewReader(raw))
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode image: %w", err)
 	}
 
-	var buf bytes.Buffer
-	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90}); err != nil {
-		return nil, fmt.Errorf("failed to encode image: %w", err)
+	// the smaller sizes are scaled from the largest one, which is far cheaper
+	// than scaling a camera photo again
+	largest := imaging.Orient(imaging.Square(img, domain.AvatarSizes[0]), imaging.Orientation(raw))
+
+	objects := make([]avatarObject, 0, len(domain.AvatarSizes)*len(domain.AvatarFormats))
+	for _, size := range domain.AvatarSizes {
+		thumb := largest
+		if size != domain.AvatarSizes[0] {
+			thumb = imaging.Square(largest, size)
+		}
+		for _, format := range domain.AvatarFormats {
+			encoded, err := encodeAvatar(thumb, format)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode image: %w", err)
+			}
+			objects = append(objects, avatarObject{
+				key:         domain.AvatarKey(userID, size, format),
+				contentType: "image/" + format,
+				data:        encoded,
+			})
+		}
 	}
 
-	avatarID := fmt.Sprintf("avatars/%d.jpg", userID)
-
 	u := &avatarUploader{
 		uploader: r.uploader,
 		bucket:   r.bucket,
-		avatarID: avatarID,
-		buf:      buf,
+		avatarID: domain.AvatarKey(userID, domain.AvatarSizes[0], domain.AvatarJPEG),
+		objects:  objects,
 	}
 
 	return u, nil
 }
+
+func encodeAvatar(img *image.NRGBA, format string) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case domain.AvatarJPEG:
+		// transparent pixels would turn black
+		err = jpeg.Encode(&buf, imaging.Flatten(img, color.White), &jpeg.Options{Quality: 85})
+	case domain.AvatarWebP:
+		err = nativewebp.Encode(&buf, img, nil)
+	default:
+		err = fmt.Errorf("unknown avatar format %q", format)
+	}
+	return buf.Bytes(), err
+}
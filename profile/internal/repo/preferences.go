@@ -0,0 +1,193 @@
+package repo
+
+import (
+	"FinanceTracker/profile/internal/domain"
+	"FinanceTracker/profile/pkg/transaction"
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type NotificationPreferences struct {
+	UserID            int            `db:"user_id"`
+	Channels          pq.StringArray `db:"channels"`
+	ReminderLeadDays  int            `db:"reminder_lead_days"`
+	QuietHoursStart   sql.NullInt16  `db:"quiet_hours_start"`
+	QuietHoursEnd     sql.NullInt16  `db:"quiet_hours_end"`
+	Timezone          string         `db:"timezone"`
+	Mode              string         `db:"mode"`
+	DigestFrequency   string         `db:"digest_frequency"`
+	DigestDay         int            `db:"digest_day"`
+	EmailUnsubscribed pq.StringArray `db:"email_unsubscribed"`
+}
+
+func (p NotificationPreferences) ToDomain() domain.NotificationPreferences {
+	res := domain.NotificationPreferences{
+		UserID:            p.UserID,
+		Channels:          p.Channels,
+		ReminderLeadDays:  p.ReminderLeadDays,
+		Timezone:          p.Timezone,
+		Mode:              p.Mode,
+		DigestFrequency:   p.DigestFrequency,
+		DigestDay:         time.Weekday(p.DigestDay),
+		EmailUnsubscribed: p.EmailUnsubscribed,
+	}
+	if p.QuietHoursStart.Valid && p.QuietHoursEnd.Valid {
+		res.QuietHours = &domain.QuietHours{Start: int(p.QuietHoursStart.Int16), End: int(p.QuietHoursEnd.Int16)}
+	}
+	return res
+}
+
+// foreignKeyViolation is the Postgres error code of a missing referenced row.
+const foreignKeyViolation = "23503"
+
+type preferencesRepo struct {
+	storage *sqlx.DB
+	qb      sq.StatementBuilderType
+}
+
+func NewPreferencesRepo(storage *sqlx.DB) *preferencesRepo {
+	qb := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	return &preferencesRepo{
+		storage: storage,
+		qb:      qb,
+	}
+}
+
+// Get returns the defaults for users who have not saved preferences.
+func (r *preferencesRepo) Get(ctx context.Context, userID int) (domain.NotificationPreferences, error) {
+	query, args := r.qb.Select("user_id", "channels", "reminder_lead_days", "quiet_hours_start", "quiet_hours_end", "timezone", "mode", "digest_frequency", "digest_day", "email_unsubscribed").
+		From("notification_preferences").
+		Where(sq.Eq{"user_id": userID}).
+		MustSql()
+
+	var prefs NotificationPreferences
+	err := r.getContext(ctx, &prefs, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.DefaultNotificationPreferences(userID), nil
+	}
+	if err != nil {
+		return domain.NotificationPreferences{}, err
+	}
+	return prefs.ToDomain(), nil
+}
+
+func (r *preferencesRepo) Save(ctx context.Context, prefs domain.NotificationPreferences) error {
+	return r.save(ctx, prefs, true)
+}
+
+// SaveExceptUnsubscribed saves the preferences but keeps the stored email
+// unsubscribes, so it doesn't race with unsubscribe links.
+func (r *preferencesRepo) SaveExceptUnsubscribed(ctx context.Context, prefs domain.NotificationPreferences) error {
+	prefs.EmailUnsubscribed = nil
+	return r.save(ctx, prefs, false)
+}
+
+func (r *preferencesRepo) save(ctx context.Context, prefs domain.NotificationPreferences, withUnsubscribed bool) error {
+	var start, end sql.NullInt16
+	if prefs.QuietHours != nil {
+		start = sql.NullInt16{Int16: int16(prefs.QuietHours.Start), Valid: true}
+		end = sql.NullInt16{Int16: int16(prefs.QuietHours.End), Valid: true}
+	}
+	channels := pq.StringArray(prefs.Channels)
+	if channels == nil {
+		channels = pq.StringArray{}
+	}
+	unsubscribed := pq.StringArray(prefs.EmailUnsubscribed)
+	if unsubscribed == nil {
+		unsubscribed = pq.StringArray{}
+	}
+
+	set := `ON CONFLICT (user_id) DO UPDATE SET
+			channels = EXCLUDED.channels,
+			reminder_lead_days = EXCLUDED.reminder_lead_days,
+			quiet_hours_start = EXCLUDED.quiet_hours_start,
+			quiet_hours_end = EXCLUDED.quiet_hours_end,
+			timezone = EXCLUDED.timezone,
+			mode = EXCLUDED.mode,
+			digest_frequency = EXCLUDED.digest_frequency,
+			digest_day = EXCLUDED.digest_day,`
+	if withUnsubscribed {
+		set += `
+			email_unsubscribed = EXCLUDED.email_unsubscribed,`
+	}
+	set += `
+			updated_at = now()`
+
+	query, args := r.qb.Insert("notification_preferences").
+		Columns("user_id", "channels", "reminder_lead_days", "quiet_hours_start", "quiet_hours_end", "timezone", "mode", "digest_frequency", "digest_day", "email_unsubscribed").
+		Values(prefs.UserID, channels, prefs.ReminderLeadDays, start, end, prefs.Timezone, prefs.Mode, prefs.DigestFrequency, int(prefs.DigestDay), unsubscribed).
+		Suffix(set).
+		MustSql()
+
+	_, err := r.execContext(ctx, query, args...)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolation {
+		return domain.ErrProfileNotFound
+	}
+	return err
+}
+
+// Unsubscribe turns off an email category, keeping the other preferences or
+// the defaults of the columns for users who have not saved any.
+func (r *preferencesRepo) Unsubscribe(ctx context.Context, userID int, category string) error {
+	query, args := r.qb.Insert("notification_preferences").
+		Columns("user_id", "email_unsubscribed").
+		Values(userID, pq.StringArray{category}).
+		Suffix(`ON CONFLICT (user_id) DO UPDATE SET
+			email_unsubscribed = array_append(array_remove(notification_preferences.email_unsubscribed, ?), ?),
+			updated_at = now()`, category, category).
+		MustSql()
+
+	_, err := r.storage.ExecContext(ctx, query, args...)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolation {
+		return domain.ErrProfileNotFound
+	}
+	return err
+}
+
+// EnableChannel adds a channel, keeping the other preferences or the defaults
+// of the columns for users who have not saved any.
+func (r *preferencesRepo) EnableChannel(ctx context.Context, userID int, channel string) error {
+	channels := pq.StringArray(domain.DefaultNotificationPreferences(userID).Channels)
+	if !slices.Contains(channels, channel) {
+		channels = append(channels, channel)
+	}
+	query, args := r.qb.Insert("notification_preferences").
+		Columns("user_id", "channels").
+		Values(userID, channels).
+		Suffix(`ON CONFLICT (user_id) DO UPDATE SET
+			channels = array_append(array_remove(notification_preferences.channels, ?), ?),
+			updated_at = now()`, channel, channel).
+		MustSql()
+
+	_, err := r.execContext(ctx, query, args...)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolation {
+		return domain.ErrProfileNotFound
+	}
+	return err
+}
+
+func (r *preferencesRepo) execContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	tx := transaction.ExtractTx(ctx)
+	if tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return r.storage.ExecContext(ctx, query, args...)
+}
+
+func (r *preferencesRepo) getContext(ctx context.Context, dest any, query string, args ...any) error {
+	tx := transaction.ExtractTx(ctx)
+	if tx != nil {
+		return tx.GetContext(ctx, dest, query, args...)
+	}
+	return r.storage.GetContext(ctx, dest, query, args...)
+}
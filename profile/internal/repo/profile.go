@@ -18,6 +18,7 @@ type User struct {
 	Provider  string         `db:"provider"`
 	FullName  sql.NullString `db:"full_name"`
 	AvatarID  sql.NullString `db:"avatar_id"`
+	Locale    string         `db:"locale"`
 	CreatedAt time.Time      `db:"created_at"`
 }
 
@@ -28,6 +29,7 @@ func (u User) ToProfile() domain.Profile {
 		Provider: u.Provider,
 		AvatarID: u.AvatarID.String,
 		FullName: u.FullName.String,
+		Locale:   u.Locale,
 	}
 }
 
@@ -45,7 +47,7 @@ func NewUserRepo(storage *sqlx.DB) *userRepo {
 }
 
 func (r *userRepo) GetProfileByID(ctx context.Context, userID int) (domain.Profile, error) {
-	query, args := r.qb.Select("user_id", "email", "provider", "full_name", "avatar_id", "created_at").
+	query, args := r.qb.Select("user_id", "email", "provider", "full_name", "avatar_id", "locale", "created_at").
 		From("users").
 		Where(sq.Eq{"user_id": userID}).
 		MustSql()
@@ -70,6 +72,9 @@ func (r *userRepo) Update(ctx context.Context, user domain.Profile) error {
 	if user.AvatarID != "" {
 		m["avatar_id"] = user.AvatarID
 	}
+	if user.Locale != "" {
+		m["locale"] = user.Locale
+	}
 	query, args := r.qb.Update("users").SetMap(m).Where(sq.Eq{"user_id": user.UserID}).MustSql()
 	res, err := r.execContext(ctx, query, args...)
 	if err != nil {
@@ -0,0 +1,185 @@
+package repo
+
+import (
+	"FinanceTracker/profile/internal/domain"
+	"FinanceTracker/profile/pkg/transaction"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type TelegramLink struct {
+	UserID   int       `db:"user_id"`
+	ChatID   int64     `db:"chat_id"`
+	Username string    `db:"username"`
+	LinkedAt time.Time `db:"linked_at"`
+}
+
+func (l TelegramLink) ToDomain() domain.TelegramLink {
+	return domain.TelegramLink{
+		UserID:   l.UserID,
+		ChatID:   l.ChatID,
+		Username: l.Username,
+		LinkedAt: l.LinkedAt,
+	}
+}
+
+type telegramRepo struct {
+	storage *sqlx.DB
+	qb      sq.StatementBuilderType
+}
+
+func NewTelegramRepo(storage *sqlx.DB) *telegramRepo {
+	return &telegramRepo{
+		storage: storage,
+		qb:      sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+// CreateCode replaces the pending codes of a user with a new one.
+func (r *telegramRepo) CreateCode(ctx context.Context, userID int, ttl time.Duration) (domain.TelegramLinkCode, error) {
+	code, err := generateLinkCode()
+	if err != nil {
+		return domain.TelegramLinkCode{}, fmt.Errorf("failed to generate link code: %w", err)
+	}
+	now := time.Now()
+
+	query, args := r.qb.Delete("telegram_link_codes").
+		Where(sq.Or{sq.Eq{"user_id": userID}, sq.LtOrEq{"expires_at": now}}).
+		MustSql()
+	if _, err := r.execContext(ctx, query, args...); err != nil {
+		return domain.TelegramLinkCode{}, fmt.Errorf("failed to delete old link codes: %w", err)
+	}
+
+	res := domain.TelegramLinkCode{Code: code, UserID: userID, ExpiresAt: now.Add(ttl)}
+	query, args = r.qb.Insert("telegram_link_codes").
+		Columns("code", "user_id", "expires_at").
+		Values(res.Code, res.UserID, res.ExpiresAt).
+		MustSql()
+	_, err = r.execContext(ctx, query, args...)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolation {
+		return domain.TelegramLinkCode{}, domain.ErrProfileNotFound
+	}
+	if err != nil {
+		return domain.TelegramLinkCode{}, fmt.Errorf("failed to insert link code: %w", err)
+	}
+	return res, nil
+}
+
+// ConsumeCode deletes a valid code and returns the user it was issued to.
+func (r *telegramRepo) ConsumeCode(ctx context.Context, code string) (int, error) {
+	query, args := r.qb.Delete("telegram_link_codes").
+		Where(sq.Eq{"code": code}).
+		Where(sq.Gt{"expires_at": time.Now()}).
+		Suffix("RETURNING user_id").
+		MustSql()
+
+	var userID int
+	err := r.getContext(ctx, &userID, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, domain.ErrInvalidLinkCode
+	}
+	return userID, err
+}
+
+// Link points the user to the chat, taking the chat over from whoever had it.
+func (r *telegramRepo) Link(ctx context.Context, link domain.TelegramLink) (domain.TelegramLink, error) {
+	query, args := r.qb.Delete("telegram_links").
+		Where(sq.Eq{"chat_id": link.ChatID}).
+		Where(sq.NotEq{"user_id": link.UserID}).
+		MustSql()
+	if _, err := r.execContext(ctx, query, args...); err != nil {
+		return domain.TelegramLink{}, fmt.Errorf("failed to release chat: %w", err)
+	}
+
+	query, args = r.qb.Insert("telegram_links").
+		Columns("user_id", "chat_id", "username").
+		Values(link.UserID, link.ChatID, link.Username).
+		Suffix(`ON CONFLICT (user_id) DO UPDATE SET
+			chat_id = EXCLUDED.chat_id,
+			username = EXCLUDED.username,
+			linked_at = now()
+			RETURNING user_id, chat_id, username, linked_at`).
+		MustSql()
+
+	var res TelegramLink
+	err := r.getContext(ctx, &res, query, args...)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolation {
+		return domain.TelegramLink{}, domain.ErrProfileNotFound
+	}
+	if err != nil {
+		return domain.TelegramLink{}, err
+	}
+	return res.ToDomain(), nil
+}
+
+func (r *telegramRepo) Get(ctx context.Context, userID int) (domain.TelegramLink, error) {
+	query, args := r.qb.Select("user_id", "chat_id", "username", "linked_at").
+		From("telegram_links").
+		Where(sq.Eq{"user_id": userID}).
+		MustSql()
+
+	var res TelegramLink
+	err := r.getContext(ctx, &res, query, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.TelegramLink{}, domain.ErrTelegramNotLinked
+	}
+	if err != nil {
+		return domain.TelegramLink{}, err
+	}
+	return res.ToDomain(), nil
+}
+
+func (r *telegramRepo) Delete(ctx context.Context, userID int) error {
+	query, args := r.qb.Delete("telegram_links").
+		Where(sq.Eq{"user_id": userID}).
+		MustSql()
+
+	res, err := r.execContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	aff, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if aff == 0 {
+		return domain.ErrTelegramNotLinked
+	}
+	return nil
+}
+
+// generateLinkCode returns a code that fits the deep link start parameter.
+func generateLinkCode() (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func (r *telegramRepo) execContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	tx := transaction.ExtractTx(ctx)
+	if tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return r.storage.ExecContext(ctx, query, args...)
+}
+
+func (r *telegramRepo) getContext(ctx context.Context, dest any, query string, args ...any) error {
+	tx := transaction.ExtractTx(ctx)
+	if tx != nil {
+		return tx.GetContext(ctx, dest, query, args...)
+	}
+	return r.storage.GetContext(ctx, dest, query, args...)
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"FinanceTracker/profile/internal/domain"
+	"FinanceTracker/profile/pkg/transaction"
+	"context"
+	"slices"
+)
+
+type PreferencesRepo interface {
+	Get(ctx context.Context, userID int) (domain.NotificationPreferences, error)
+	Save(ctx context.Context, prefs domain.NotificationPreferences) error
+	SaveExceptUnsubscribed(ctx context.Context, prefs domain.NotificationPreferences) error
+	Unsubscribe(ctx context.Context, userID int, category string) error
+	EnableChannel(ctx context.Context, userID int, channel string) error
+}
+
+type preferencesService struct {
+	repo      PreferencesRepo
+	txManager transaction.Manager
+}
+
+func NewPreferencesService(repo PreferencesRepo, txManager transaction.Manager) *preferencesService {
+	return &preferencesService{repo: repo, txManager: txManager}
+}
+
+func (s *preferencesService) GetPreferences(ctx context.Context, userID int) (domain.NotificationPreferences, error) {
+	return s.repo.Get(ctx, userID)
+}
+
+// UpdatePreferences replaces the preferences of a user. With
+// keepUnsubscribed the stored email unsubscribes are kept instead, for
+// clients that predate unsubscribe links and must not undo them.
+func (s *preferencesService) UpdatePreferences(ctx context.Context, prefs domain.NotificationPreferences, keepUnsubscribed bool) (domain.NotificationPreferences, error) {
+	if keepUnsubscribed {
+		prefs.EmailUnsubscribed = nil
+	}
+	if err := prefs.Validate(); err != nil {
+		return domain.NotificationPreferences{}, err
+	}
+	slices.Sort(prefs.Channels)
+	prefs.Channels = slices.Compact(prefs.Channels)
+	slices.Sort(prefs.EmailUnsubscribed)
+	prefs.EmailUnsubscribed = slices.Compact(prefs.EmailUnsubscribed)
+
+	if !keepUnsubscribed {
+		if err := s.repo.Save(ctx, prefs); err != nil {
+			return domain.NotificationPreferences{}, err
+		}
+		return prefs, nil
+	}
+
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		if err := s.repo.SaveExceptUnsubscribed(ctx, prefs); err != nil {
+			return err
+		}
+		stored, err := s.repo.Get(ctx, prefs.UserID)
+		if err != nil {
+			return err
+		}
+		prefs.EmailUnsubscribed = stored.EmailUnsubscribed
+		return nil
+	})
+	if err != nil {
+		return domain.NotificationPreferences{}, err
+	}
+	return prefs, nil
+}
+
+// Unsubscribe turns off an email category for a user who followed an
+// unsubscribe link. The other preferences are kept.
+func (s *preferencesService) Unsubscribe(ctx context.Context, userID int, category string) (domain.NotificationPreferences, error) {
+	if err := domain.ValidateEmailCategory(category); err != nil {
+		return domain.NotificationPreferences{}, &domain.PreferencesError{Field: "category", Message: err.Error()}
+	}
+	if err := s.repo.Unsubscribe(ctx, userID, category); err != nil {
+		return domain.NotificationPreferences{}, err
+	}
+	return s.repo.Get(ctx, userID)
+}
+
+// EnableChannel adds a channel for a user who just connected it, e.g. allowed
+// push messages in a browser. The other preferences are kept.
+func (s *preferencesService) EnableChannel(ctx context.Context, userID int, channel string) (domain.NotificationPreferences, error) {
+	if err := domain.ValidateChannel(channel); err != nil {
+		return domain.NotificationPreferences{}, &domain.PreferencesError{Field: "channel", Message: err.Error()}
+	}
+	if err := s.repo.EnableChannel(ctx, userID, channel); err != nil {
+		return domain.NotificationPreferences{}, err
+	}
+	return s.repo.Get(ctx, userID)
+}
@@ -0,0 +1,293 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"FinanceTracker/profile/internal/domain"
+	"FinanceTracker/profile/internal/service"
+	smocks "FinanceTracker/profile/internal/service/mocks"
+	txmocks "FinanceTracker/profile/pkg/transaction/mocks"
+)
+
+func TestPreferencesService_UpdatePreferences(t *testing.T) {
+	saveErr := errors.New("save error")
+	valid := domain.NotificationPreferences{
+		UserID:           7,
+		Channels:         []string{"in_app", "email", "in_app"},
+		ReminderLeadDays: 3,
+		QuietHours:       &domain.QuietHours{Start: 22 * 60, End: 8 * 60},
+		Timezone:         "Europe/Moscow",
+		Mode:             domain.ModeDigest,
+		DigestFrequency:  domain.DigestWeekly,
+		DigestDay:        time.Monday,
+	}
+
+	with := func(f func(p *domain.NotificationPreferences)) domain.NotificationPreferences {
+		p := valid
+		p.Channels = append([]string(nil), valid.Channels...)
+		f(&p)
+		return p
+	}
+
+	testCases := []struct {
+		name         string
+		prefs        domain.NotificationPreferences
+		saveErr      error
+		wantChannels []string
+		wantField    string
+		wantErr      error
+	}{
+		{
+			name:         "success_dedupes_channels",
+			prefs:        with(func(p *domain.NotificationPreferences) {}),
+			wantChannels: []string{"email", "in_app"},
+		},
+		{
+			name:         "no_channels",
+			prefs:        with(func(p *domain.NotificationPreferences) { p.Channels = nil }),
+			wantChannels: nil,
+		},
+		{
+			name:      "unknown_channel",
+			prefs:     with(func(p *domain.NotificationPreferences) { p.Channels = []string{"sms"} }),
+			wantField: "channels",
+		},
+		{
+			name:      "lead_days_out_of_range",
+			prefs:     with(func(p *domain.NotificationPreferences) { p.ReminderLeadDays = 31 }),
+			wantField: "reminder_lead_days",
+		},
+		{
+			name:      "unknown_timezone",
+			prefs:     with(func(p *domain.NotificationPreferences) { p.Timezone = "Mars/Olympus" }),
+			wantField: "timezone",
+		},
+		{
+			name:      "unknown_mode",
+			prefs:     with(func(p *domain.NotificationPreferences) { p.Mode = "weekly" }),
+			wantField: "mode",
+		},
+		{
+			name:      "unknown_digest_frequency",
+			prefs:     with(func(p *domain.NotificationPreferences) { p.DigestFrequency = "monthly" }),
+			wantField: "digest_frequency",
+		},
+		{
+			name:      "unknown_digest_day",
+			prefs:     with(func(p *domain.NotificationPreferences) { p.DigestDay = 7 }),
+			wantField: "digest_day",
+		},
+		{
+			name:      "unknown_email_category",
+			prefs:     with(func(p *domain.NotificationPreferences) { p.EmailUnsubscribed = []string{"news"} }),
+			wantField: "email_unsubscribed",
+		},
+		{
+			name:         "save_error",
+			prefs:        with(func(p *domain.NotificationPreferences) {}),
+			wantChannels: []string{"email", "in_app"},
+			saveErr:      saveErr,
+			wantErr:      saveErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := smocks.NewMockPreferencesRepo(t)
+			if tc.wantField == "" {
+				repo.EXPECT().Save(mock.Anything, mock.MatchedBy(func(p domain.NotificationPreferences) bool {
+					return assert.ObjectsAreEqual(tc.wantChannels, p.Channels)
+				})).Return(tc.saveErr)
+			}
+			svc := service.NewPreferencesService(repo, txmocks.NewMockManager(t))
+
+			got, err := svc.UpdatePreferences(context.Background(), tc.prefs, false)
+			if tc.wantField != "" {
+				var prefsErr *domain.PreferencesError
+				require.ErrorAs(t, err, &prefsErr)
+				assert.Equal(t, tc.wantField, prefsErr.Field)
+				assert.ErrorIs(t, err, domain.ErrInvalidPreferences)
+				return
+			}
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantChannels, got.Channels)
+		})
+	}
+}
+
+func TestPreferencesService_UpdatePreferences_KeepUnsubscribed(t *testing.T) {
+	getErr := errors.New("get error")
+	prefs := domain.DefaultNotificationPreferences(7)
+	prefs.EmailUnsubscribed = []string{"news"}
+	stored := domain.DefaultNotificationPreferences(7)
+	stored.EmailUnsubscribed = []string{domain.EmailCategoryDigest}
+
+	testCases := []struct {
+		name    string
+		saveErr error
+		getErr  error
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "success_keeps_stored",
+			want: []string{domain.EmailCategoryDigest},
+		},
+		{
+			name:    "save_error",
+			saveErr: domain.ErrProfileNotFound,
+			wantErr: domain.ErrProfileNotFound,
+		},
+		{
+			name:    "get_error",
+			getErr:  getErr,
+			wantErr: getErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := smocks.NewMockPreferencesRepo(t)
+			repo.EXPECT().SaveExceptUnsubscribed(mock.Anything, mock.MatchedBy(func(p domain.NotificationPreferences) bool {
+				return p.EmailUnsubscribed == nil
+			})).Return(tc.saveErr)
+			if tc.saveErr == nil {
+				repo.EXPECT().Get(mock.Anything, 7).Return(stored, tc.getErr)
+			}
+			tx := txmocks.NewMockManager(t)
+			tx.EXPECT().Do(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, cb func(ctx context.Context) error) error { return cb(ctx) })
+			svc := service.NewPreferencesService(repo, tx)
+
+			got, err := svc.UpdatePreferences(context.Background(), prefs, true)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got.EmailUnsubscribed)
+		})
+	}
+}
+
+func TestPreferencesService_Unsubscribe(t *testing.T) {
+	unsubscribed := domain.DefaultNotificationPreferences(7)
+	unsubscribed.EmailUnsubscribed = []string{domain.EmailCategoryDigest}
+
+	testCases := []struct {
+		name      string
+		category  string
+		repoErr   error
+		wantField string
+		wantErr   error
+	}{
+		{
+			name:     "success",
+			category: domain.EmailCategoryDigest,
+		},
+		{
+			name:      "unknown_category",
+			category:  "otp",
+			wantField: "category",
+		},
+		{
+			name:     "profile_not_found",
+			category: domain.EmailCategoryDigest,
+			repoErr:  domain.ErrProfileNotFound,
+			wantErr:  domain.ErrProfileNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := smocks.NewMockPreferencesRepo(t)
+			if tc.wantField == "" {
+				repo.EXPECT().Unsubscribe(mock.Anything, 7, tc.category).Return(tc.repoErr)
+			}
+			if tc.wantField == "" && tc.repoErr == nil {
+				repo.EXPECT().Get(mock.Anything, 7).Return(unsubscribed, nil)
+			}
+			svc := service.NewPreferencesService(repo, txmocks.NewMockManager(t))
+
+			got, err := svc.Unsubscribe(context.Background(), 7, tc.category)
+			if tc.wantField != "" {
+				var prefsErr *domain.PreferencesError
+				require.ErrorAs(t, err, &prefsErr)
+				assert.Equal(t, tc.wantField, prefsErr.Field)
+				return
+			}
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, unsubscribed, got)
+		})
+	}
+}
+
+func TestPreferencesService_EnableChannel(t *testing.T) {
+	enabled := domain.DefaultNotificationPreferences(7)
+	enabled.Channels = append(enabled.Channels, domain.ChannelWebPush)
+
+	testCases := []struct {
+		name      string
+		channel   string
+		repoErr   error
+		wantField string
+		wantErr   error
+	}{
+		{
+			name:    "success",
+			channel: domain.ChannelWebPush,
+		},
+		{
+			name:      "unknown_channel",
+			channel:   "sms",
+			wantField: "channel",
+		},
+		{
+			name:    "profile_not_found",
+			channel: domain.ChannelWebPush,
+			repoErr: domain.ErrProfileNotFound,
+			wantErr: domain.ErrProfileNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := smocks.NewMockPreferencesRepo(t)
+			if tc.wantField == "" {
+				repo.EXPECT().EnableChannel(mock.Anything, 7, tc.channel).Return(tc.repoErr)
+			}
+			if tc.wantField == "" && tc.repoErr == nil {
+				repo.EXPECT().Get(mock.Anything, 7).Return(enabled, nil)
+			}
+			svc := service.NewPreferencesService(repo, txmocks.NewMockManager(t))
+
+			got, err := svc.EnableChannel(context.Background(), 7, tc.channel)
+			if tc.wantField != "" {
+				var prefsErr *domain.PreferencesError
+				require.ErrorAs(t, err, &prefsErr)
+				assert.Equal(t, tc.wantField, prefsErr.Field)
+				return
+			}
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, enabled, got)
+		})
+	}
+}
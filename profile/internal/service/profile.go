@@ -22,17 +22,23 @@ type AvatarRepo interface {
 	Create(userID int, data io.Reader) (domain.Avatar, error)
 }
 
+type Producer interface {
+	PublishProfileUpdated(ctx context.Context, event events.EventProfileUpdated) error
+}
+
 type profileService struct {
 	userRepo   UserRepo
 	avatarRepo AvatarRepo
+	producer   Producer
 	txManager  transaction.Manager
 }
 
-func NewProfileService(userRepo UserRepo, avatarRepo AvatarRepo, txManager transaction.Manager) *profileService {
+func NewProfileService(userRepo UserRepo, avatarRepo AvatarRepo, producer Producer, txManager transaction.Manager) *profileService {
 	return &profileService{
 		userRepo:   userRepo,
 		txManager:  txManager,
 		avatarRepo: avatarRepo,
+		producer:   producer,
 	}
 }
 
@@ -41,7 +47,7 @@ func (s *profileService) GetProfileInfo(ctx context.Context, userID int) (domain
 }
 
 func (s *profileService) InitializeUserProfile(ctx context.Context, data events.EventUserRegistered) error {
-	return s.txManager.Do(ctx, func(ctx context.Context) error {
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
 		// get profile info
 		profile, err := s.userRepo.GetProfileByID(ctx, data.UserID)
 		if err != nil {
@@ -87,6 +93,12 @@ func (s *profileService) InitializeUserProfile(ctx context.Context, data events.
 		logger.Debug(ctx, "profile initialized", "user_id", profile.UserID, "full_name", profile.FullName)
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	s.publishUpdated(ctx, data.UserID)
+	return nil
 }
 
 func (s *profileService) UpdateProfile(ctx context.Context, userID int, dto domain.UpdateProfileDto) (domain.Profile, error) {
@@ -100,6 +112,9 @@ func (s *profileService) UpdateProfile(ctx context.Context, userID int, dto doma
 	if dto.FullName != nil {
 		profile.FullName = *dto.FullName
 	}
+	if dto.Locale != nil {
+		profile.Locale = *dto.Locale
+	}
 
 	var avatar domain.Avatar
 	if len(dto.AvatarBytes) > 0 {
@@ -127,8 +142,20 @@ func (s *profileService) UpdateProfile(ctx context.Context, userID int, dto doma
 		logger.Debug(ctx, "profile updated", "user_id", userID, "full_name", profile.FullName)
 		return nil
 	})
+	if err != nil {
+		return profile, err
+	}
 
-	return profile, err
+	s.publishUpdated(ctx, userID)
+	return profile, nil
+}
+
+// publishUpdated lets readers drop cached copies of the profile. The change is
+// already committed, so a failed publish is only logged.
+func (s *profileService) publishUpdated(ctx context.Context, userID int) {
+	if err := s.producer.PublishProfileUpdated(ctx, events.EventProfileUpdated{UserID: userID}); err != nil {
+		logger.Error(ctx, "failed to publish profile updated event", "user_id", userID, "err", err)
+	}
 }
 
 var adjectives = []string{
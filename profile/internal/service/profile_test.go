@@ -51,6 +51,7 @@ func TestProfileService_InitializeUserProfile(t *testing.T) {
 		name            string
 		event           events.EventUserRegistered
 		mockBehavior    MockBehavior
+		publishErr      error
 		wantErr         error
 		wantErrContains string
 	}{
@@ -75,6 +76,15 @@ func TestProfileService_InitializeUserProfile(t *testing.T) {
 				})).Return(nil)
 			},
 		},
+		{
+			name:  "publish_error_is_not_fatal",
+			event: events.EventUserRegistered{UserID: base.UserID, Email: base.Email, Provider: base.Provider, FullName: "John Doe"},
+			mockBehavior: func(users *smocks.MockUserRepo, _ *smocks.MockAvatarRepo) {
+				users.EXPECT().GetProfileByID(mock.Anything, base.UserID).Return(domain.Profile{UserID: base.UserID}, nil)
+				users.EXPECT().Update(mock.Anything, mock.Anything).Return(nil)
+			},
+			publishErr: errors.New("publish error"),
+		},
 		{
 			name:  "get_profile_error",
 			event: base,
@@ -126,7 +136,12 @@ func TestProfileService_InitializeUserProfile(t *testing.T) {
 				tc.mockBehavior(users, avatars)
 			}
 
-			svc := service.NewProfileService(users, avatars, tx)
+			producer := smocks.NewMockProducer(t)
+			if tc.wantErr == nil && tc.wantErrContains == "" {
+				producer.EXPECT().PublishProfileUpdated(mock.Anything, events.EventProfileUpdated{UserID: tc.event.UserID}).Return(tc.publishErr)
+			}
+
+			svc := service.NewProfileService(users, avatars, producer, tx)
 			ctx := logger.WithLogger(context.Background(), logger.New("test"))
 			err := svc.InitializeUserProfile(ctx, tc.event)
 
@@ -162,6 +177,7 @@ func TestProfileService_UpdateProfile(t *testing.T) {
 		name            string
 		dto             domain.UpdateProfileDto
 		mockBehavior    MockBehavior
+		publishErr      error
 		wantErr         error
 		wantErrContains string
 		expectTx        bool
@@ -212,6 +228,16 @@ func TestProfileService_UpdateProfile(t *testing.T) {
 			},
 			expectTx: true,
 		},
+		{
+			name: "publish_error_is_not_fatal",
+			dto:  domain.UpdateProfileDto{FullName: &newName},
+			mockBehavior: func(users *smocks.MockUserRepo, _ *smocks.MockAvatarRepo) {
+				users.EXPECT().GetProfileByID(mock.Anything, userID).Return(baseProfile, nil)
+				users.EXPECT().Update(mock.Anything, mock.Anything).Return(nil)
+			},
+			publishErr: errors.New("publish error"),
+			expectTx:   true,
+		},
 		{
 			name: "get_profile_error",
 			dto:  domain.UpdateProfileDto{},
@@ -262,7 +288,12 @@ func TestProfileService_UpdateProfile(t *testing.T) {
 				tc.mockBehavior(users, avatars)
 			}
 
-			svc := service.NewProfileService(users, avatars, tx)
+			producer := smocks.NewMockProducer(t)
+			if tc.wantErr == nil && tc.wantErrContains == "" {
+				producer.EXPECT().PublishProfileUpdated(mock.Anything, events.EventProfileUpdated{UserID: userID}).Return(tc.publishErr)
+			}
+
+			svc := service.NewProfileService(users, avatars, producer, tx)
 			ctx := logger.WithLogger(context.Background(), logger.New("test"))
 			got, err := svc.UpdateProfile(ctx, userID, tc.dto)
 
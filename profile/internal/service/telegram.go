@@ -0,0 +1,66 @@
+package service
+
+import (
+	"FinanceTracker/profile/internal/domain"
+	"FinanceTracker/profile/pkg/transaction"
+	"context"
+	"time"
+)
+
+type TelegramRepo interface {
+	CreateCode(ctx context.Context, userID int, ttl time.Duration) (domain.TelegramLinkCode, error)
+	ConsumeCode(ctx context.Context, code string) (int, error)
+	Link(ctx context.Context, link domain.TelegramLink) (domain.TelegramLink, error)
+	Get(ctx context.Context, userID int) (domain.TelegramLink, error)
+	Delete(ctx context.Context, userID int) error
+}
+
+// ChannelEnabler turns a channel on in the preferences of a user.
+type ChannelEnabler interface {
+	EnableChannel(ctx context.Context, userID int, channel string) error
+}
+
+type telegramService struct {
+	repo      TelegramRepo
+	channels  ChannelEnabler
+	txManager transaction.Manager
+}
+
+func NewTelegramService(repo TelegramRepo, channels ChannelEnabler, txManager transaction.Manager) *telegramService {
+	return &telegramService{repo: repo, channels: channels, txManager: txManager}
+}
+
+// CreateLinkCode issues a code for /start, invalidating the previous one.
+func (s *telegramService) CreateLinkCode(ctx context.Context, userID int) (domain.TelegramLinkCode, error) {
+	return s.repo.CreateCode(ctx, userID, domain.TelegramLinkCodeTTL)
+}
+
+// Link redeems a code sent to the bot from the chat and turns the Telegram
+// channel on, the default channels don't include it. The code is spent only
+// if the link is saved.
+func (s *telegramService) Link(ctx context.Context, code string, chatID int64, username string) (domain.TelegramLink, error) {
+	var res domain.TelegramLink
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		userID, err := s.repo.ConsumeCode(ctx, code)
+		if err != nil {
+			return err
+		}
+		res, err = s.repo.Link(ctx, domain.TelegramLink{UserID: userID, ChatID: chatID, Username: username})
+		if err != nil {
+			return err
+		}
+		return s.channels.EnableChannel(ctx, userID, domain.ChannelTelegram)
+	})
+	if err != nil {
+		return domain.TelegramLink{}, err
+	}
+	return res, nil
+}
+
+func (s *telegramService) GetLink(ctx context.Context, userID int) (domain.TelegramLink, error) {
+	return s.repo.Get(ctx, userID)
+}
+
+func (s *telegramService) Unlink(ctx context.Context, userID int) error {
+	return s.repo.Delete(ctx, userID)
+}
@@ -0,0 +1,86 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"FinanceTracker/profile/internal/domain"
+	"FinanceTracker/profile/internal/service"
+	smocks "FinanceTracker/profile/internal/service/mocks"
+	txmocks "FinanceTracker/profile/pkg/transaction/mocks"
+)
+
+func TestTelegramService_Link(t *testing.T) {
+	linkErr := errors.New("link error")
+	const (
+		code   = "c0de"
+		chatID = int64(1001)
+		userID = 7
+	)
+	want := domain.TelegramLink{UserID: userID, ChatID: chatID, Username: "alice"}
+
+	testCases := []struct {
+		name         string
+		mockBehavior func(repo *smocks.MockTelegramRepo, prefs *smocks.MockPreferencesRepo)
+		wantErr      error
+	}{
+		{
+			name: "linked",
+			mockBehavior: func(repo *smocks.MockTelegramRepo, prefs *smocks.MockPreferencesRepo) {
+				repo.EXPECT().ConsumeCode(mock.Anything, code).Return(userID, nil)
+				repo.EXPECT().Link(mock.Anything, domain.TelegramLink{UserID: userID, ChatID: chatID, Username: "alice"}).Return(want, nil)
+				prefs.EXPECT().EnableChannel(mock.Anything, userID, domain.ChannelTelegram).Return(nil)
+			},
+		},
+		{
+			name: "invalid_code",
+			mockBehavior: func(repo *smocks.MockTelegramRepo, prefs *smocks.MockPreferencesRepo) {
+				repo.EXPECT().ConsumeCode(mock.Anything, code).Return(0, domain.ErrInvalidLinkCode)
+			},
+			wantErr: domain.ErrInvalidLinkCode,
+		},
+		{
+			name: "link_error",
+			mockBehavior: func(repo *smocks.MockTelegramRepo, prefs *smocks.MockPreferencesRepo) {
+				repo.EXPECT().ConsumeCode(mock.Anything, code).Return(userID, nil)
+				repo.EXPECT().Link(mock.Anything, mock.Anything).Return(domain.TelegramLink{}, linkErr)
+			},
+			wantErr: linkErr,
+		},
+		{
+			name: "enable_channel_error",
+			mockBehavior: func(repo *smocks.MockTelegramRepo, prefs *smocks.MockPreferencesRepo) {
+				repo.EXPECT().ConsumeCode(mock.Anything, code).Return(userID, nil)
+				repo.EXPECT().Link(mock.Anything, mock.Anything).Return(want, nil)
+				prefs.EXPECT().EnableChannel(mock.Anything, userID, domain.ChannelTelegram).Return(linkErr)
+			},
+			wantErr: linkErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := smocks.NewMockTelegramRepo(t)
+			prefs := smocks.NewMockPreferencesRepo(t)
+			tx := txmocks.NewMockManager(t)
+			tx.EXPECT().Do(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, cb func(ctx context.Context) error) error { return cb(ctx) })
+			tc.mockBehavior(repo, prefs)
+
+			svc := service.NewTelegramService(repo, prefs, tx)
+			link, err := svc.Link(context.Background(), code, chatID, "alice")
+
+			if tc.wantErr != nil {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, want, link)
+		})
+	}
+}
@@ -19,8 +19,16 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ProfileService_GetProfile_FullMethodName    = "/profile.ProfileService/GetProfile"
-	ProfileService_UpdateProfile_FullMethodName = "/profile.ProfileService/UpdateProfile"
+	ProfileService_GetProfile_FullMethodName                    = "/profile.ProfileService/GetProfile"
+	ProfileService_UpdateProfile_FullMethodName                 = "/profile.ProfileService/UpdateProfile"
+	ProfileService_GetNotificationPreferences_FullMethodName    = "/profile.ProfileService/GetNotificationPreferences"
+	ProfileService_UpdateNotificationPreferences_FullMethodName = "/profile.ProfileService/UpdateNotificationPreferences"
+	ProfileService_UnsubscribeEmail_FullMethodName              = "/profile.ProfileService/UnsubscribeEmail"
+	ProfileService_EnableChannel_FullMethodName                 = "/profile.ProfileService/EnableChannel"
+	ProfileService_CreateTelegramLinkCode_FullMethodName        = "/profile.ProfileService/CreateTelegramLinkCode"
+	ProfileService_LinkTelegram_FullMethodName                  = "/profile.ProfileService/LinkTelegram"
+	ProfileService_GetTelegramLink_FullMethodName               = "/profile.ProfileService/GetTelegramLink"
+	ProfileService_UnlinkTelegram_FullMethodName                = "/profile.ProfileService/UnlinkTelegram"
 )
 
 // ProfileServiceClient is the client API for ProfileService service.
@@ -29,6 +37,14 @@ const (
 type ProfileServiceClient interface {
 	GetProfile(ctx context.Context, in *GetProfileRequest, opts ...grpc.CallOption) (*Profile, error)
 	UpdateProfile(ctx context.Context, in *UpdateProfileRequest, opts ...grpc.CallOption) (*Profile, error)
+	GetNotificationPreferences(ctx context.Context, in *GetNotificationPreferencesRequest, opts ...grpc.CallOption) (*NotificationPreferences, error)
+	UpdateNotificationPreferences(ctx context.Context, in *NotificationPreferences, opts ...grpc.CallOption) (*NotificationPreferences, error)
+	UnsubscribeEmail(ctx context.Context, in *UnsubscribeEmailRequest, opts ...grpc.CallOption) (*NotificationPreferences, error)
+	EnableChannel(ctx context.Context, in *EnableChannelRequest, opts ...grpc.CallOption) (*NotificationPreferences, error)
+	CreateTelegramLinkCode(ctx context.Context, in *CreateTelegramLinkCodeRequest, opts ...grpc.CallOption) (*TelegramLinkCode, error)
+	LinkTelegram(ctx context.Context, in *LinkTelegramRequest, opts ...grpc.CallOption) (*TelegramLink, error)
+	GetTelegramLink(ctx context.Context, in *GetTelegramLinkRequest, opts ...grpc.CallOption) (*TelegramLink, error)
+	UnlinkTelegram(ctx context.Context, in *UnlinkTelegramRequest, opts ...grpc.CallOption) (*UnlinkTelegramResponse, error)
 }
 
 type profileServiceClient struct {
@@ -59,12 +75,100 @@ func (c *profileServiceClient) UpdateProfile(ctx context.Context, in *UpdateProf
 	return out, nil
 }
 
+func (c *profileServiceClient) GetNotificationPreferences(ctx context.Context, in *GetNotificationPreferencesRequest, opts ...grpc.CallOption) (*NotificationPreferences, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NotificationPreferences)
+	err := c.cc.Invoke(ctx, ProfileService_GetNotificationPreferences_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileServiceClient) UpdateNotificationPreferences(ctx context.Context, in *NotificationPreferences, opts ...grpc.CallOption) (*NotificationPreferences, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NotificationPreferences)
+	err := c.cc.Invoke(ctx, ProfileService_UpdateNotificationPreferences_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileServiceClient) UnsubscribeEmail(ctx context.Context, in *UnsubscribeEmailRequest, opts ...grpc.CallOption) (*NotificationPreferences, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NotificationPreferences)
+	err := c.cc.Invoke(ctx, ProfileService_UnsubscribeEmail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileServiceClient) EnableChannel(ctx context.Context, in *EnableChannelRequest, opts ...grpc.CallOption) (*NotificationPreferences, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NotificationPreferences)
+	err := c.cc.Invoke(ctx, ProfileService_EnableChannel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileServiceClient) CreateTelegramLinkCode(ctx context.Context, in *CreateTelegramLinkCodeRequest, opts ...grpc.CallOption) (*TelegramLinkCode, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TelegramLinkCode)
+	err := c.cc.Invoke(ctx, ProfileService_CreateTelegramLinkCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileServiceClient) LinkTelegram(ctx context.Context, in *LinkTelegramRequest, opts ...grpc.CallOption) (*TelegramLink, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TelegramLink)
+	err := c.cc.Invoke(ctx, ProfileService_LinkTelegram_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileServiceClient) GetTelegramLink(ctx context.Context, in *GetTelegramLinkRequest, opts ...grpc.CallOption) (*TelegramLink, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TelegramLink)
+	err := c.cc.Invoke(ctx, ProfileService_GetTelegramLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileServiceClient) UnlinkTelegram(ctx context.Context, in *UnlinkTelegramRequest, opts ...grpc.CallOption) (*UnlinkTelegramResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnlinkTelegramResponse)
+	err := c.cc.Invoke(ctx, ProfileService_UnlinkTelegram_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProfileServiceServer is the server API for ProfileService service.
 // All implementations must embed UnimplementedProfileServiceServer
 // for forward compatibility.
 type ProfileServiceServer interface {
 	GetProfile(context.Context, *GetProfileRequest) (*Profile, error)
 	UpdateProfile(context.Context, *UpdateProfileRequest) (*Profile, error)
+	GetNotificationPreferences(context.Context, *GetNotificationPreferencesRequest) (*NotificationPreferences, error)
+	UpdateNotificationPreferences(context.Context, *NotificationPreferences) (*NotificationPreferences, error)
+	UnsubscribeEmail(context.Context, *UnsubscribeEmailRequest) (*NotificationPreferences, error)
+	EnableChannel(context.Context, *EnableChannelRequest) (*NotificationPreferences, error)
+	CreateTelegramLinkCode(context.Context, *CreateTelegramLinkCodeRequest) (*TelegramLinkCode, error)
+	LinkTelegram(context.Context, *LinkTelegramRequest) (*TelegramLink, error)
+	GetTelegramLink(context.Context, *GetTelegramLinkRequest) (*TelegramLink, error)
+	UnlinkTelegram(context.Context, *UnlinkTelegramRequest) (*UnlinkTelegramResponse, error)
 	mustEmbedUnimplementedProfileServiceServer()
 }
 
@@ -81,6 +185,30 @@ func (UnimplementedProfileServiceServer) GetProfile(context.Context, *GetProfile
 func (UnimplementedProfileServiceServer) UpdateProfile(context.Context, *UpdateProfileRequest) (*Profile, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateProfile not implemented")
 }
+func (UnimplementedProfileServiceServer) GetNotificationPreferences(context.Context, *GetNotificationPreferencesRequest) (*NotificationPreferences, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNotificationPreferences not implemented")
+}
+func (UnimplementedProfileServiceServer) UpdateNotificationPreferences(context.Context, *NotificationPreferences) (*NotificationPreferences, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateNotificationPreferences not implemented")
+}
+func (UnimplementedProfileServiceServer) UnsubscribeEmail(context.Context, *UnsubscribeEmailRequest) (*NotificationPreferences, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnsubscribeEmail not implemented")
+}
+func (UnimplementedProfileServiceServer) EnableChannel(context.Context, *EnableChannelRequest) (*NotificationPreferences, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnableChannel not implemented")
+}
+func (UnimplementedProfileServiceServer) CreateTelegramLinkCode(context.Context, *CreateTelegramLinkCodeRequest) (*TelegramLinkCode, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTelegramLinkCode not implemented")
+}
+func (UnimplementedProfileServiceServer) LinkTelegram(context.Context, *LinkTelegramRequest) (*TelegramLink, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LinkTelegram not implemented")
+}
+func (UnimplementedProfileServiceServer) GetTelegramLink(context.Context, *GetTelegramLinkRequest) (*TelegramLink, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTelegramLink not implemented")
+}
+func (UnimplementedProfileServiceServer) UnlinkTelegram(context.Context, *UnlinkTelegramRequest) (*UnlinkTelegramResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnlinkTelegram not implemented")
+}
 func (UnimplementedProfileServiceServer) mustEmbedUnimplementedProfileServiceServer() {}
 func (UnimplementedProfileServiceServer) testEmbeddedByValue()                        {}
 
@@ -138,6 +266,150 @@ func _ProfileService_UpdateProfile_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProfileService_GetNotificationPreferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNotificationPreferencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).GetNotificationPreferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_GetNotificationPreferences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).GetNotificationPreferences(ctx, req.(*GetNotificationPreferencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileService_UpdateNotificationPreferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NotificationPreferences)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).UpdateNotificationPreferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_UpdateNotificationPreferences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).UpdateNotificationPreferences(ctx, req.(*NotificationPreferences))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileService_UnsubscribeEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsubscribeEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).UnsubscribeEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_UnsubscribeEmail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).UnsubscribeEmail(ctx, req.(*UnsubscribeEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileService_EnableChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnableChannelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).EnableChannel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_EnableChannel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).EnableChannel(ctx, req.(*EnableChannelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileService_CreateTelegramLinkCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTelegramLinkCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).CreateTelegramLinkCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_CreateTelegramLinkCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).CreateTelegramLinkCode(ctx, req.(*CreateTelegramLinkCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileService_LinkTelegram_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkTelegramRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).LinkTelegram(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_LinkTelegram_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).LinkTelegram(ctx, req.(*LinkTelegramRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileService_GetTelegramLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTelegramLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).GetTelegramLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_GetTelegramLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).GetTelegramLink(ctx, req.(*GetTelegramLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileService_UnlinkTelegram_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlinkTelegramRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).UnlinkTelegram(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_UnlinkTelegram_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).UnlinkTelegram(ctx, req.(*UnlinkTelegramRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ProfileService_ServiceDesc is the grpc.ServiceDesc for ProfileService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,6 +425,38 @@ var ProfileService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateProfile",
 			Handler:    _ProfileService_UpdateProfile_Handler,
 		},
+		{
+			MethodName: "GetNotificationPreferences",
+			Handler:    _ProfileService_GetNotificationPreferences_Handler,
+		},
+		{
+			MethodName: "UpdateNotificationPreferences",
+			Handler:    _ProfileService_UpdateNotificationPreferences_Handler,
+		},
+		{
+			MethodName: "UnsubscribeEmail",
+			Handler:    _ProfileService_UnsubscribeEmail_Handler,
+		},
+		{
+			MethodName: "EnableChannel",
+			Handler:    _ProfileService_EnableChannel_Handler,
+		},
+		{
+			MethodName: "CreateTelegramLinkCode",
+			Handler:    _ProfileService_CreateTelegramLinkCode_Handler,
+		},
+		{
+			MethodName: "LinkTelegram",
+			Handler:    _ProfileService_LinkTelegram_Handler,
+		},
+		{
+			MethodName: "GetTelegramLink",
+			Handler:    _ProfileService_GetTelegramLink_Handler,
+		},
+		{
+			MethodName: "UnlinkTelegram",
+			Handler:    _ProfileService_UnlinkTelegram_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/profile.proto",
@@ -1,7 +1,8 @@
 package events
 
 const (
-	TopicRegistered = "user.registered"
+	TopicRegistered     = "user.registered"
+	TopicProfileUpdated = "profile.updated"
 )
 
 type EventUserRegistered struct {
@@ -10,4 +11,10 @@ type EventUserRegistered struct {
 	Provider  string `json:"provider"`
 	FullName  string `json:"full_name,omitempty"`
 	AvatarURL string `json:"avatar_url,omitempty"`
+	Locale    string `json:"locale,omitempty"` // ru or en
+}
+
+// EventProfileUpdated is published after a profile has been changed.
+type EventProfileUpdated struct {
+	UserID int `json:"user_id"`
 }
@@ -0,0 +1,23 @@
+package health
+
+import (
+	"context"
+	"errors"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Kafka checks that at least one of the brokers accepts connections.
+func Kafka(brokers []string) Checker {
+	return func(ctx context.Context) error {
+		var errs []error
+		for _, broker := range brokers {
+			conn, err := kafka.DialContext(ctx, "tcp", broker)
+			if err == nil {
+				return conn.Close()
+			}
+			errs = append(errs, err)
+		}
+		return errors.Join(errs...)
+	}
+}
@@ -0,0 +1,89 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// Orientations of the EXIF tag 0x0112: how the stored pixels must be turned
+// to be displayed upright.
+const (
+	OrientationNormal     = 1
+	OrientationFlipH      = 2
+	OrientationRotate180  = 3
+	OrientationFlipV      = 4
+	OrientationTranspose  = 5
+	OrientationRotate90   = 6 // clockwise
+	OrientationTransverse = 7
+	OrientationRotate270  = 8 // clockwise
+)
+
+const exifOrientationTag = 0x0112
+
+// Orientation reads the EXIF orientation of a JPEG file. Other formats, files
+// without EXIF and malformed tags are OrientationNormal.
+func Orientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return OrientationNormal
+	}
+
+	// walk the segments up to the image data
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return OrientationNormal
+		}
+		marker := data[i+1]
+		if marker == 0xDA || marker == 0xD9 { // start of scan, end of image
+			return OrientationNormal
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return OrientationNormal
+		}
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return tiffOrientation(segment[6:])
+		}
+		i += 2 + length
+	}
+	return OrientationNormal
+}
+
+// tiffOrientation finds the orientation in IFD0 of the TIFF structure that
+// holds the EXIF tags.
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return OrientationNormal
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return OrientationNormal
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return OrientationNormal
+	}
+	count := int(order.Uint16(tiff[ifd:]))
+	for n := range count {
+		entry := ifd + 2 + n*12
+		if entry+12 > len(tiff) {
+			break
+		}
+		if order.Uint16(tiff[entry:]) != exifOrientationTag {
+			continue
+		}
+		// a SHORT is stored in the first bytes of the value field
+		o := int(order.Uint16(tiff[entry+8:]))
+		if o < OrientationNormal || o > OrientationRotate270 {
+			return OrientationNormal
+		}
+		return o
+	}
+	return OrientationNormal
+}
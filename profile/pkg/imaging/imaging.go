@@ -0,0 +1,73 @@
+// Package imaging makes square thumbnails of uploaded pictures. The
+// thumbnails are new pixel buffers, so no metadata of the source survives
+// encoding them.
+package imaging
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+// Square crops the largest centered square of img and scales it to size
+// pixels.
+func Square(img image.Image, size int) *image.NRGBA {
+	b := img.Bounds()
+	side := min(b.Dx(), b.Dy())
+	crop := image.Rect(0, 0, side, side).Add(b.Min).Add(image.Pt((b.Dx()-side)/2, (b.Dy()-side)/2))
+
+	dst := image.NewNRGBA(image.Rect(0, 0, size, size))
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), img, crop, xdraw.Src, nil)
+	return dst
+}
+
+// Orient turns img upright according to its EXIF orientation.
+func Orient(img *image.NRGBA, orientation int) *image.NRGBA {
+	if orientation <= OrientationNormal || orientation > OrientationRotate270 {
+		return img
+	}
+
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+	dw, dh := w, h
+	if orientation >= OrientationTranspose {
+		dw, dh = h, w
+	}
+
+	// src maps a pixel of the result to the stored one
+	var src func(x, y int) (int, int)
+	switch orientation {
+	case OrientationFlipH:
+		src = func(x, y int) (int, int) { return w - 1 - x, y }
+	case OrientationRotate180:
+		src = func(x, y int) (int, int) { return w - 1 - x, h - 1 - y }
+	case OrientationFlipV:
+		src = func(x, y int) (int, int) { return x, h - 1 - y }
+	case OrientationTranspose:
+		src = func(x, y int) (int, int) { return y, x }
+	case OrientationRotate90:
+		src = func(x, y int) (int, int) { return y, h - 1 - x }
+	case OrientationTransverse:
+		src = func(x, y int) (int, int) { return w - 1 - y, h - 1 - x }
+	case OrientationRotate270:
+		src = func(x, y int) (int, int) { return w - 1 - y, x }
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for y := range dh {
+		for x := range dw {
+			sx, sy := src(x, y)
+			copy(dst.Pix[dst.PixOffset(x, y):][:4], img.Pix[img.PixOffset(sx+img.Rect.Min.X, sy+img.Rect.Min.Y):][:4])
+		}
+	}
+	return dst
+}
+
+// Flatten draws img over a background, for formats without transparency.
+func Flatten(img image.Image, background color.Color) *image.RGBA {
+	dst := image.NewRGBA(img.Bounds())
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), img, img.Bounds().Min, draw.Over)
+	return dst
+}
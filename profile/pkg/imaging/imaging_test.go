@@ -0,0 +1,160 @@
+package imaging_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"FinanceTracker/profile/pkg/imaging"
+)
+
+// withExif puts an APP1 segment with the orientation tag right after SOI.
+func withExif(t *testing.T, data []byte, order binary.ByteOrder, orientation uint16) []byte {
+	t.Helper()
+
+	var tiff bytes.Buffer
+	if order == binary.LittleEndian {
+		tiff.WriteString("II")
+	} else {
+		tiff.WriteString("MM")
+	}
+	binary.Write(&tiff, order, uint16(42))
+	binary.Write(&tiff, order, uint32(8))                // IFD0 offset
+	binary.Write(&tiff, order, uint16(2))                // entries
+	binary.Write(&tiff, order, []uint16{0x010F, 2})      // Make, ASCII
+	binary.Write(&tiff, order, []uint32{4, 0})           // count, value
+	binary.Write(&tiff, order, []uint16{0x0112, 3})      // Orientation, SHORT
+	binary.Write(&tiff, order, uint32(1))                // count
+	binary.Write(&tiff, order, []uint16{orientation, 0}) // value
+	binary.Write(&tiff, order, uint32(0))                // next IFD
+
+	segment := append([]byte("Exif\x00\x00"), tiff.Bytes()...)
+	var out bytes.Buffer
+	out.Write(data[:2])
+	out.Write([]byte{0xFF, 0xE1})
+	binary.Write(&out, binary.BigEndian, uint16(len(segment)+2))
+	out.Write(segment)
+	out.Write(data[2:])
+	return out.Bytes()
+}
+
+func TestOrientation(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	var jpg, pngData bytes.Buffer
+	require.NoError(t, jpeg.Encode(&jpg, img, nil))
+	require.NoError(t, png.Encode(&pngData, img))
+
+	testCases := []struct {
+		name string
+		data []byte
+		want int
+	}{
+		{
+			name: "jpeg_without_exif",
+			data: jpg.Bytes(),
+			want: imaging.OrientationNormal,
+		},
+		{
+			name: "little_endian",
+			data: withExif(t, jpg.Bytes(), binary.LittleEndian, 6),
+			want: imaging.OrientationRotate90,
+		},
+		{
+			name: "big_endian",
+			data: withExif(t, jpg.Bytes(), binary.BigEndian, 3),
+			want: imaging.OrientationRotate180,
+		},
+		{
+			name: "out_of_range",
+			data: withExif(t, jpg.Bytes(), binary.BigEndian, 9),
+			want: imaging.OrientationNormal,
+		},
+		{
+			name: "truncated",
+			data: withExif(t, jpg.Bytes(), binary.LittleEndian, 6)[:20],
+			want: imaging.OrientationNormal,
+		},
+		{
+			name: "png",
+			data: pngData.Bytes(),
+			want: imaging.OrientationNormal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, imaging.Orientation(tc.data))
+		})
+	}
+
+	// decoders skip the segment
+	_, err := jpeg.Decode(bytes.NewReader(withExif(t, jpg.Bytes(), binary.LittleEndian, 6)))
+	require.NoError(t, err)
+}
+
+func TestOrient(t *testing.T) {
+	// 3x2, the red channel numbers the pixels row by row
+	src := image.NewNRGBA(image.Rect(0, 0, 3, 2))
+	for i := range 6 {
+		src.SetNRGBA(i%3, i/3, color.NRGBA{R: uint8(i), A: 255})
+	}
+
+	testCases := []struct {
+		name        string
+		orientation int
+		want        [][]uint8 // rows of the result
+	}{
+		{name: "normal", orientation: imaging.OrientationNormal, want: [][]uint8{{0, 1, 2}, {3, 4, 5}}},
+		{name: "flip_h", orientation: imaging.OrientationFlipH, want: [][]uint8{{2, 1, 0}, {5, 4, 3}}},
+		{name: "rotate_180", orientation: imaging.OrientationRotate180, want: [][]uint8{{5, 4, 3}, {2, 1, 0}}},
+		{name: "flip_v", orientation: imaging.OrientationFlipV, want: [][]uint8{{3, 4, 5}, {0, 1, 2}}},
+		{name: "transpose", orientation: imaging.OrientationTranspose, want: [][]uint8{{0, 3}, {1, 4}, {2, 5}}},
+		{name: "rotate_90", orientation: imaging.OrientationRotate90, want: [][]uint8{{3, 0}, {4, 1}, {5, 2}}},
+		{name: "transverse", orientation: imaging.OrientationTransverse, want: [][]uint8{{5, 2}, {4, 1}, {3, 0}}},
+		{name: "rotate_270", orientation: imaging.OrientationRotate270, want: [][]uint8{{2, 5}, {1, 4}, {0, 3}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := imaging.Orient(src, tc.orientation)
+
+			require.Equal(t, image.Pt(len(tc.want[0]), len(tc.want)), got.Bounds().Size())
+			for y, row := range tc.want {
+				for x, want := range row {
+					assert.Equal(t, want, got.NRGBAAt(x, y).R, "pixel %d,%d", x, y)
+				}
+			}
+		})
+	}
+}
+
+func TestSquare(t *testing.T) {
+	// red borders around a green 2x2 center
+	src := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+	for x := range 4 {
+		for y := range 2 {
+			c := color.NRGBA{R: 255, A: 255}
+			if x == 1 || x == 2 {
+				c = color.NRGBA{G: 255, A: 255}
+			}
+			src.SetNRGBA(x, y, c)
+		}
+	}
+
+	got := imaging.Square(src, 2)
+
+	require.Equal(t, image.Pt(2, 2), got.Bounds().Size())
+	for x := range 2 {
+		for y := range 2 {
+			assert.Equal(t, color.NRGBA{G: 255, A: 255}, got.NRGBAAt(x, y))
+		}
+	}
+	assert.Equal(t, image.Pt(64, 64), imaging.Square(src, 64).Bounds().Size())
+}
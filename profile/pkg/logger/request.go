@@ -0,0 +1,23 @@
+package logger
+
+import "context"
+
+const (
+	// RequestIDKey names the request ID in gRPC metadata and Kafka headers.
+	RequestIDKey = "x-request-id"
+	// ClientIPKey names the client IP resolved by the gateway in gRPC metadata.
+	ClientIPKey = "x-client-ip"
+)
+
+type requestIDKey struct{}
+
+// WithRequestID stores the request ID in ctx and attaches it to the context logger.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	ctx = context.WithValue(ctx, requestIDKey{}, id)
+	return WithLogger(ctx, FromContext(ctx).With("request_id", id))
+}
+
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
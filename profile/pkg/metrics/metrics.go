@@ -0,0 +1,12 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Handler serves metrics from the default registry.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
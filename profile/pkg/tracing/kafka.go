@@ -0,0 +1,71 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const kafkaTracerName = "FinanceTracker/kafka"
+
+// headerCarrier adapts kafka message headers to propagation.TextMapCarrier.
+type headerCarrier struct {
+	headers *[]kafka.Header
+}
+
+func (c headerCarrier) Get(key string) string {
+	for _, h := range *c.headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func (c headerCarrier) Set(key, value string) {
+	for i, h := range *c.headers {
+		if h.Key == key {
+			(*c.headers)[i].Value = []byte(value)
+			return
+		}
+	}
+	*c.headers = append(*c.headers, kafka.Header{Key: key, Value: []byte(value)})
+}
+
+func (c headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(*c.headers))
+	for _, h := range *c.headers {
+		keys = append(keys, h.Key)
+	}
+	return keys
+}
+
+// StartProducerSpan starts a producer span and injects its context into the message headers.
+func StartProducerSpan(ctx context.Context, topic string, m *kafka.Message) (context.Context, trace.Span) {
+	ctx, span := otel.Tracer(kafkaTracerName).Start(ctx, "publish "+topic,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "kafka"),
+			attribute.String("messaging.destination.name", topic),
+		),
+	)
+	otel.GetTextMapPropagator().Inject(ctx, headerCarrier{&m.Headers})
+	return ctx, span
+}
+
+// StartConsumerSpan extracts the producer context from the message headers and starts a consumer span.
+func StartConsumerSpan(ctx context.Context, m kafka.Message) (context.Context, trace.Span) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, headerCarrier{&m.Headers})
+	return otel.Tracer(kafkaTracerName).Start(ctx, "process "+m.Topic,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "kafka"),
+			attribute.String("messaging.destination.name", m.Topic),
+			attribute.Int("messaging.kafka.partition", m.Partition),
+			attribute.Int64("messaging.kafka.offset", m.Offset),
+		),
+	)
+}
@@ -0,0 +1,24 @@
+// Service worker for Web Push notifications. The payload is JSON
+// {title, body, url, tag} sent by the notification service.
+self.addEventListener('push', (event) => {
+  if (!event.data) return
+  const message = event.data.json()
+  event.waitUntil(
+    self.registration.showNotification(message.title, {
+      body: message.body,
+      tag: message.tag,
+      data: { url: message.url || '/' },
+    }),
+  )
+})
+
+self.addEventListener('notificationclick', (event) => {
+  event.notification.close()
+  const url = new URL(event.notification.data.url, self.location.origin).href
+  event.waitUntil(
+    self.clients.matchAll({ type: 'window', includeUncontrolled: true }).then((windows) => {
+      const open = windows.find((w) => w.url === url)
+      return open ? open.focus() : self.clients.openWindow(url)
+    }),
+  )
+})
@@ -5,6 +5,7 @@ import { Header } from '@/widgets/header'
 import Image from 'next/image'
 import './globals.css'
 import { fetchCurrentUser } from '@/entities/profile'
+import { EventStream } from '@/features/events'
 
 const geistSans = Geist({
   variable: '--font-geist-sans',
@@ -42,6 +43,7 @@ export default async function RootLayout({
               />
             </div>
             <Header profile={profile} />
+            {profile && <EventStream />}
             {children}
           </div>
         </Providers>
@@ -1,5 +1,6 @@
 import { fetchCurrentUser } from '@/entities/profile'
 import { ProfileForm } from '@/features/profile'
+import { PushToggle } from '@/features/push'
 import { redirect } from 'next/navigation'
 
 export default async function ProfilePage() {
@@ -14,6 +15,7 @@ export default async function ProfilePage() {
         <h1 className='text-4xl font-bold'>Мой профиль</h1>
         <p className='text-muted-foreground'>Здесь вы можете управлять своим профилем.</p>
         <ProfileForm profile={profile} />
+        <PushToggle />
       </section>
     </main>
   )
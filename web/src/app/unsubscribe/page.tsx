@@ -0,0 +1,15 @@
+import { UnsubscribeConfirm } from '@/features/unsubscribe'
+
+type Props = {
+  searchParams: Promise<{ token?: string }>
+}
+
+export default async function UnsubscribePage({ searchParams }: Props) {
+  const params = await searchParams
+
+  return (
+    <main className='flex-1 flex items-center justify-center'>
+      <UnsubscribeConfirm token={params.token} />
+    </main>
+  )
+}
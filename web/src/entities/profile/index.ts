@@ -1,2 +1,3 @@
-export { type Profile, profileSchema } from './model/profile-schema'
+export { type Profile, type AvatarVariant, profileSchema } from './model/profile-schema'
+export { avatarSrc, avatarSrcSet } from './model/avatar'
 export { fetchCurrentUser } from './api/fetch-current-user'
@@ -0,0 +1,21 @@
+import { MEDIA_URL } from '@/shared/constants'
+import type { Profile } from './profile-schema'
+
+// avatarSrc returns the smallest JPEG copy of the avatar that covers size
+// pixels. Avatars uploaded before the copies were made have only the
+// original image.
+export function avatarSrc(profile: Profile, size: number): string {
+  const jpegs = profile.avatar_variants
+    .filter((v) => v.format === 'jpeg')
+    .sort((a, b) => a.size - b.size)
+  const variant = jpegs.find((v) => v.size >= size) ?? jpegs[jpegs.length - 1]
+  return variant?.url ?? `${MEDIA_URL}/${profile.avatar_id}`
+}
+
+// avatarSrcSet lists the WebP copies of the avatar for srcset, the browser
+// picks one for the rendered size and pixel density.
+export function avatarSrcSet(profile: Profile): string | undefined {
+  const webps = profile.avatar_variants.filter((v) => v.format === 'webp')
+  if (webps.length === 0) return undefined
+  return webps.map((v) => `${v.url} ${v.size}w`).join(', ')
+}
@@ -1,7 +1,14 @@
 import { z } from 'zod'
 
+export const avatarVariantSchema = z.object({
+  url: z.string(),
+  size: z.number(),
+  format: z.enum(['jpeg', 'webp']),
+})
+
 export const profileSchema = z.object({
   avatar_id: z.string().optional(),
+  avatar_variants: z.array(avatarVariantSchema).default([]),
   email: z.string().email(),
   full_name: z.string().optional(),
   provider: z.string(),
@@ -9,3 +16,4 @@ export const profileSchema = z.object({
 })
 
 export type Profile = z.infer<typeof profileSchema>
+export type AvatarVariant = z.infer<typeof avatarVariantSchema>
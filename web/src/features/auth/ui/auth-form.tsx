@@ -146,7 +146,9 @@ export function AuthForm({ error }: Props) {
       <CardFooter className='flex flex-col gap-3 items-center'>
         {error && (
           <div className='bg-red-600 opacity-60 text-sm p-2 rounded-lg w-full text-center text-white'>
-            {error === 'oauth_failed' ? 'Попробуйте войти другим способом' : error}
+            {error === 'oauth_failed' || error === 'provider_mismatch'
+              ? 'Попробуйте войти другим способом'
+              : error}
           </div>
         )}
 
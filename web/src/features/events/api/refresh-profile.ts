@@ -0,0 +1,6 @@
+'use server'
+import { revalidateTag } from 'next/cache'
+
+export async function refreshProfile(): Promise<void> {
+  revalidateTag('profile')
+}
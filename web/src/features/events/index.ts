@@ -0,0 +1 @@
+export { EventStream } from './ui/event-stream'
@@ -0,0 +1,28 @@
+'use client'
+import { API_URL } from '@/shared/constants'
+import { useRouter } from 'next/navigation'
+import { useEffect } from 'react'
+import { refreshProfile } from '../api/refresh-profile'
+
+// EventStream keeps the page in sync with server events. The browser
+// reconnects by itself and resumes from the last received event.
+export function EventStream() {
+  const router = useRouter()
+
+  useEffect(() => {
+    const source = new EventSource(`${API_URL}/events/stream`, { withCredentials: true })
+
+    const reload = async () => {
+      await refreshProfile()
+      router.refresh()
+    }
+
+    source.addEventListener('profile.updated', reload)
+    // events were missed while disconnected
+    source.addEventListener('resync', reload)
+
+    return () => source.close()
+  }, [router])
+
+  return null
+}
@@ -1,10 +1,9 @@
 'use client'
-import { Profile } from '@/entities/profile'
+import { Profile, avatarSrc, avatarSrcSet } from '@/entities/profile'
 import { logout } from '@/features/auth'
 import { Dropdown, DropdownTrigger, DropdownMenu, DropdownItem } from '@heroui/dropdown'
 import { User } from '@heroui/user'
 import { useRouter } from 'next/navigation'
-import { MEDIA_URL } from '@/shared/constants'
 
 type Props = {
   profile: Profile
@@ -18,7 +17,8 @@ export function ProfileDropdown({ profile }: Props) {
         <User
           as='button'
           avatarProps={{
-            src: `${MEDIA_URL}/${profile.avatar_id}`,
+            src: avatarSrc(profile, 80),
+            imgProps: { srcSet: avatarSrcSet(profile), sizes: '40px' },
           }}
           className='transition-transform cursor-pointer'
           classNames={{
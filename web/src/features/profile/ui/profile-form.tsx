@@ -6,8 +6,7 @@ import { Button } from '@heroui/button'
 import type { ChangeEvent, FormEvent } from 'react'
 import { addToast } from '@heroui/react'
 import { useRef, useState } from 'react'
-import { Profile } from '@/entities/profile'
-import { MEDIA_URL } from '@/shared/constants'
+import { Profile, avatarSrc, avatarSrcSet } from '@/entities/profile'
 import { Divider } from '@heroui/divider'
 import { updateProfile } from '../api/update-profile'
 import { useRouter } from 'next/navigation'
@@ -21,7 +20,9 @@ export function ProfileForm({ profile }: Props) {
   const router = useRouter()
 
   const fileInputRef = useRef<HTMLInputElement>(null)
-  const [preview, setPreview] = useState(`${MEDIA_URL}/${profile.avatar_id}`)
+  const [preview, setPreview] = useState(avatarSrc(profile, 400))
+  // the copies are stale once another picture is chosen
+  const [srcSet, setSrcSet] = useState(avatarSrcSet(profile))
 
   const providerLabelMap: Record<string, string> = {
     google: 'Google',
@@ -52,6 +53,7 @@ export function ProfileForm({ profile }: Props) {
       reader.onload = () => {
         if (typeof reader.result === 'string') {
           setPreview(reader.result)
+          setSrcSet(undefined)
         }
       }
       reader.readAsDataURL(file)
@@ -61,7 +63,14 @@ export function ProfileForm({ profile }: Props) {
   return (
     <Form onSubmit={handleSubmit} className='w-full max-w-md space-y-2 mt-2'>
       <div className='relative overflow-hidden group'>
-        <Image src={preview} alt='Profile icon' width={200} height={200} />
+        <Image
+          src={preview}
+          srcSet={srcSet}
+          sizes='200px'
+          alt='Profile icon'
+          width={200}
+          height={200}
+        />
         <input
           type='file'
           name='avatar'
@@ -0,0 +1,56 @@
+import { API_URL } from '@/shared/constants'
+
+const SERVICE_WORKER_URL = '/sw.js'
+
+export function isPushSupported(): boolean {
+  return typeof window !== 'undefined' && 'serviceWorker' in navigator && 'PushManager' in window
+}
+
+export async function getPushSubscription(): Promise<PushSubscription | null> {
+  const registration = await navigator.serviceWorker.getRegistration(SERVICE_WORKER_URL)
+  return (await registration?.pushManager.getSubscription()) ?? null
+}
+
+export async function subscribePush(): Promise<void> {
+  const keyRes = await fetch(`${API_URL}/notifications/push/public-key`, { credentials: 'include' })
+  if (!keyRes.ok) throw new Error('push notifications are disabled')
+  const { public_key: publicKey } = await keyRes.json()
+
+  const registration = await navigator.serviceWorker.register(SERVICE_WORKER_URL)
+  await navigator.serviceWorker.ready
+  const subscription = await registration.pushManager.subscribe({
+    userVisibleOnly: true,
+    applicationServerKey: decodeBase64Url(publicKey),
+  })
+
+  const { endpoint, keys } = subscription.toJSON()
+  const res = await fetch(`${API_URL}/notifications/push/subscriptions`, {
+    credentials: 'include',
+    method: 'POST',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify({ endpoint, keys }),
+  })
+  if (!res.ok) {
+    await subscription.unsubscribe()
+    throw new Error('failed to register push subscription')
+  }
+}
+
+export async function unsubscribePush(): Promise<void> {
+  const subscription = await getPushSubscription()
+  if (!subscription) return
+
+  await fetch(`${API_URL}/notifications/push/subscriptions`, {
+    credentials: 'include',
+    method: 'DELETE',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify({ endpoint: subscription.endpoint }),
+  })
+  await subscription.unsubscribe()
+}
+
+function decodeBase64Url(value: string): Uint8Array {
+  const base64 = value.replace(/-/g, '+').replace(/_/g, '/')
+  const raw = atob(base64.padEnd(base64.length + ((4 - (base64.length % 4)) % 4), '='))
+  return Uint8Array.from(raw, (c) => c.charCodeAt(0))
+}
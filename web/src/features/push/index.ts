@@ -0,0 +1 @@
+export { PushToggle } from './ui/push-toggle'
@@ -0,0 +1,49 @@
+'use client'
+import { Button } from '@heroui/button'
+import { addToast } from '@heroui/react'
+import { useEffect, useState } from 'react'
+import { getPushSubscription, isPushSupported, subscribePush, unsubscribePush } from '../api/push'
+
+// PushToggle enables or disables browser push notifications for the
+// current device.
+export function PushToggle() {
+  const [supported, setSupported] = useState(false)
+  const [subscribed, setSubscribed] = useState(false)
+  const [pending, setPending] = useState(false)
+
+  useEffect(() => {
+    if (!isPushSupported()) return
+    setSupported(true)
+    getPushSubscription().then((subscription) => setSubscribed(subscription !== null))
+  }, [])
+
+  if (!supported) return null
+
+  const toggle = async () => {
+    setPending(true)
+    try {
+      if (subscribed) {
+        await unsubscribePush()
+        setSubscribed(false)
+        addToast({ title: 'Push-уведомления отключены' })
+      } else {
+        await subscribePush()
+        setSubscribed(true)
+        addToast({ title: 'Push-уведомления включены' })
+      }
+    } catch {
+      addToast({
+        title: 'Не удалось изменить настройки push-уведомлений',
+        color: 'danger',
+      })
+    } finally {
+      setPending(false)
+    }
+  }
+
+  return (
+    <Button variant='bordered' className='w-full' isLoading={pending} onPress={toggle}>
+      {subscribed ? 'Отключить push-уведомления' : 'Включить push-уведомления'}
+    </Button>
+  )
+}
@@ -0,0 +1,9 @@
+import { API_URL } from '@/shared/constants'
+
+// unsubscribeEmail turns off the email category of a token from an email
+// link. It needs no session.
+export async function unsubscribeEmail(token: string): Promise<Response> {
+  return fetch(`${API_URL}/notifications/unsubscribe?token=${encodeURIComponent(token)}`, {
+    method: 'POST',
+  })
+}
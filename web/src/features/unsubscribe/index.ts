@@ -0,0 +1 @@
+export { UnsubscribeConfirm } from './ui/unsubscribe-confirm'
@@ -0,0 +1,69 @@
+'use client'
+import { Button } from '@heroui/button'
+import { Card, CardBody, CardHeader } from '@heroui/react'
+import Link from 'next/link'
+import { useState } from 'react'
+import { unsubscribeEmail } from '../api/unsubscribe'
+
+type Props = {
+  token?: string
+}
+
+const CATEGORIES: Record<string, string> = {
+  digest: 'дайджест предстоящих списаний',
+  reminders: 'напоминания о списаниях',
+}
+
+type State = 'idle' | 'pending' | 'done' | 'invalid' | 'failed'
+
+// UnsubscribeConfirm asks to confirm before following the link of an email,
+// so that link scanners of mail providers don't unsubscribe the user.
+export function UnsubscribeConfirm({ token }: Props) {
+  const [state, setState] = useState<State>(token ? 'idle' : 'invalid')
+  const [category, setCategory] = useState('')
+
+  const confirm = async () => {
+    if (!token) return
+    setState('pending')
+    try {
+      const response = await unsubscribeEmail(token)
+      if (response.status === 400) {
+        setState('invalid')
+        return
+      }
+      if (!response.ok) {
+        setState('failed')
+        return
+      }
+      const body = await response.json()
+      setCategory(body.category)
+      setState('done')
+    } catch {
+      setState('failed')
+    }
+  }
+
+  return (
+    <Card className='w-full max-w-md'>
+      <CardHeader className='text-xl font-semibold'>Отписка от писем</CardHeader>
+      <CardBody className='gap-4'>
+        {state === 'done' && (
+          <p>
+            Вы больше не будете получать {CATEGORIES[category] ?? 'эти письма'}. Включить их снова можно в{' '}
+            <Link href='/profile' className='underline'>
+              настройках уведомлений
+            </Link>
+            .
+          </p>
+        )}
+        {state === 'invalid' && <p>Ссылка для отписки недействительна.</p>}
+        {state === 'failed' && <p>Не удалось отписаться. Попробуйте позже.</p>}
+        {(state === 'idle' || state === 'pending' || state === 'failed') && (
+          <Button color='primary' isLoading={state === 'pending'} onPress={confirm}>
+            Отписаться
+          </Button>
+        )}
+      </CardBody>
+    </Card>
+  )
+}